@@ -1,23 +1,203 @@
 package app
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"net"
+	"net/http"
 	"ninth-learn/config"
+	"ninth-learn/model"
+	"ninth-learn/outbox"
 	"ninth-learn/repository"
 	"ninth-learn/route"
+	"ninth-learn/rpc"
+	"ninth-learn/scheduler"
 	"ninth-learn/service"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
 )
 
 var router = gin.New()
 
+const (
+	// outboxRetention is how long published events are kept around
+	outboxRetention = 7 * 24 * time.Hour
+	// shutdownTimeout is how long requests under way get to finish
+	shutdownTimeout = 30 * time.Second
+)
+
 func StartApplication() {
-	repo := repository.NewRepo(config.PSQL.DB)
-	app := service.NewService(repo)
-	route.RegisterApi(router, app)
+	repo := repository.NewRepo(config.PSQL.DB, config.Blobs)
+
+	// books looked up by id are read through the cache
+	store, err := config.BookCache()
+	if err != nil {
+		log.Fatalf("open book cache: %v", err)
+	}
+	books := repository.NewBookCache(store, config.BookCacheTTL(), config.BookCacheNegativeTTL())
+	books.Stats.Publish("book_cache")
+
+	// book changes purge the responses a CDN keeps of them
+	var opts []service.Option
+	purger, err := config.CachePurger()
+	if err != nil {
+		log.Fatalf("open CDN purger: %v", err)
+	}
+	if purger != nil {
+		defer purger.Close()
+		opts = append(opts, service.WithCachePurger(purger))
+	}
+
+	// books written by the other replicas reach the watchers and the
+	// similarity index of this one. without LISTEN/NOTIFY the service
+	// notifies the listener of its own writes
+	changeListener, err := config.ChangeListener()
+	if err != nil {
+		log.Fatalf("open change listener: %v", err)
+	}
+	defer changeListener.Close()
+	if notifier, ok := changeListener.(service.ChangeNotifier); ok {
+		opts = append(opts, service.WithChangeNotifier(notifier))
+	}
+
+	app := service.NewService(repository.NewCachedRepo(repo, books), config.LoanPolicy(), opts...)
+	route.RegisterApi(router, app, config.CacheControl())
+	route.RegisterGraphQL(router, app, config.GraphQLLimits())
+
+	publisher, err := config.OutboxPublisher()
+	if err != nil {
+		log.Fatalf("open outbox publisher: %v", err)
+	}
+	defer publisher.Close()
+	// the relay queues the webhooks of the events it publishes, and every
+	// instance tells its watchers about the events once published
+	relay := outbox.NewRelay(repo, publisher, outbox.PublisherFunc(app.QueueWebhooks))
+	follower := outbox.NewFollower(repo, func(m outbox.Message) {
+		if err := app.BroadcastBookEvent(m); err != nil {
+			log.Printf("broadcast event %d: %v", m.ID, err)
+		}
+	})
+
+	// the root service is not tenant scoped, the jobs sweep every tenant
+	jobs := scheduler.New(config.SchedulerInterval(),
+		scheduler.Job{Name: "expire holds", Run: func() error {
+			_, err := app.ExpireHolds()
+			return err
+		}},
+		scheduler.Job{Name: "accrue fines", Run: func() error {
+			_, err := app.AccrueFines()
+			return err
+		}},
+		scheduler.Job{Name: "prune outbox", Run: func() error {
+			_, err := relay.Prune(outboxRetention)
+			return err
+		}},
+	)
+	jobs.Start()
+	defer jobs.Stop()
+
+	// webhooks are retried on a much shorter cycle than the daily sweeps
+	deliveries := scheduler.New(config.WebhookInterval(),
+		scheduler.Job{Name: "deliver webhooks", Run: func() error {
+			_, err := app.DeliverWebhooks()
+			return err
+		}},
+	)
+	deliveries.Start()
+	defer deliveries.Stop()
+
+	// book events leave through the outbox, written along with the books
+	events := scheduler.New(config.OutboxInterval(),
+		scheduler.Job{Name: "relay outbox", Run: func() error {
+			_, err := relay.Run()
+			return err
+		}},
+		scheduler.Job{Name: "follow outbox", Run: func() error {
+			_, err := follower.Run()
+			return err
+		}},
+	)
+	events.Start()
+	defer events.Stop()
+
+	// an interrupt or SIGTERM shuts the servers down, and ends the event
+	// streams and the change listener along with them
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	go func() {
+		err := changeListener.Listen(ctx, func(change model.BookChange) {
+			books.Invalidate(change.TenantID, change.BookID)
+			if err := app.ReplicateBookChange(change); err != nil {
+				log.Printf("replicate book %d: %v", change.BookID, err)
+			}
+		}, func() {
+			books.Purge()
+			app.BookChangesLost()
+		})
+		if err != nil && ctx.Err() == nil {
+			log.Printf("book changes stopped: %v", err)
+		}
+	}()
+
+	// internal services reach the catalogue over gRPC on its own port
+	grpcServer := rpc.NewServer(app)
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+	if err != nil {
+		log.Fatalf("listen for gRPC on port %s: %v", grpcPort, err)
+	}
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Printf("gRPC server stopped: %v", err)
+		}
+	}()
+
+	server := &http.Server{
+		Addr:        fmt.Sprintf(":%s", os.Getenv("APP_PORT")),
+		Handler:     router,
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+	case err := <-serveErr:
+		log.Printf("HTTP server stopped: %v", err)
+	}
+	log.Printf("shutting down")
+	shutdown(server, grpcServer)
+}
+
+// shutdown lets the requests under way on both servers finish, those still
+// running after shutdownTimeout are cut off
+func shutdown(server *http.Server, grpcServer *grpc.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
 
-	port := os.Getenv("APP_PORT")
-	router.Run(fmt.Sprintf(":%s", port))
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("HTTP shutdown: %v", err)
+	}
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+	}
 }
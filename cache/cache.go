@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"expvar"
+	"sync/atomic"
+	"time"
+)
+
+// Store keeps values for a while. a value may be gone before its time is
+// up, callers go back to the source on a miss
+type Store interface {
+	Get(key string) (value []byte, ok bool, err error)
+	Set(key string, value []byte, ttl time.Duration) error
+	Delete(keys ...string) error
+}
+
+// Purger is a Store that can drop everything it holds
+type Purger interface {
+	Purge()
+}
+
+// Stats counts the lookups of a cache, negative hits are hits on a record
+// of something missing
+type Stats struct {
+	Hits         atomic.Int64
+	NegativeHits atomic.Int64
+	Misses       atomic.Int64
+	Errors       atomic.Int64
+}
+
+type StatsSnapshot struct {
+	Hits         int64   `json:"hits"`
+	NegativeHits int64   `json:"negative_hits"`
+	Misses       int64   `json:"misses"`
+	Errors       int64   `json:"errors"`
+	HitRatio     float64 `json:"hit_ratio"`
+}
+
+func (s *Stats) Snapshot() StatsSnapshot {
+	snapshot := StatsSnapshot{
+		Hits:         s.Hits.Load(),
+		NegativeHits: s.NegativeHits.Load(),
+		Misses:       s.Misses.Load(),
+		Errors:       s.Errors.Load(),
+	}
+	if total := snapshot.Hits + snapshot.Misses; total > 0 {
+		snapshot.HitRatio = float64(snapshot.Hits) / float64(total)
+	}
+	return snapshot
+}
+
+// Publish exports the stats under the name on /debug/vars
+func (s *Stats) Publish(name string) {
+	if expvar.Get(name) == nil {
+		expvar.Publish(name, expvar.Func(func() interface{} { return s.Snapshot() }))
+	}
+}
@@ -0,0 +1,293 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Redis keeps values in a server speaking the Redis protocol, shared by
+// every instance. commands run concurrently on a pool of connections, one
+// that fails is dropped and a new one dialled for the next command
+type Redis struct {
+	addr     string
+	user     string
+	password string
+	db       int
+	timeout  time.Duration
+	poolSize int
+
+	// slots holds a token for every connection in use
+	slots  chan struct{}
+	mu     sync.Mutex
+	idle   []*redisConn
+	closed bool
+}
+
+// redisConn is a pooled connection with its reply reader
+type redisConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// defaultPoolSize is the number of connections without a pool_size
+const defaultPoolSize = 10
+
+var (
+	// errNil is the reply to a GET of a key that is not there
+	errNil = errors.New("redis: nil")
+	// errPoolTimeout is returned when every connection stayed busy for the
+	// timeout
+	errPoolTimeout = errors.New("redis: connection pool timeout")
+	errClosed      = errors.New("redis: client is closed")
+)
+
+// NewRedis takes a redis://[[user]:password@]host[:port][/db][?pool_size=n]
+// URL, each command gives up after the timeout, waiting for a free
+// connection included. nothing is dialled before the first command
+func NewRedis(rawURL string, timeout time.Duration) (*Redis, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "redis" || u.Hostname() == "" {
+		return nil, fmt.Errorf("unsupported Redis URL %q", rawURL)
+	}
+
+	c := &Redis{addr: u.Host, timeout: timeout, poolSize: defaultPoolSize}
+	if u.Port() == "" {
+		c.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		c.user = u.User.Username()
+		c.password, _ = u.User.Password()
+	}
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		if c.db, err = strconv.Atoi(db); err != nil {
+			return nil, fmt.Errorf("unsupported Redis database %q", db)
+		}
+	}
+	if size := u.Query().Get("pool_size"); size != "" {
+		if c.poolSize, err = strconv.Atoi(size); err != nil || c.poolSize < 1 {
+			return nil, fmt.Errorf("unsupported Redis pool_size %q", size)
+		}
+	}
+	c.slots = make(chan struct{}, c.poolSize)
+	return c, nil
+}
+
+func (c *Redis) Get(key string) ([]byte, bool, error) {
+	reply, err := c.do("GET", []byte(key))
+	if err == errNil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return reply, true, nil
+}
+
+func (c *Redis) Set(key string, value []byte, ttl time.Duration) error {
+	ms := ttl.Milliseconds()
+	if ms < 1 {
+		ms = 1
+	}
+	_, err := c.do("SET", []byte(key), value, []byte("PX"), []byte(strconv.FormatInt(ms, 10)))
+	return err
+}
+
+func (c *Redis) Delete(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	args := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		args = append(args, []byte(key))
+	}
+	_, err := c.do("DEL", args...)
+	return err
+}
+
+// Close closes the idle connections, the ones in use are closed when their
+// command is done
+func (c *Redis) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	for _, conn := range c.idle {
+		conn.Close()
+	}
+	c.idle = nil
+	return nil
+}
+
+func (c *Redis) do(command string, args ...[]byte) ([]byte, error) {
+	conn, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+	reply, err := conn.roundTrip(c.timeout, command, args...)
+	c.put(conn, err != nil && err != errNil && !isServerError(err))
+	return reply, err
+}
+
+// get takes an idle connection or dials one, waiting for a free slot when
+// the pool is in use
+func (c *Redis) get() (*redisConn, error) {
+	if err := c.acquire(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		<-c.slots
+		return nil, errClosed
+	}
+	if n := len(c.idle); n > 0 {
+		conn := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		c.mu.Unlock()
+		return conn, nil
+	}
+	c.mu.Unlock()
+
+	conn, err := c.connect()
+	if err != nil {
+		<-c.slots
+		return nil, err
+	}
+	return conn, nil
+}
+
+// acquire takes a slot, waiting up to the timeout for one to be freed
+func (c *Redis) acquire() error {
+	select {
+	case c.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	if c.timeout <= 0 {
+		c.slots <- struct{}{}
+		return nil
+	}
+
+	timer := time.NewTimer(c.timeout)
+	defer timer.Stop()
+	select {
+	case c.slots <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return errPoolTimeout
+	}
+}
+
+// put hands the connection back to the pool, a broken one is closed
+func (c *Redis) put(conn *redisConn, broken bool) {
+	c.mu.Lock()
+	if broken || c.closed {
+		conn.Close()
+	} else {
+		c.idle = append(c.idle, conn)
+	}
+	c.mu.Unlock()
+	<-c.slots
+}
+
+func (c *Redis) connect() (*redisConn, error) {
+	nc, err := net.DialTimeout("tcp", c.addr, c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	conn := &redisConn{Conn: nc, r: bufio.NewReader(nc)}
+
+	if c.password != "" {
+		args := [][]byte{[]byte(c.password)}
+		if c.user != "" {
+			args = [][]byte{[]byte(c.user), []byte(c.password)}
+		}
+		if _, err := conn.roundTrip(c.timeout, "AUTH", args...); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	if c.db != 0 {
+		if _, err := conn.roundTrip(c.timeout, "SELECT", []byte(strconv.Itoa(c.db))); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+func (c *redisConn) roundTrip(timeout time.Duration, command string, args ...[]byte) ([]byte, error) {
+	var frame bytes.Buffer
+	fmt.Fprintf(&frame, "*%d\r\n$%d\r\n%s\r\n", len(args)+1, len(command), command)
+	for _, arg := range args {
+		fmt.Fprintf(&frame, "$%d\r\n", len(arg))
+		frame.Write(arg)
+		frame.WriteString("\r\n")
+	}
+
+	if timeout > 0 {
+		c.SetDeadline(time.Now().Add(timeout))
+	}
+	if _, err := c.Write(frame.Bytes()); err != nil {
+		return nil, err
+	}
+	return c.readReply()
+}
+
+// serverError is an error reply, the connection stays usable
+type serverError string
+
+func (e serverError) Error() string {
+	return "redis: " + string(e)
+}
+
+func isServerError(err error) bool {
+	_, ok := err.(serverError)
+	return ok
+}
+
+// readReply reads a simple string, error, integer or bulk string reply
+func (c *redisConn) readReply() ([]byte, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+', ':':
+		return []byte(line[1:]), nil
+	case '-':
+		return nil, serverError(line[1:])
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: bad bulk length %q", line)
+		}
+		if n < 0 {
+			return nil, errNil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, data); err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
@@ -0,0 +1,121 @@
+package cdn
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// queueSize is how many purges may wait for the backend, more are dropped
+	// and left to expire
+	queueSize = 256
+	// purgeTimeout bounds a call to the backend
+	purgeTimeout = 10 * time.Second
+)
+
+// Backend drops the cached responses tagged with any of the keys
+type Backend interface {
+	Purge(ctx context.Context, keys []string) error
+}
+
+// Purger hands surrogate keys to the backend in the background, the keys
+// piling up while a purge is under way go out together in the next one
+type Purger struct {
+	backend Backend
+	queue   chan []string
+	done    chan struct{}
+	once    sync.Once
+}
+
+func NewPurger(backend Backend) *Purger {
+	p := &Purger{backend: backend, queue: make(chan []string, queueSize), done: make(chan struct{})}
+	go p.run()
+	return p
+}
+
+// Purge never blocks the writer
+func (p *Purger) Purge(keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	select {
+	case p.queue <- keys:
+	default:
+		log.Printf("cdn: purge queue full, dropped %s", strings.Join(keys, " "))
+	}
+}
+
+// Close sends the purges still queued and stops
+func (p *Purger) Close() error {
+	p.once.Do(func() { close(p.queue) })
+	<-p.done
+	return nil
+}
+
+func (p *Purger) run() {
+	defer close(p.done)
+	for keys := range p.queue {
+		batch := map[string]bool{}
+		for _, key := range keys {
+			batch[key] = true
+		}
+	drain:
+		for {
+			select {
+			case more, ok := <-p.queue:
+				if !ok {
+					break drain
+				}
+				for _, key := range more {
+					batch[key] = true
+				}
+			default:
+				break drain
+			}
+		}
+		p.send(batch)
+	}
+}
+
+func (p *Purger) send(batch map[string]bool) {
+	keys := make([]string, 0, len(batch))
+	for key := range batch {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	ctx, cancel := context.WithTimeout(context.Background(), purgeTimeout)
+	defer cancel()
+	if err := p.backend.Purge(ctx, keys); err != nil {
+		log.Printf("cdn: purge %s: %v", strings.Join(keys, " "), err)
+	}
+}
+
+// WriterBackend writes a line per purge, for CDNs fed from logs and for
+// development
+type WriterBackend struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewWriterBackend(w io.Writer) *WriterBackend {
+	return &WriterBackend{w: w}
+}
+
+func NewStdoutBackend() *WriterBackend {
+	return NewWriterBackend(os.Stdout)
+}
+
+func (b *WriterBackend) Purge(ctx context.Context, keys []string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, err := fmt.Fprintf(b.w, "purge %s\n", strings.Join(keys, " "))
+	return err
+}
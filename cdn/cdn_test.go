@@ -0,0 +1,119 @@
+package cdn
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder blocks each purge until released
+type recorder struct {
+	mu      sync.Mutex
+	purges  [][]string
+	release chan struct{}
+}
+
+func (r *recorder) Purge(ctx context.Context, keys []string) error {
+	if r.release != nil {
+		<-r.release
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.purges = append(r.purges, keys)
+	return nil
+}
+
+func Test_Purger(t *testing.T) {
+	r := &recorder{release: make(chan struct{})}
+	p := NewPurger(r)
+
+	// the first purge holds up the rest, which go out together
+	p.Purge("book-1", "books-1")
+	p.Purge()
+	p.Purge("book-2", "books-1")
+	p.Purge("book-3", "books-1")
+	close(r.release)
+	assert.NoError(t, p.Close())
+
+	assert.LessOrEqual(t, len(r.purges), 2)
+	purged := map[string]int{}
+	for _, keys := range r.purges {
+		for _, key := range keys {
+			purged[key]++
+		}
+	}
+	assert.Equal(t, 1, purged["book-1"])
+	assert.Equal(t, 1, purged["book-2"])
+	assert.Equal(t, 1, purged["book-3"])
+	assert.LessOrEqual(t, purged["books-1"], 2)
+}
+
+func Test_Purger_Full(t *testing.T) {
+	r := &recorder{release: make(chan struct{})}
+	p := NewPurger(r)
+
+	// the writer never waits on a stuck backend
+	for i := 0; i < 2*queueSize; i++ {
+		p.Purge(fmt.Sprintf("book-%d", i))
+	}
+	close(r.release)
+	assert.NoError(t, p.Close())
+}
+
+type failing struct{}
+
+func (failing) Purge(ctx context.Context, keys []string) error {
+	return errors.New("cdn unavailable")
+}
+
+func Test_Purger_Failure(t *testing.T) {
+	p := NewPurger(failing{})
+	p.Purge("book-1")
+	assert.NoError(t, p.Close())
+}
+
+func Test_WriterBackend(t *testing.T) {
+	var buf bytes.Buffer
+	b := NewWriterBackend(&buf)
+	assert.NoError(t, b.Purge(context.Background(), []string{"book-1", "books-1"}))
+	assert.Equal(t, "purge book-1 books-1\n", buf.String())
+}
+
+func Test_FastlyBackend(t *testing.T) {
+	var requests []*http.Request
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		w.WriteHeader(status)
+		fmt.Fprint(w, `{"status":"ok"}`)
+	}))
+	defer server.Close()
+
+	b := NewFastlyBackend(server.URL+"/", "svc 1", "secret", true)
+	keys := make([]string, fastlyMaxKeys+1)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("book-%d", i)
+	}
+	assert.NoError(t, b.Purge(context.Background(), keys))
+
+	assert.Len(t, requests, 2, "split at the key limit")
+	r := requests[0]
+	assert.Equal(t, http.MethodPost, r.Method)
+	assert.Equal(t, "/service/svc%201/purge", r.URL.EscapedPath())
+	assert.Equal(t, "secret", r.Header.Get("Fastly-Key"))
+	assert.Equal(t, "1", r.Header.Get("Fastly-Soft-Purge"))
+	assert.Len(t, strings.Fields(r.Header.Get("Surrogate-Key")), fastlyMaxKeys)
+	assert.Equal(t, "book-256", requests[1].Header.Get("Surrogate-Key"))
+
+	status = http.StatusUnauthorized
+	err := b.Purge(context.Background(), []string{"book-1"})
+	assert.EqualError(t, err, `fastly answered 401 Unauthorized: {"status":"ok"}`)
+}
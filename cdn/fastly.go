@@ -0,0 +1,72 @@
+package cdn
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// fastlyMaxKeys is the most keys Fastly takes in one purge
+const fastlyMaxKeys = 256
+
+// FastlyBackend purges by surrogate key through the Fastly API. soft purges
+// mark the responses stale, so they can still be served while the origin
+// is unavailable
+type FastlyBackend struct {
+	endpoint string
+	token    string
+	soft     bool
+	client   *http.Client
+}
+
+func NewFastlyBackend(apiURL, serviceID, token string, soft bool) *FastlyBackend {
+	return &FastlyBackend{
+		endpoint: strings.TrimSuffix(apiURL, "/") + "/service/" + url.PathEscape(serviceID) + "/purge",
+		token:    token,
+		soft:     soft,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (b *FastlyBackend) Purge(ctx context.Context, keys []string) error {
+	for len(keys) > 0 {
+		n := len(keys)
+		if n > fastlyMaxKeys {
+			n = fastlyMaxKeys
+		}
+		if err := b.purge(ctx, keys[:n]); err != nil {
+			return err
+		}
+		keys = keys[n:]
+	}
+	return nil
+}
+
+func (b *FastlyBackend) purge(ctx context.Context, keys []string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Fastly-Key", b.token)
+	req.Header.Set("Surrogate-Key", strings.Join(keys, " "))
+	req.Header.Set("Accept", "application/json")
+	if b.soft {
+		req.Header.Set("Fastly-Soft-Purge", "1")
+	}
+
+	res, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 4<<10))
+		return fmt.Errorf("fastly answered %s: %s", res.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
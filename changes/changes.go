@@ -0,0 +1,104 @@
+package changes
+
+import (
+	"context"
+	"ninth-learn/model"
+	"sync"
+)
+
+// Channel is the notification channel the books trigger notifies on
+const Channel = "book_changes"
+
+// busBuffer is how many changes a MemoryBus holds for a slow listener
+const busBuffer = 256
+
+// Listener passes on the book changes made by other instances until the
+// context is done. lost is called when changes may have been missed, after
+// the connection to the database dropped or the listener fell behind, so
+// anything derived from the books has to be rebuilt
+type Listener interface {
+	Listen(ctx context.Context, handle func(model.BookChange), lost func()) error
+	Close() error
+}
+
+// MemoryBus carries changes within the process, for drivers without
+// LISTEN/NOTIFY and for tests. the writers notify it in place of the books
+// trigger, every listener gets each change
+type MemoryBus struct {
+	mu        sync.Mutex
+	listeners map[chan model.BookChange]bool
+	closed    bool
+}
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{listeners: map[chan model.BookChange]bool{}}
+}
+
+// Notify hands the change to the listeners without waiting on them, one
+// that fell behind misses it and is told so
+func (b *MemoryBus) Notify(change model.BookChange) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.listeners {
+		select {
+		case ch <- change:
+		default:
+			// the zero change marks the overflow
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- model.BookChange{}
+		}
+	}
+}
+
+func (b *MemoryBus) Listen(ctx context.Context, handle func(model.BookChange), lost func()) error {
+	ch := make(chan model.BookChange, busBuffer)
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.listeners[ch] = true
+	b.mu.Unlock()
+
+	defer func() {
+		b.mu.Lock()
+		delete(b.listeners, ch)
+		b.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case change, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if change == (model.BookChange{}) {
+				lost()
+				continue
+			}
+			handle(change)
+		}
+	}
+}
+
+// Close ends every Listen
+func (b *MemoryBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	for ch := range b.listeners {
+		close(ch)
+		delete(b.listeners, ch)
+	}
+	return nil
+}
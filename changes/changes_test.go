@@ -0,0 +1,85 @@
+package changes
+
+import (
+	"context"
+	"ninth-learn/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MemoryBus(t *testing.T) {
+	bus := NewMemoryBus()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan model.BookChange, busBuffer)
+	done := make(chan error)
+	go func() {
+		done <- bus.Listen(ctx, func(c model.BookChange) { received <- c }, func() {})
+	}()
+
+	change := model.BookChange{Op: model.BookChangeUpdate, BookID: 10, TenantID: 1, Origin: "a"}
+	assert.Eventually(t, func() bool {
+		bus.Notify(change)
+		select {
+		case c := <-received:
+			return assert.Equal(t, change, c)
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, time.Second, time.Millisecond)
+
+	bus.Close()
+	assert.NoError(t, <-done)
+}
+
+func Test_MemoryBus_Overflow(t *testing.T) {
+	bus := NewMemoryBus()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	block := make(chan struct{})
+	lost := make(chan struct{}, 1)
+	handled := 0
+	listening := make(chan struct{})
+	go bus.Listen(ctx, func(model.BookChange) {
+		if handled == 0 {
+			close(listening)
+			<-block
+		}
+		handled++
+	}, func() { lost <- struct{}{} })
+
+	assert.Eventually(t, func() bool {
+		bus.Notify(model.BookChange{Op: model.BookChangeInsert, BookID: 1})
+		select {
+		case <-listening:
+			return true
+		case <-time.After(time.Millisecond):
+			return false
+		}
+	}, time.Second, time.Millisecond)
+
+	// the notifier never waits, the listener is told it missed changes
+	for i := 0; i <= busBuffer; i++ {
+		bus.Notify(model.BookChange{Op: model.BookChangeUpdate, BookID: int64(i + 2)})
+	}
+	close(block)
+
+	select {
+	case <-lost:
+	case <-time.After(time.Second):
+		t.Fatal("overflow not reported")
+	}
+	cancel()
+}
+
+func Test_parseChange(t *testing.T) {
+	change, err := parseChange(`{"op":"DELETE","id":42,"tenant_id":3,"origin":"web-1"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, model.BookChange{Op: model.BookChangeDelete, BookID: 42, TenantID: 3, Origin: "web-1"}, change)
+
+	_, err = parseChange(`not json`)
+	assert.Error(t, err)
+}
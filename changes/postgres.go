@@ -0,0 +1,88 @@
+package changes
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"ninth-learn/model"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// pingInterval is how long a quiet connection goes unchecked, a dead one
+// is noticed and reconnected within it
+const pingInterval = 90 * time.Second
+
+// PostgresListener holds a LISTEN connection of its own, reconnecting with
+// a backoff doubling from minBackoff up to maxBackoff
+type PostgresListener struct {
+	listener *pq.Listener
+	// origin is the application_name of this instance, its own changes
+	// are skipped
+	origin string
+}
+
+func NewPostgresListener(dsn, origin string, minBackoff, maxBackoff time.Duration) *PostgresListener {
+	report := func(event pq.ListenerEventType, err error) {
+		switch event {
+		case pq.ListenerEventDisconnected:
+			log.Printf("book changes: connection lost: %v", err)
+		case pq.ListenerEventReconnected:
+			log.Printf("book changes: reconnected")
+		case pq.ListenerEventConnectionAttemptFailed:
+			log.Printf("book changes: reconnect failed: %v", err)
+		}
+	}
+	return &PostgresListener{
+		listener: pq.NewListener(dsn, minBackoff, maxBackoff, report),
+		origin:   origin,
+	}
+}
+
+func (l *PostgresListener) Listen(ctx context.Context, handle func(model.BookChange), lost func()) error {
+	if err := l.listener.Listen(Channel); err != nil {
+		return err
+	}
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ping.C:
+			// a failed ping drops the connection, the listener reconnects
+			go l.listener.Ping()
+		case n, ok := <-l.listener.Notify:
+			if !ok {
+				return nil
+			}
+			if n == nil {
+				// notifications sent while reconnecting are gone
+				lost()
+				continue
+			}
+			change, err := parseChange(n.Extra)
+			if err != nil {
+				log.Printf("book changes: %v", err)
+				continue
+			}
+			if change.Origin == l.origin {
+				continue
+			}
+			handle(change)
+		}
+	}
+}
+
+func (l *PostgresListener) Close() error {
+	return l.listener.Close()
+}
+
+func parseChange(payload string) (model.BookChange, error) {
+	change := model.BookChange{}
+	err := json.Unmarshal([]byte(payload), &change)
+	return change, err
+}
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"ninth-learn/model"
+)
+
+func (c *Client) CreateAuthor(ctx context.Context, in model.AuthorRequest) (res model.Author, err error) {
+	err = c.call(ctx, http.MethodPost, "/authors", nil, in, &res)
+	return res, err
+}
+
+func (c *Client) GetAuthorById(ctx context.Context, id int64) (res model.Author, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/authors/%s", id), nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) GetAuthors(ctx context.Context) (res []model.Author, err error) {
+	err = c.call(ctx, http.MethodGet, "/authors", nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) UpdateAuthor(ctx context.Context, id int64, in model.AuthorRequest) (res model.Author, err error) {
+	err = c.call(ctx, http.MethodPut, pathf("/authors/%s", id), nil, in, &res)
+	return res, err
+}
+
+func (c *Client) DeleteAuthor(ctx context.Context, id int64) error {
+	return c.call(ctx, http.MethodDelete, pathf("/authors/%s", id), nil, nil, nil)
+}
+
+// GetAuthorBooks returns the books the author contributed to
+func (c *Client) GetAuthorBooks(ctx context.Context, id int64) (res []model.Book, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/authors/%s/books", id), nil, nil, &res)
+	return res, err
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"ninth-learn/model"
+	"strconv"
+	"strings"
+)
+
+// BookOptions tailor GetBookById
+type BookOptions struct {
+	// MemberID asks for the hold queue position of the member
+	MemberID int64
+	// AcceptLanguage picks the translation of title and description
+	AcceptLanguage string
+}
+
+// CreateBook creates a book, likely duplicates already in the catalogue come
+// back in its Duplicates
+func (c *Client) CreateBook(ctx context.Context, in model.BookRequest) (res model.Book, err error) {
+	err = c.call(ctx, http.MethodPost, "/books", nil, in, &res)
+	return res, err
+}
+
+// GetBookById returns a book with its series and related works, the id of a
+// book merged into another resolves to that book
+func (c *Client) GetBookById(ctx context.Context, id int64, opts BookOptions) (res model.Book, err error) {
+	query := url.Values{}
+	if opts.MemberID != 0 {
+		query.Set("member_id", strconv.FormatInt(opts.MemberID, 10))
+	}
+
+	req := request{method: http.MethodGet, path: pathf("/books/%s", id), query: query}
+	if opts.AcceptLanguage != "" {
+		req.header = http.Header{"Accept-Language": {opts.AcceptLanguage}}
+	}
+	err = c.callRequest(ctx, req, &res)
+	return res, err
+}
+
+// GetBookByISBN accepts either form of the ISBN, with or without hyphens
+func (c *Client) GetBookByISBN(ctx context.Context, isbn string) (res model.Book, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/books/isbn/%s", isbn), nil, nil, &res)
+	return res, err
+}
+
+// GetBooks returns the books matching the filter. the endpoint has no pages,
+// Limit and Offset of the filter are ignored.
+func (c *Client) GetBooks(ctx context.Context, filter model.BookFilter) (res []model.Book, err error) {
+	err = c.call(ctx, http.MethodGet, "/books", bookQuery(filter), nil, &res)
+	return res, err
+}
+
+func (c *Client) UpdateBook(ctx context.Context, id int64, in model.BookRequest) (res model.Book, err error) {
+	err = c.call(ctx, http.MethodPut, pathf("/books/%s", id), nil, in, &res)
+	return res, err
+}
+
+// DeleteBook refuses to delete a book with copies unless forced
+func (c *Client) DeleteBook(ctx context.Context, id int64, force bool) error {
+	query := url.Values{}
+	if force {
+		query.Set("force", "true")
+	}
+	return c.call(ctx, http.MethodDelete, pathf("/books/%s", id), query, nil, nil)
+}
+
+// bookQuery is the GET /books query string of a filter
+func bookQuery(filter model.BookFilter) url.Values {
+	query := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	set("q", filter.Query)
+	if filter.CategoryID != 0 {
+		query.Set("category", strconv.FormatInt(filter.CategoryID, 10))
+	}
+	set("tags", strings.Join(filter.Tags, ","))
+	if filter.MatchAllTags {
+		query.Set("tags_match", "all")
+	}
+	set("publisher", filter.Publisher)
+	set("language", filter.Language)
+	set("format", filter.Format)
+	set("series", filter.SeriesName)
+	if filter.PublishedAfter != nil {
+		query.Set("published_after", filter.PublishedAfter.String())
+	}
+	if filter.PublishedBefore != nil {
+		query.Set("published_before", filter.PublishedBefore.String())
+	}
+	set("sort", filter.Sort)
+	return query
+}
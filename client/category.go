@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"ninth-learn/model"
+)
+
+func (c *Client) CreateCategory(ctx context.Context, in model.CategoryRequest) (res model.Category, err error) {
+	err = c.call(ctx, http.MethodPost, "/categories", nil, in, &res)
+	return res, err
+}
+
+func (c *Client) GetCategoryById(ctx context.Context, id int64) (res model.Category, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/categories/%s", id), nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) GetCategories(ctx context.Context) (res []model.Category, err error) {
+	err = c.call(ctx, http.MethodGet, "/categories", nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) UpdateCategory(ctx context.Context, id int64, in model.CategoryRequest) (res model.Category, err error) {
+	err = c.call(ctx, http.MethodPut, pathf("/categories/%s", id), nil, in, &res)
+	return res, err
+}
+
+func (c *Client) DeleteCategory(ctx context.Context, id int64) error {
+	return c.call(ctx, http.MethodDelete, pathf("/categories/%s", id), nil, nil, nil)
+}
@@ -0,0 +1,235 @@
+// Package client is a typed Go client of the books REST API, one method per
+// endpoint of handler.HttpServer.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRetries    = 3
+	defaultBackoff    = 200 * time.Millisecond
+	defaultMaxBackoff = 5 * time.Second
+)
+
+// Client calls the books API of one tenant
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	token      string
+	tenant     string
+	retries    int
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+type Option func(*Client)
+
+// New returns a client of the API served at baseURL, e.g.
+// https://books.example.com
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, errors.New("base URL must be absolute")
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		retries:    defaultRetries,
+		backoff:    defaultBackoff,
+		maxBackoff: defaultMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// WithHTTPClient sends the requests through hc
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithToken authenticates the requests with a bearer token
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithTenant names the tenant of the requests in the X-Tenant-ID header
+func WithTenant(slug string) Option {
+	return func(c *Client) {
+		c.tenant = slug
+	}
+}
+
+// WithRetries retries a request at most n times, waiting backoff before the
+// first retry and twice as long before each following one
+func WithRetries(n int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = n
+		c.backoff = backoff
+	}
+}
+
+// request is a call to the API, its body is kept to be sent again on retries
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	header      http.Header
+	body        []byte
+	contentType string
+}
+
+// call sends a JSON request and decodes the data of the response into out,
+// out may be nil
+func (c *Client) call(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+	req := request{method: method, path: path, query: query}
+	if in != nil {
+		body, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		req.body, req.contentType = body, "application/json"
+	}
+	return c.callRequest(ctx, req, out)
+}
+
+func (c *Client) callRequest(ctx context.Context, req request, out interface{}) error {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+	envelope := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return err
+	}
+	return json.Unmarshal(envelope.Data, out)
+}
+
+// send returns the response to a request, an error status is returned as an
+// *Error. responses of 429 are retried, server errors too unless the request
+// is a POST, which might have been applied before the server failed.
+func (c *Client) send(ctx context.Context, r request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := c.newRequest(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode < 400 {
+			return resp, nil
+		}
+
+		apiErr := errorFrom(resp)
+		resp.Body.Close()
+		if attempt >= c.retries || !retryable(r.method, resp.StatusCode) {
+			return nil, apiErr
+		}
+
+		wait := c.delay(attempt)
+		if after, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && time.Duration(after)*time.Second > wait {
+			wait = time.Duration(after) * time.Second
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) newRequest(ctx context.Context, r request) (*http.Request, error) {
+	u := *c.baseURL
+	u.Path += r.path
+	u.RawQuery = r.query.Encode()
+
+	var body io.Reader
+	if r.body != nil {
+		body = bytes.NewReader(r.body)
+	}
+	req, err := http.NewRequestWithContext(ctx, r.method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range r.header {
+		req.Header[key] = values
+	}
+	if r.contentType != "" {
+		req.Header.Set("Content-Type", r.contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.tenant != "" {
+		req.Header.Set("X-Tenant-ID", c.tenant)
+	}
+	return req, nil
+}
+
+func retryable(method string, status int) bool {
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	return status >= 500 && method != http.MethodPost
+}
+
+// delay doubles the backoff with every attempt, jittered so that clients
+// failing together do not retry together
+func (c *Client) delay(attempt int) time.Duration {
+	d := c.backoff << attempt
+	if d <= 0 || d > c.maxBackoff {
+		d = c.maxBackoff
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// pathf formats a path, escaping the string segments
+func pathf(format string, args ...interface{}) string {
+	parts := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case int64:
+			parts[i] = strconv.FormatInt(v, 10)
+		case string:
+			parts[i] = url.PathEscape(v)
+		default:
+			parts[i] = v
+		}
+	}
+	return fmt.Sprintf(format, parts...)
+}
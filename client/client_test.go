@@ -0,0 +1,391 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"ninth-learn/outbox"
+	"ninth-learn/repository"
+	"ninth-learn/repository/mocks"
+	"ninth-learn/route"
+	"ninth-learn/service"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	secret       = "test-secret"
+	cacheControl = "public, max-age=60"
+)
+
+type mockRepo struct {
+	*mocks.MockBookRepo
+	*mocks.MockTenantRepo
+	*mocks.MockAuthorRepo
+	*mocks.MockCategoryRepo
+	*mocks.MockTagRepo
+	*mocks.MockCoverRepo
+	*mocks.MockCopyRepo
+	*mocks.MockMemberRepo
+	*mocks.MockLoanRepo
+	*mocks.MockHoldRepo
+	*mocks.MockFineRepo
+	*mocks.MockReviewRepo
+	*mocks.MockReadingListRepo
+	*mocks.MockDuplicateRepo
+	*mocks.MockTranslationRepo
+	*mocks.MockSeriesRepo
+	*mocks.MockRelationRepo
+	*mocks.MockWebhookRepo
+	*mocks.MockOutboxRepo
+}
+
+func (m mockRepo) WithTenant(tenantID int64) repository.RepoInterface {
+	return m
+}
+
+// api runs the real router over the repo, tenants resolve to themselves
+func api(t *testing.T, ctrl *gomock.Controller, repo mockRepo) *gin.Engine {
+	router, _ := apiService(t, ctrl, repo)
+	return router
+}
+
+// apiService is api along with the service behind the router
+func apiService(t *testing.T, ctrl *gomock.Controller, repo mockRepo) (*gin.Engine, service.ServiceInterface) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("JWT_SECRET", secret)
+
+	if repo.MockTenantRepo == nil {
+		repo.MockTenantRepo = mocks.NewMockTenantRepo(ctrl)
+	}
+	repo.MockTenantRepo.EXPECT().GetTenantBySlug(gomock.Any()).DoAndReturn(func(slug string) (model.Tenant, error) {
+		if slug == "gone" {
+			return model.Tenant{}, errors.New(helper.ErrNotFound)
+		}
+		return model.Tenant{ID: 1, Slug: slug, Status: model.TenantStatusActive}, nil
+	}).AnyTimes()
+
+	router := gin.New()
+	app := service.NewService(repo, model.LoanPolicy{})
+	route.RegisterApi(router, app, cacheControl)
+	return router, app
+}
+
+func serve(t *testing.T, handler http.Handler, opts ...Option) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := New(server.URL, append([]Option{WithRetries(3, time.Millisecond)}, opts...)...)
+	assert.NoError(t, err)
+	return c
+}
+
+func token(t *testing.T, subject, role string) string {
+	return tenantToken(t, subject, role, "")
+}
+
+func tenantToken(t *testing.T, subject, role, tenant string) string {
+	claims := jwt.MapClaims{"sub": subject, "role": role}
+	if tenant != "" {
+		claims["tenant"] = tenant
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	assert.NoError(t, err)
+	return signed
+}
+
+func Test_Client_GetBooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	books := mocks.NewMockBookRepo(ctrl)
+	after := model.NewDate(2000, 1, 1)
+
+	books.EXPECT().GetBooks(model.BookFilter{
+		Query:          "gatsby",
+		Tags:           []string{"jazz-age", "classic"},
+		MatchAllTags:   true,
+		Language:       "en",
+		PublishedAfter: &after,
+	}).Return([]model.Book{{ID: 1, Title: "The Great Gatsby", PublicationDate: &after}}, nil)
+	books.EXPECT().BooksModifiedAt().Return(time.Time{}, nil)
+
+	c := serve(t, api(t, ctrl, mockRepo{MockBookRepo: books}))
+	res, err := c.GetBooks(context.Background(), model.BookFilter{
+		Query:          "gatsby",
+		Tags:           []string{"jazz-age", "classic"},
+		MatchAllTags:   true,
+		Language:       "en",
+		PublishedAfter: &after,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "The Great Gatsby", res[0].Title)
+	assert.Equal(t, after.String(), res[0].PublicationDate.String())
+}
+
+func Test_Client_CreateBook(t *testing.T) {
+	valid := model.BookRequest{Title: "The Great Gatsby", Author: "F. Scott Fitzgerald", Description: "A novel"}
+
+	tests := []struct {
+		name       string
+		input      model.BookRequest
+		onBookRepo func(*mocks.MockBookRepo, *mocks.MockDuplicateRepo)
+		wantErr    error
+		message    string
+	}{
+		{
+			name:  "created",
+			input: valid,
+			onBookRepo: func(books *mocks.MockBookRepo, duplicates *mocks.MockDuplicateRepo) {
+				books.EXPECT().CreateBook(gomock.Any()).DoAndReturn(func(in model.Book) (model.Book, error) {
+					in.ID = 5
+					return in, nil
+				})
+				duplicates.EXPECT().GetDuplicateCandidates(gomock.Any()).Return(nil, nil)
+			},
+		},
+		{
+			name:    "invalid",
+			input:   model.BookRequest{Title: "No"},
+			wantErr: ErrBadRequest,
+		},
+		{
+			name:  "duplicated ISBN",
+			input: valid,
+			onBookRepo: func(books *mocks.MockBookRepo, duplicates *mocks.MockDuplicateRepo) {
+				books.EXPECT().CreateBook(gomock.Any()).Return(model.Book{}, errors.New(helper.ErrDuplicatedKey))
+			},
+			wantErr: ErrConflict,
+			message: "A book with this ISBN already exists",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			books, duplicates := mocks.NewMockBookRepo(ctrl), mocks.NewMockDuplicateRepo(ctrl)
+			if tt.onBookRepo != nil {
+				tt.onBookRepo(books, duplicates)
+			}
+
+			c := serve(t, api(t, ctrl, mockRepo{MockBookRepo: books, MockDuplicateRepo: duplicates}))
+			res, err := c.CreateBook(context.Background(), tt.input)
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(5), res.ID)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+			apiErr := &Error{}
+			assert.True(t, errors.As(err, &apiErr))
+			if tt.message != "" {
+				assert.Equal(t, tt.message, apiErr.Message)
+			}
+		})
+	}
+}
+
+func Test_Client_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authors := mocks.NewMockAuthorRepo(ctrl)
+	authors.EXPECT().GetAuthorById(int64(9)).Return(model.Author{}, errors.New(helper.ErrNotFound))
+
+	router := api(t, ctrl, mockRepo{MockAuthorRepo: authors})
+	ctx := context.Background()
+
+	_, err := serve(t, router).GetAuthorById(ctx, 9)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.EqualError(t, err, "books api: 404 record not found")
+
+	_, err = serve(t, router, WithTenant("gone")).GetAuthors(ctx)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, "Unknown tenant", err.(*Error).Message)
+
+	// responses that are not a helper.Response keep their body
+	proxy := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream down", http.StatusBadGateway)
+	})
+	_, err = serve(t, proxy, WithRetries(0, 0)).GetAuthors(ctx)
+	assert.ErrorIs(t, err, ErrServer)
+	assert.Equal(t, "upstream down", err.(*Error).Message)
+}
+
+func Test_Client_Auth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	lists := mocks.NewMockReadingListRepo(ctrl)
+	tenants := mocks.NewMockTenantRepo(ctrl)
+	lists.EXPECT().CreateReadingList(gomock.Any()).DoAndReturn(func(in model.ReadingList) (model.ReadingList, error) {
+		in.ID = 3
+		return in, nil
+	})
+	tenants.EXPECT().GetTenants().Return([]model.Tenant{{ID: 1, Slug: "default"}}, nil)
+
+	router := api(t, ctrl, mockRepo{MockReadingListRepo: lists, MockTenantRepo: tenants})
+	ctx := context.Background()
+
+	_, err := serve(t, router).CreateList(ctx, model.ReadingListRequest{Name: "Summer"})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	list, err := serve(t, router, WithToken(token(t, "jane", ""))).CreateList(ctx, model.ReadingListRequest{Name: "Summer"})
+	assert.NoError(t, err)
+	assert.Equal(t, "jane", list.Owner)
+
+	_, err = serve(t, router, WithToken(token(t, "jane", ""))).GetTenants(ctx)
+	assert.ErrorIs(t, err, ErrForbidden)
+
+	// the admin of one library cannot manage the others
+	librarian := serve(t, router, WithToken(tenantToken(t, "librarian", model.RoleAdmin, "default")))
+	_, err = librarian.GetTenants(ctx)
+	assert.ErrorIs(t, err, ErrForbidden)
+	_, err = librarian.SuspendTenant(ctx, 2)
+	assert.ErrorIs(t, err, ErrForbidden)
+
+	res, err := serve(t, router, WithToken(token(t, "root", model.RoleAdmin))).GetTenants(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "default", res[0].Slug)
+}
+
+func Test_Client_StreamBookEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router, app := apiService(t, ctrl, mockRepo{})
+	c := serve(t, router)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	all, err := c.StreamBookEvents(ctx, model.BookEventFilter{}, "")
+	assert.NoError(t, err)
+	defer all.Close()
+	herbert, err := c.StreamBookEvents(ctx, model.BookEventFilter{Authors: []string{"frank herbert"}}, "")
+	assert.NoError(t, err)
+	defer herbert.Close()
+
+	// the events the outbox relay published
+	for _, book := range []model.Book{
+		{ID: 15, Title: "The Great Gatsby", Author: "F. Scott Fitzgerald"},
+		{ID: 4, Title: "Dune", Author: "Frank Herbert"},
+	} {
+		book := book
+		payload, err := json.Marshal(model.BookEvent{Type: model.BookCreated, BookID: book.ID, Book: &book})
+		assert.NoError(t, err)
+		err = app.BroadcastBookEvent(outbox.Message{Type: model.BookCreated, Key: model.AggregateBook + ":" + strconv.FormatInt(book.ID, 10), TenantID: 1, Payload: payload})
+		assert.NoError(t, err)
+	}
+
+	e, err := herbert.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, model.BookCreated, e.Type)
+	assert.Equal(t, "Dune", e.Event.Book.Title)
+
+	first, err := all.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, "The Great Gatsby", first.Event.Book.Title)
+
+	// picking up after the first event replays the second
+	resumed, err := c.StreamBookEvents(ctx, model.BookEventFilter{}, first.ID)
+	assert.NoError(t, err)
+	defer resumed.Close()
+	e, err = resumed.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), e.Event.BookID)
+	assert.NotEqual(t, first.ID, e.ID)
+
+	// the ids of another process cannot be replayed
+	lost, err := c.StreamBookEvents(ctx, model.BookEventFilter{}, "elsewhere-1")
+	assert.NoError(t, err)
+	defer lost.Close()
+	e, err = lost.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, EventReset, e.Type)
+}
+
+func Test_Client_CreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	webhooks := mocks.NewMockWebhookRepo(ctrl)
+	webhooks.EXPECT().CreateWebhook(gomock.Any()).DoAndReturn(func(in model.Webhook) (model.Webhook, error) {
+		in.ID = 4
+		return in, nil
+	}).Times(1)
+
+	router := api(t, ctrl, mockRepo{MockWebhookRepo: webhooks})
+	admin := serve(t, router, WithToken(token(t, "root", model.RoleAdmin)))
+	ctx := context.Background()
+
+	res, err := admin.CreateWebhook(ctx, model.WebhookRequest{URL: "https://example.com/hooks", Events: []string{model.BookCreated}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), res.ID)
+	assert.True(t, res.Active)
+	assert.Len(t, res.Secret, 32)
+
+	_, err = admin.CreateWebhook(ctx, model.WebhookRequest{URL: "https://example.com/hooks", Events: []string{"book.read"}})
+	assert.ErrorIs(t, err, ErrBadRequest)
+
+	_, err = admin.CreateWebhook(ctx, model.WebhookRequest{URL: "ftp://example.com/hooks", Events: []string{model.BookCreated}})
+	assert.ErrorIs(t, err, ErrBadRequest)
+
+	_, err = serve(t, router, WithToken(token(t, "jane", ""))).CreateWebhook(ctx, model.WebhookRequest{URL: "https://example.com/hooks", Events: []string{model.BookCreated}})
+	assert.ErrorIs(t, err, ErrForbidden)
+}
+
+// flaky answers the first failures requests with status, then hands over
+func flaky(status, failures int32, next http.Handler) (http.Handler, *int32) {
+	calls := new(int32)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) <= failures {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(int(status))
+			w.Write([]byte(`{"message":"try again","data":null}`))
+			return
+		}
+		next.ServeHTTP(w, r)
+	}), calls
+}
+
+func Test_Client_Retries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tags := mocks.NewMockTagRepo(ctrl)
+	tags.EXPECT().GetTags().Return([]model.TagUsage{{Tag: model.Tag{Name: "jazz-age"}, BookCount: 2}}, nil).Times(1)
+	tags.EXPECT().CreateTag(gomock.Any()).Return(model.Tag{ID: 1, Name: "noir"}, nil).Times(1)
+	router := api(t, ctrl, mockRepo{MockTagRepo: tags})
+	ctx := context.Background()
+
+	handler, calls := flaky(http.StatusServiceUnavailable, 2, router)
+	res, err := serve(t, handler).GetTags(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), res[0].BookCount)
+	assert.Equal(t, int32(3), *calls)
+
+	// a POST may have been applied, server errors are not retried
+	handler, calls = flaky(http.StatusInternalServerError, 1, router)
+	_, err = serve(t, handler).CreateTag(ctx, model.TagRequest{Name: "noir"})
+	assert.ErrorIs(t, err, ErrServer)
+	assert.Equal(t, int32(1), *calls)
+
+	// but it was not when rate limited
+	handler, calls = flaky(http.StatusTooManyRequests, 1, router)
+	tag, err := serve(t, handler).CreateTag(ctx, model.TagRequest{Name: "noir"})
+	assert.NoError(t, err)
+	assert.Equal(t, "noir", tag.Name)
+	assert.Equal(t, int32(2), *calls)
+
+	handler, calls = flaky(http.StatusBadGateway, 10, router)
+	_, err = serve(t, handler).GetTags(ctx)
+	assert.ErrorIs(t, err, ErrServer)
+	assert.Equal(t, int32(4), *calls)
+
+	// waiting for a retry gives way to the context
+	handler, _ = flaky(http.StatusServiceUnavailable, 10, router)
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = serve(t, handler, WithRetries(3, time.Hour)).GetTags(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"ninth-learn/model"
+)
+
+func (c *Client) CreateCopy(ctx context.Context, bookID int64, in model.CopyRequest) (res model.Copy, err error) {
+	err = c.call(ctx, http.MethodPost, pathf("/books/%s/copies", bookID), nil, in, &res)
+	return res, err
+}
+
+func (c *Client) GetCopies(ctx context.Context, bookID int64) (res []model.Copy, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/books/%s/copies", bookID), nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) GetCopyById(ctx context.Context, bookID, id int64) (res model.Copy, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/books/%s/copies/%s", bookID, id), nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) GetCopyByBarcode(ctx context.Context, barcode string) (res model.Copy, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/copies/barcode/%s", barcode), nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) UpdateCopy(ctx context.Context, bookID, id int64, in model.CopyRequest) (res model.Copy, err error) {
+	err = c.call(ctx, http.MethodPut, pathf("/books/%s/copies/%s", bookID, id), nil, in, &res)
+	return res, err
+}
+
+func (c *Client) DeleteCopy(ctx context.Context, bookID, id int64) error {
+	return c.call(ctx, http.MethodDelete, pathf("/books/%s/copies/%s", bookID, id), nil, nil, nil)
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"ninth-learn/model"
+	"strings"
+	"time"
+)
+
+// UploadBookCover uploads a JPEG, PNG or WebP cover for the book
+func (c *Client) UploadBookCover(ctx context.Context, id int64, filename string, data []byte) (res model.Book, err error) {
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	part, err := form.CreateFormFile("cover", filename)
+	if err != nil {
+		return res, err
+	}
+	if _, err := part.Write(data); err != nil {
+		return res, err
+	}
+	if err := form.Close(); err != nil {
+		return res, err
+	}
+
+	err = c.callRequest(ctx, request{
+		method:      http.MethodPut,
+		path:        pathf("/books/%s/cover", id),
+		body:        body.Bytes(),
+		contentType: form.FormDataContentType(),
+	}, &res)
+	return res, err
+}
+
+// GetBookCover downloads a rendition of the cover, model.CoverSizeOriginal
+// when size is empty
+func (c *Client) GetBookCover(ctx context.Context, id int64, size string) (res model.CoverImage, err error) {
+	query := url.Values{}
+	if size != "" {
+		query.Set("size", size)
+	}
+
+	resp, err := c.send(ctx, request{method: http.MethodGet, path: pathf("/books/%s/cover", id), query: query})
+	if err != nil {
+		return res, err
+	}
+	defer resp.Body.Close()
+
+	res.Size = size
+	if res.Size == "" {
+		res.Size = model.CoverSizeOriginal
+	}
+	res.ContentType = resp.Header.Get("Content-Type")
+	res.ETag = strings.Trim(resp.Header.Get("ETag"), `"`)
+	if modified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		res.UpdatedAt = modified.In(time.UTC)
+	}
+	res.Data, err = io.ReadAll(resp.Body)
+	return res, err
+}
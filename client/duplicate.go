@@ -0,0 +1,19 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"ninth-learn/model"
+)
+
+// GetDuplicateBooks returns the clusters of books that look alike
+func (c *Client) GetDuplicateBooks(ctx context.Context) (res []model.DuplicateCluster, err error) {
+	err = c.call(ctx, http.MethodGet, "/books/duplicates", nil, nil, &res)
+	return res, err
+}
+
+// MergeBooks merges the source book into the target and returns the target
+func (c *Client) MergeBooks(ctx context.Context, in model.MergeRequest) (res model.Book, err error) {
+	err = c.call(ctx, http.MethodPost, "/books/merge", nil, in, &res)
+	return res, err
+}
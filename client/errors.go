@@ -0,0 +1,85 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// the errors an *Error unwraps to, by status
+var (
+	ErrBadRequest           = errors.New("bad request")
+	ErrUnauthorized         = errors.New("unauthorized")
+	ErrForbidden            = errors.New("forbidden")
+	ErrNotFound             = errors.New("not found")
+	ErrConflict             = errors.New("conflict")
+	ErrPayloadTooLarge      = errors.New("payload too large")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrTooManyRequests      = errors.New("too many requests")
+	ErrServer               = errors.New("server error")
+)
+
+// Error is an error response of the API, Message is the message of its
+// helper.Response
+type Error struct {
+	StatusCode int
+	Message    string
+	// Data holds the details some errors carry, e.g. the failed validations
+	Data json.RawMessage
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("books api: %d %s", e.StatusCode, e.Message)
+}
+
+// Unwrap lets errors.Is match the error by status, e.g. against ErrNotFound
+func (e *Error) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusBadRequest:
+		return ErrBadRequest
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusForbidden:
+		return ErrForbidden
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusConflict:
+		return ErrConflict
+	case e.StatusCode == http.StatusRequestEntityTooLarge:
+		return ErrPayloadTooLarge
+	case e.StatusCode == http.StatusUnsupportedMediaType:
+		return ErrUnsupportedMediaType
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrTooManyRequests
+	case e.StatusCode >= 500:
+		return ErrServer
+	}
+	return nil
+}
+
+// errorFrom reads the error of a response, falling back to the body or the
+// status text when it is not a helper.Response
+func errorFrom(resp *http.Response) *Error {
+	e := &Error{StatusCode: resp.StatusCode}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	envelope := struct {
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+	}{}
+	if json.Unmarshal(body, &envelope) == nil && envelope.Message != "" {
+		e.Message = envelope.Message
+		if string(envelope.Data) != "null" {
+			e.Data = envelope.Data
+		}
+		return e
+	}
+
+	if e.Message = strings.TrimSpace(string(body)); e.Message == "" {
+		e.Message = http.StatusText(resp.StatusCode)
+	}
+	return e
+}
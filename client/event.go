@@ -0,0 +1,107 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"ninth-learn/model"
+	"strings"
+)
+
+// EventReset is the type of the event telling that changes were missed
+// and the books should be reloaded
+const EventReset = "reset"
+
+// StreamEvent is an event of the book stream
+type StreamEvent struct {
+	ID    string
+	Type  string
+	Event model.BookEvent
+}
+
+// EventStream reads a Server-Sent Events response
+type EventStream struct {
+	body io.ReadCloser
+	r    *bufio.Reader
+}
+
+// StreamBookEvents opens the stream of book changes, after the event with
+// lastEventID when given. the stream ends when ctx is done or it is closed
+func (c *Client) StreamBookEvents(ctx context.Context, filter model.BookEventFilter, lastEventID string) (*EventStream, error) {
+	query := url.Values{}
+	if len(filter.Authors) > 0 {
+		query.Set("author", strings.Join(filter.Authors, ","))
+	}
+	if len(filter.Tags) > 0 {
+		query.Set("tag", strings.Join(filter.Tags, ","))
+	}
+	header := http.Header{}
+	if lastEventID != "" {
+		header.Set("Last-Event-ID", lastEventID)
+	}
+
+	// the stream outlives any client timeout
+	streaming := *c
+	hc := *c.httpClient
+	hc.Timeout = 0
+	streaming.httpClient = &hc
+
+	resp, err := streaming.send(ctx, request{method: http.MethodGet, path: "/books/events", query: query, header: header})
+	if err != nil {
+		return nil, err
+	}
+	return &EventStream{body: resp.Body, r: bufio.NewReader(resp.Body)}, nil
+}
+
+// Next blocks until the next event, heartbeats are skipped. it returns
+// io.EOF once the server ends the stream, reconnect with the last ID to go on
+func (s *EventStream) Next() (StreamEvent, error) {
+	e := StreamEvent{}
+	var data strings.Builder
+
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil {
+			return e, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if e.Type == "" {
+				// retry hints and heartbeats carry no event
+				continue
+			}
+			if e.Type != EventReset {
+				if err := json.Unmarshal([]byte(data.String()), &e.Event); err != nil {
+					return e, err
+				}
+				e.Event.ID = e.ID
+			}
+			return e, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			e.ID = value
+		case "event":
+			e.Type = value
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		}
+	}
+}
+
+func (s *EventStream) Close() error {
+	return s.body.Close()
+}
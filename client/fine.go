@@ -0,0 +1,22 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"ninth-learn/model"
+)
+
+func (c *Client) GetMemberFines(ctx context.Context, memberID int64) (res model.FineSummary, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/members/%s/fines", memberID), nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) PayFine(ctx context.Context, memberID int64, in model.FineTransactionRequest) (res model.FineTransaction, err error) {
+	err = c.call(ctx, http.MethodPost, pathf("/members/%s/fines/payments", memberID), nil, in, &res)
+	return res, err
+}
+
+func (c *Client) WaiveFine(ctx context.Context, memberID int64, in model.FineTransactionRequest) (res model.FineTransaction, err error) {
+	err = c.call(ctx, http.MethodPost, pathf("/members/%s/fines/waivers", memberID), nil, in, &res)
+	return res, err
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"ninth-learn/model"
+)
+
+func (c *Client) PlaceHold(ctx context.Context, bookID int64, in model.HoldRequest) (res model.Hold, err error) {
+	err = c.call(ctx, http.MethodPost, pathf("/books/%s/holds", bookID), nil, in, &res)
+	return res, err
+}
+
+// GetBookHolds returns the holds on a book, only waiting and ready ones when
+// activeOnly
+func (c *Client) GetBookHolds(ctx context.Context, bookID int64, activeOnly bool) (res []model.Hold, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/books/%s/holds", bookID), activeQuery(activeOnly), nil, &res)
+	return res, err
+}
+
+// GetMemberHolds returns the holds of a member, only waiting and ready ones
+// when activeOnly
+func (c *Client) GetMemberHolds(ctx context.Context, memberID int64, activeOnly bool) (res []model.Hold, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/members/%s/holds", memberID), activeQuery(activeOnly), nil, &res)
+	return res, err
+}
+
+func (c *Client) GetHoldById(ctx context.Context, id int64) (res model.Hold, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/holds/%s", id), nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) CancelHold(ctx context.Context, id int64) (res model.Hold, err error) {
+	err = c.call(ctx, http.MethodPut, pathf("/holds/%s/cancel", id), nil, nil, &res)
+	return res, err
+}
+
+func activeQuery(activeOnly bool) url.Values {
+	if !activeOnly {
+		return nil
+	}
+	return url.Values{"active": {"true"}}
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"ninth-learn/model"
+)
+
+func (c *Client) CheckoutBook(ctx context.Context, bookID int64, in model.CheckoutRequest) (res model.Loan, err error) {
+	err = c.call(ctx, http.MethodPost, pathf("/books/%s/checkout", bookID), nil, in, &res)
+	return res, err
+}
+
+// GetBookLoans returns the loans of a book, only those not yet returned when
+// activeOnly
+func (c *Client) GetBookLoans(ctx context.Context, bookID int64, activeOnly bool) (res []model.Loan, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/books/%s/loans", bookID), activeQuery(activeOnly), nil, &res)
+	return res, err
+}
+
+func (c *Client) GetLoanById(ctx context.Context, id int64) (res model.Loan, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/loans/%s", id), nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) GetOverdueLoans(ctx context.Context) (res []model.Loan, err error) {
+	err = c.call(ctx, http.MethodGet, "/loans/overdue", nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) ReturnLoan(ctx context.Context, id int64) (res model.Loan, err error) {
+	err = c.call(ctx, http.MethodPut, pathf("/loans/%s/return", id), nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) RenewLoan(ctx context.Context, id int64) (res model.Loan, err error) {
+	err = c.call(ctx, http.MethodPut, pathf("/loans/%s/renew", id), nil, nil, &res)
+	return res, err
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"ninth-learn/model"
+)
+
+func (c *Client) CreateMember(ctx context.Context, in model.MemberRequest) (res model.Member, err error) {
+	err = c.call(ctx, http.MethodPost, "/members", nil, in, &res)
+	return res, err
+}
+
+func (c *Client) GetMembers(ctx context.Context) (res []model.Member, err error) {
+	err = c.call(ctx, http.MethodGet, "/members", nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) GetMemberById(ctx context.Context, id int64) (res model.Member, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/members/%s", id), nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) UpdateMember(ctx context.Context, id int64, in model.MemberRequest) (res model.Member, err error) {
+	err = c.call(ctx, http.MethodPut, pathf("/members/%s", id), nil, in, &res)
+	return res, err
+}
+
+func (c *Client) DeleteMember(ctx context.Context, id int64) error {
+	return c.call(ctx, http.MethodDelete, pathf("/members/%s", id), nil, nil, nil)
+}
+
+// GetMemberLoans returns the loans of a member, only those not yet returned
+// when activeOnly
+func (c *Client) GetMemberLoans(ctx context.Context, id int64, activeOnly bool) (res []model.Loan, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/members/%s/loans", id), activeQuery(activeOnly), nil, &res)
+	return res, err
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"ninth-learn/model"
+)
+
+// the /me endpoints act on the lists of the principal of the token
+
+func (c *Client) GetMyLists(ctx context.Context) (res []model.ReadingList, err error) {
+	err = c.call(ctx, http.MethodGet, "/me/lists", nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) GetMyList(ctx context.Context, id int64) (res model.ReadingList, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/me/lists/%s", id), nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) CreateList(ctx context.Context, in model.ReadingListRequest) (res model.ReadingList, err error) {
+	err = c.call(ctx, http.MethodPost, "/me/lists", nil, in, &res)
+	return res, err
+}
+
+func (c *Client) UpdateList(ctx context.Context, id int64, in model.ReadingListRequest) (res model.ReadingList, err error) {
+	err = c.call(ctx, http.MethodPut, pathf("/me/lists/%s", id), nil, in, &res)
+	return res, err
+}
+
+// ShareList returns the list with the token it can be read by through
+// GetSharedList
+func (c *Client) ShareList(ctx context.Context, id int64) (res model.ReadingList, err error) {
+	err = c.call(ctx, http.MethodPut, pathf("/me/lists/%s/share", id), nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) DeleteList(ctx context.Context, id int64) error {
+	return c.call(ctx, http.MethodDelete, pathf("/me/lists/%s", id), nil, nil, nil)
+}
+
+func (c *Client) AddListBook(ctx context.Context, listID int64, in model.ReadingListEntryRequest) (res model.ReadingListEntry, err error) {
+	err = c.call(ctx, http.MethodPost, pathf("/me/lists/%s/books", listID), nil, in, &res)
+	return res, err
+}
+
+func (c *Client) MoveListBook(ctx context.Context, listID, bookID int64, position int) (res model.ReadingListEntry, err error) {
+	in := model.ReadingListMoveRequest{Position: position}
+	err = c.call(ctx, http.MethodPut, pathf("/me/lists/%s/books/%s", listID, bookID), nil, in, &res)
+	return res, err
+}
+
+func (c *Client) RemoveListBook(ctx context.Context, listID, bookID int64) error {
+	return c.call(ctx, http.MethodDelete, pathf("/me/lists/%s/books/%s", listID, bookID), nil, nil, nil)
+}
+
+// GetSharedList reads a shared list by its token, no token needed
+func (c *Client) GetSharedList(ctx context.Context, token string) (res model.ReadingList, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/lists/%s", token), nil, nil, &res)
+	return res, err
+}
@@ -0,0 +1,21 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"ninth-learn/model"
+)
+
+func (c *Client) GetBookRelations(ctx context.Context, bookID int64) (res []model.RelatedWork, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/books/%s/relations", bookID), nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) CreateBookRelation(ctx context.Context, bookID int64, in model.BookRelationRequest) (res model.RelatedWork, err error) {
+	err = c.call(ctx, http.MethodPost, pathf("/books/%s/relations", bookID), nil, in, &res)
+	return res, err
+}
+
+func (c *Client) DeleteBookRelation(ctx context.Context, bookID, relationID int64) error {
+	return c.call(ctx, http.MethodDelete, pathf("/books/%s/relations/%s", bookID, relationID), nil, nil, nil)
+}
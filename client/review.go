@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"ninth-learn/model"
+)
+
+// GetBookReviews returns the approved reviews of a book, admins may ask for
+// another moderation status
+func (c *Client) GetBookReviews(ctx context.Context, bookID int64, status string) (res []model.Review, err error) {
+	var query url.Values
+	if status != "" {
+		query = url.Values{"status": {status}}
+	}
+	err = c.call(ctx, http.MethodGet, pathf("/books/%s/reviews", bookID), query, nil, &res)
+	return res, err
+}
+
+func (c *Client) CreateReview(ctx context.Context, bookID int64, in model.ReviewRequest) (res model.Review, err error) {
+	err = c.call(ctx, http.MethodPost, pathf("/books/%s/reviews", bookID), nil, in, &res)
+	return res, err
+}
+
+func (c *Client) UpdateReview(ctx context.Context, bookID, reviewID int64, in model.ReviewRequest) (res model.Review, err error) {
+	err = c.call(ctx, http.MethodPut, pathf("/books/%s/reviews/%s", bookID, reviewID), nil, in, &res)
+	return res, err
+}
+
+func (c *Client) DeleteReview(ctx context.Context, bookID, reviewID int64) error {
+	return c.call(ctx, http.MethodDelete, pathf("/books/%s/reviews/%s", bookID, reviewID), nil, nil, nil)
+}
+
+// ModerateReview approves or rejects a review, admins only
+func (c *Client) ModerateReview(ctx context.Context, bookID, reviewID int64, status string) (res model.Review, err error) {
+	in := model.ReviewModerationRequest{Status: status}
+	err = c.call(ctx, http.MethodPut, pathf("/books/%s/reviews/%s/status", bookID, reviewID), nil, in, &res)
+	return res, err
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"ninth-learn/model"
+)
+
+func (c *Client) GetSeries(ctx context.Context) (res []model.Series, err error) {
+	err = c.call(ctx, http.MethodGet, "/series", nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) GetSeriesById(ctx context.Context, id int64) (res model.Series, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/series/%s", id), nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) CreateSeries(ctx context.Context, in model.SeriesRequest) (res model.Series, err error) {
+	err = c.call(ctx, http.MethodPost, "/series", nil, in, &res)
+	return res, err
+}
+
+func (c *Client) UpdateSeries(ctx context.Context, id int64, in model.SeriesRequest) (res model.Series, err error) {
+	err = c.call(ctx, http.MethodPut, pathf("/series/%s", id), nil, in, &res)
+	return res, err
+}
+
+func (c *Client) DeleteSeries(ctx context.Context, id int64) error {
+	return c.call(ctx, http.MethodDelete, pathf("/series/%s", id), nil, nil, nil)
+}
+
+func (c *Client) AddSeriesBook(ctx context.Context, seriesID int64, in model.SeriesEntryRequest) (res model.SeriesEntry, err error) {
+	err = c.call(ctx, http.MethodPost, pathf("/series/%s/books", seriesID), nil, in, &res)
+	return res, err
+}
+
+func (c *Client) MoveSeriesBook(ctx context.Context, seriesID, bookID int64, position int) (res model.SeriesEntry, err error) {
+	in := model.SeriesMoveRequest{Position: position}
+	err = c.call(ctx, http.MethodPut, pathf("/series/%s/books/%s", seriesID, bookID), nil, in, &res)
+	return res, err
+}
+
+func (c *Client) RemoveSeriesBook(ctx context.Context, seriesID, bookID int64) error {
+	return c.call(ctx, http.MethodDelete, pathf("/series/%s/books/%s", seriesID, bookID), nil, nil, nil)
+}
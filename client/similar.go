@@ -0,0 +1,20 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"ninth-learn/model"
+	"strconv"
+)
+
+// GetSimilarBooks returns the books most like the given one, the server
+// default number of them when limit is 0
+func (c *Client) GetSimilarBooks(ctx context.Context, id int64, limit int) (res []model.SimilarBook, err error) {
+	var query url.Values
+	if limit != 0 {
+		query = url.Values{"limit": {strconv.Itoa(limit)}}
+	}
+	err = c.call(ctx, http.MethodGet, pathf("/books/%s/similar", id), query, nil, &res)
+	return res, err
+}
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"ninth-learn/model"
+)
+
+func (c *Client) CreateTag(ctx context.Context, in model.TagRequest) (res model.Tag, err error) {
+	err = c.call(ctx, http.MethodPost, "/tags", nil, in, &res)
+	return res, err
+}
+
+// GetTags returns the tags with the number of books carrying them
+func (c *Client) GetTags(ctx context.Context) (res []model.TagUsage, err error) {
+	err = c.call(ctx, http.MethodGet, "/tags", nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) UpdateTag(ctx context.Context, id int64, in model.TagRequest) (res model.Tag, err error) {
+	err = c.call(ctx, http.MethodPut, pathf("/tags/%s", id), nil, in, &res)
+	return res, err
+}
+
+func (c *Client) DeleteTag(ctx context.Context, id int64) error {
+	return c.call(ctx, http.MethodDelete, pathf("/tags/%s", id), nil, nil, nil)
+}
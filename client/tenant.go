@@ -0,0 +1,29 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"ninth-learn/model"
+)
+
+// the /admin endpoints need a token with the admin role
+
+func (c *Client) CreateTenant(ctx context.Context, in model.TenantRequest) (res model.Tenant, err error) {
+	err = c.call(ctx, http.MethodPost, "/admin/tenants", nil, in, &res)
+	return res, err
+}
+
+func (c *Client) GetTenants(ctx context.Context) (res []model.Tenant, err error) {
+	err = c.call(ctx, http.MethodGet, "/admin/tenants", nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) SuspendTenant(ctx context.Context, id int64) (res model.Tenant, err error) {
+	err = c.call(ctx, http.MethodPut, pathf("/admin/tenants/%s/suspend", id), nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) ActivateTenant(ctx context.Context, id int64) (res model.Tenant, err error) {
+	err = c.call(ctx, http.MethodPut, pathf("/admin/tenants/%s/activate", id), nil, nil, &res)
+	return res, err
+}
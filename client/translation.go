@@ -0,0 +1,23 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"ninth-learn/model"
+)
+
+func (c *Client) GetBookTranslations(ctx context.Context, bookID int64) (res []model.BookTranslation, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/books/%s/translations", bookID), nil, nil, &res)
+	return res, err
+}
+
+// PutBookTranslation creates or replaces the translation of a book into the
+// language, a BCP 47 tag
+func (c *Client) PutBookTranslation(ctx context.Context, bookID int64, lang string, in model.BookTranslationRequest) (res model.BookTranslation, err error) {
+	err = c.call(ctx, http.MethodPut, pathf("/books/%s/translations/%s", bookID, lang), nil, in, &res)
+	return res, err
+}
+
+func (c *Client) DeleteBookTranslation(ctx context.Context, bookID int64, lang string) error {
+	return c.call(ctx, http.MethodDelete, pathf("/books/%s/translations/%s", bookID, lang), nil, nil, nil)
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"ninth-learn/model"
+)
+
+func (c *Client) GetWebhooks(ctx context.Context) (res []model.Webhook, err error) {
+	err = c.call(ctx, http.MethodGet, "/webhooks", nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) GetWebhookById(ctx context.Context, id int64) (res model.Webhook, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/webhooks/%s", id), nil, nil, &res)
+	return res, err
+}
+
+// CreateWebhook returns the webhook with its secret, the only time the
+// secret is shown
+func (c *Client) CreateWebhook(ctx context.Context, in model.WebhookRequest) (res model.Webhook, err error) {
+	err = c.call(ctx, http.MethodPost, "/webhooks", nil, in, &res)
+	return res, err
+}
+
+func (c *Client) UpdateWebhook(ctx context.Context, id int64, in model.WebhookRequest) (res model.Webhook, err error) {
+	err = c.call(ctx, http.MethodPut, pathf("/webhooks/%s", id), nil, in, &res)
+	return res, err
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, id int64) error {
+	return c.call(ctx, http.MethodDelete, pathf("/webhooks/%s", id), nil, nil, nil)
+}
+
+func (c *Client) GetWebhookDeliveries(ctx context.Context, webhookID int64) (res []model.WebhookDelivery, err error) {
+	err = c.call(ctx, http.MethodGet, pathf("/webhooks/%s/deliveries", webhookID), nil, nil, &res)
+	return res, err
+}
+
+func (c *Client) RedeliverWebhook(ctx context.Context, webhookID, deliveryID int64) (res model.WebhookDelivery, err error) {
+	err = c.call(ctx, http.MethodPost, pathf("/webhooks/%s/deliveries/%s/redeliver", webhookID, deliveryID), nil, nil, &res)
+	return res, err
+}
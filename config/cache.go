@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"ninth-learn/cache"
+	"ninth-learn/cdn"
+	"os"
+	"strconv"
+	"time"
+)
+
+// BookCache opens the store selected by BOOK_CACHE, memory (default) keeps
+// BOOK_CACHE_SIZE books in this process, redis shares them at REDIS_URL,
+// whose pool_size parameter sets the connections opened (10 by default)
+func BookCache() (cache.Store, error) {
+	switch store := os.Getenv("BOOK_CACHE"); store {
+	case "", "memory":
+		size, err := strconv.Atoi(envOr("BOOK_CACHE_SIZE", "10000"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid BOOK_CACHE_SIZE: %w", err)
+		}
+		return cache.NewLRU(size), nil
+	case "redis":
+		return cache.NewRedis(envOr("REDIS_URL", "redis://localhost:6379/0"), time.Second)
+	default:
+		return nil, fmt.Errorf("unknown BOOK_CACHE %q", store)
+	}
+}
+
+// BookCacheTTL is how long a book is cached, BOOK_CACHE_TTL takes a Go
+// duration such as 10m
+func BookCacheTTL() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("BOOK_CACHE_TTL")); err == nil && d > 0 {
+		return d
+	}
+	return 5 * time.Minute
+}
+
+// BookCacheNegativeTTL is how long a missing book is remembered,
+// BOOK_CACHE_NEGATIVE_TTL takes a Go duration
+func BookCacheNegativeTTL() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("BOOK_CACHE_NEGATIVE_TTL")); err == nil && d > 0 {
+		return d
+	}
+	return 30 * time.Second
+}
+
+// CacheControl is sent with the book reads a CDN may keep, CACHE_CONTROL
+// replaces the default, an empty one sends none
+func CacheControl() string {
+	if v, ok := os.LookupEnv("CACHE_CONTROL"); ok {
+		return v
+	}
+	return "public, max-age=60, stale-while-revalidate=30"
+}
+
+// CachePurger opens the CDN selected by CDN_PURGE, none (default), stdout,
+// or fastly with FASTLY_SERVICE_ID and FASTLY_API_TOKEN
+func CachePurger() (*cdn.Purger, error) {
+	switch backend := os.Getenv("CDN_PURGE"); backend {
+	case "", "none":
+		return nil, nil
+	case "stdout":
+		return cdn.NewPurger(cdn.NewStdoutBackend()), nil
+	case "fastly":
+		serviceID, token := os.Getenv("FASTLY_SERVICE_ID"), os.Getenv("FASTLY_API_TOKEN")
+		if serviceID == "" || token == "" {
+			return nil, fmt.Errorf("CDN_PURGE=fastly needs FASTLY_SERVICE_ID and FASTLY_API_TOKEN")
+		}
+		soft := os.Getenv("FASTLY_SOFT_PURGE") != "false"
+		return cdn.NewPurger(cdn.NewFastlyBackend(envOr("FASTLY_API_URL", "https://api.fastly.com"), serviceID, token, soft)), nil
+	default:
+		return nil, fmt.Errorf("unknown CDN_PURGE %q", backend)
+	}
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"ninth-learn/changes"
+	"os"
+	"strings"
+	"time"
+)
+
+var instanceID = newInstanceID()
+
+// InstanceID names this instance among its replicas, INSTANCE_ID or else
+// the host name and process id
+func InstanceID() string {
+	return instanceID
+}
+
+func newInstanceID() string {
+	if id := os.Getenv("INSTANCE_ID"); id != "" {
+		return sanitizeInstanceID(id)
+	}
+	host, _ := os.Hostname()
+	return sanitizeInstanceID(fmt.Sprintf("%s-%d", host, os.Getpid()))
+}
+
+// sanitizeInstanceID keeps the id usable as an unquoted application_name
+func sanitizeInstanceID(id string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\\' || r == '\'' || r > 0x7e {
+			return '_'
+		}
+		return r
+	}, id)
+}
+
+// ChangeListener picks how book changes of other replicas arrive, by
+// CHANGE_FEED: postgres listens on the database, memory carries the writes
+// this process notifies of, auto (default) listens when the database is
+// postgres
+func ChangeListener() (changes.Listener, error) {
+	feed := os.Getenv("CHANGE_FEED")
+	if feed == "" || feed == "auto" {
+		feed = "memory"
+		if PSQL != nil && PSQL.DB != nil && PSQL.DB.Dialector.Name() == "postgres" {
+			feed = "postgres"
+		}
+	}
+
+	switch feed {
+	case "postgres":
+		return changes.NewPostgresListener(PSQL.DSN(), InstanceID(), time.Second, time.Minute), nil
+	case "memory":
+		return changes.NewMemoryBus(), nil
+	default:
+		return nil, fmt.Errorf("unknown CHANGE_FEED %q", feed)
+	}
+}
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"ninth-learn/graph"
+	"os"
+	"strconv"
+)
+
+// GraphQLLimits bound the queries accepted on /graphql, GRAPHQL_MAX_DEPTH
+// and GRAPHQL_MAX_COMPLEXITY override the defaults
+func GraphQLLimits() graph.Limits {
+	limits := graph.Limits{MaxDepth: 8, MaxComplexity: 2000}
+
+	if n, err := strconv.Atoi(os.Getenv("GRAPHQL_MAX_DEPTH")); err == nil && n > 0 {
+		limits.MaxDepth = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("GRAPHQL_MAX_COMPLEXITY")); err == nil && n > 0 {
+		limits.MaxComplexity = n
+	}
+	return limits
+}
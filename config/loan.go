@@ -0,0 +1,41 @@
+package config
+
+import (
+	"ninth-learn/model"
+	"os"
+	"strconv"
+)
+
+// LoanPolicy reads the lending rules from LOAN_DAYS, LOAN_MAX_PER_MEMBER,
+// LOAN_MAX_RENEWALS, HOLD_PICKUP_DAYS and the FINE_* variables, unset values
+// keep their defaults
+func LoanPolicy() model.LoanPolicy {
+	policy := model.DefaultLoanPolicy()
+
+	if n, err := strconv.Atoi(os.Getenv("LOAN_DAYS")); err == nil && n > 0 {
+		policy.LoanDays = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("LOAN_MAX_PER_MEMBER")); err == nil && n > 0 {
+		policy.MaxLoans = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("LOAN_MAX_RENEWALS")); err == nil && n >= 0 {
+		policy.MaxRenewals = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("HOLD_PICKUP_DAYS")); err == nil && n > 0 {
+		policy.HoldPickupDays = n
+	}
+	if n, err := strconv.ParseInt(os.Getenv("FINE_DAILY_CENTS"), 10, 64); err == nil && n >= 0 {
+		policy.FineDailyCents = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("FINE_GRACE_DAYS")); err == nil && n >= 0 {
+		policy.FineGraceDays = n
+	}
+	if n, err := strconv.ParseInt(os.Getenv("FINE_CAP_CENTS"), 10, 64); err == nil && n >= 0 {
+		policy.FineCapCents = n
+	}
+	if n, err := strconv.ParseInt(os.Getenv("FINE_BLOCK_THRESHOLD_CENTS"), 10, 64); err == nil && n >= 0 {
+		policy.FineBlockThreshold = n
+	}
+
+	return policy
+}
@@ -0,0 +1,266 @@
+package config
+
+import (
+	"ninth-learn/changes"
+	"ninth-learn/model"
+	"os"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Migrate brings the schema and existing rows up to date
+func Migrate(db *gorm.DB) error {
+	err := db.SetupJoinTable(&model.Book{}, "Categories", &model.BookCategory{})
+	if err != nil {
+		return err
+	}
+	err = db.SetupJoinTable(&model.Book{}, "Tags", &model.BookTag{})
+	if err != nil {
+		return err
+	}
+
+	err = db.AutoMigrate(
+		&model.Tenant{},
+		&model.Book{},
+		&model.Author{},
+		&model.BookAuthor{},
+		&model.Category{},
+		&model.BookCategory{},
+		&model.Tag{},
+		&model.BookTag{},
+		&model.Copy{},
+		&model.Member{},
+		&model.Loan{},
+		&model.Hold{},
+		&model.FineTransaction{},
+		&model.Review{},
+		&model.ReadingList{},
+		&model.ReadingListEntry{},
+		&model.BookRedirect{},
+		&model.BookTranslation{},
+		&model.Series{},
+		&model.SeriesEntry{},
+		&model.BookRelation{},
+		&model.Webhook{},
+		&model.WebhookDelivery{},
+		&model.OutboxEvent{},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = migrateDefaultTenant(db)
+	if err != nil {
+		return err
+	}
+
+	err = migrateSearchIndexes(db)
+	if err != nil {
+		return err
+	}
+
+	err = migrateAuthors(db)
+	if err != nil {
+		return err
+	}
+
+	err = migrateSeries(db)
+	if err != nil {
+		return err
+	}
+
+	return migrateBookNotify(db)
+}
+
+// migrateBookNotify has every write to a book notify the replicas listening
+// on the book changes channel, with the application name of the writer so
+// it can skip its own
+func migrateBookNotify(db *gorm.DB) error {
+	err := db.Exec(`CREATE OR REPLACE FUNCTION public.notify_book_change() RETURNS trigger AS $$
+DECLARE
+	book public.books%ROWTYPE;
+BEGIN
+	IF TG_OP = 'DELETE' THEN
+		book := OLD;
+	ELSE
+		book := NEW;
+	END IF;
+	PERFORM pg_notify('` + changes.Channel + `', json_build_object(
+		'op', TG_OP,
+		'id', book.id,
+		'tenant_id', book.tenant_id,
+		'origin', current_setting('application_name', true)
+	)::text);
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql`).Error
+	if err != nil {
+		return err
+	}
+
+	err = db.Exec("DROP TRIGGER IF EXISTS books_notify_change ON public.books").Error
+	if err != nil {
+		return err
+	}
+
+	return db.Exec(`CREATE TRIGGER books_notify_change
+	AFTER INSERT OR UPDATE OR DELETE ON public.books
+	FOR EACH ROW EXECUTE PROCEDURE public.notify_book_change()`).Error
+}
+
+// migrateSearchIndexes indexes book and translation text for full-text
+// search, each row stemmed with the text search configuration of its language
+func migrateSearchIndexes(db *gorm.DB) error {
+	document := "to_tsvector(" + model.SearchConfigSQL("language") + ", " + model.SearchDocumentSQL + ")"
+
+	indexes := map[string]string{
+		"idx_books_search":             "public.books",
+		"idx_book_translations_search": "public.book_translations",
+	}
+	for name, table := range indexes {
+		err := db.Exec("CREATE INDEX IF NOT EXISTS " + name + " ON " + table + " USING GIN (" + document + ")").Error
+		if err != nil {
+			return err
+		}
+	}
+
+	// the titles a new book may duplicate are looked up by trigram similarity
+	err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error
+	if err != nil {
+		return err
+	}
+	return db.Exec("CREATE INDEX IF NOT EXISTS idx_books_title_trgm ON public.books USING GIN (title gin_trgm_ops)").Error
+}
+
+// migrateDefaultTenant makes sure the fallback tenant exists and owns every
+// book created before tenants were introduced
+func migrateDefaultTenant(db *gorm.DB) error {
+	slug := os.Getenv("DEFAULT_TENANT")
+	if slug == "" {
+		slug = model.DefaultTenantSlug
+	}
+
+	tenant := model.Tenant{}
+	err := db.Where(model.Tenant{Slug: slug}).
+		Attrs(model.Tenant{Name: slug, Status: model.TenantStatusActive}).
+		FirstOrCreate(&tenant).Error
+	if err != nil {
+		return err
+	}
+
+	return db.Model(&model.Book{}).
+		Where("tenant_id IS NULL OR tenant_id = 0").
+		Update("tenant_id", tenant.ID).Error
+}
+
+// migrateAuthors turns the free-text author of every book without
+// contributors into an author row, spelling variants of the same name
+// within a tenant collapse into one author
+func migrateAuthors(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var books []model.Book
+		err := tx.Where("id NOT IN (?)", tx.Model(&model.BookAuthor{}).Select("book_id")).
+			Order("id").
+			Find(&books).Error
+		if err != nil {
+			return err
+		}
+
+		type key struct {
+			tenantID int64
+			name     string
+		}
+		authors := map[key]int64{}
+
+		for _, book := range books {
+			k := key{tenantID: book.TenantID, name: model.NormalizeAuthorName(book.Author)}
+			if k.name == "" {
+				continue
+			}
+
+			if _, ok := authors[k]; !ok {
+				author := model.Author{}
+				err := tx.Where(model.Author{TenantID: k.tenantID, NormalizedName: k.name}).
+					Attrs(model.Author{Name: book.Author}).
+					FirstOrCreate(&author).Error
+				if err != nil {
+					return err
+				}
+				authors[k] = author.ID
+			}
+
+			err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.BookAuthor{
+				BookID:   book.ID,
+				AuthorID: authors[k],
+				Role:     model.AuthorRoleAuthor,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// migrateSeries turns the free-text series of every book outside a series
+// into series rows, ordered by the books' series positions. names differing
+// only in case within a tenant are one series
+func migrateSeries(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var books []model.Book
+		err := tx.Where("series_name <> ''").
+			Where("id NOT IN (?)", tx.Model(&model.SeriesEntry{}).Select("book_id")).
+			Order("tenant_id, LOWER(series_name), series_position, id").
+			Find(&books).Error
+		if err != nil {
+			return err
+		}
+
+		type key struct {
+			tenantID int64
+			name     string
+		}
+		positions := map[key]int{}
+		series := map[key]int64{}
+
+		for _, book := range books {
+			k := key{tenantID: book.TenantID, name: strings.ToLower(strings.TrimSpace(book.SeriesName))}
+			if k.name == "" {
+				continue
+			}
+
+			if _, ok := series[k]; !ok {
+				s := model.Series{}
+				err := tx.Where("tenant_id = ? AND LOWER(name) = ?", k.tenantID, k.name).
+					Attrs(model.Series{TenantID: k.tenantID, Name: strings.TrimSpace(book.SeriesName)}).
+					FirstOrCreate(&s).Error
+				if err != nil {
+					return err
+				}
+				series[k] = s.ID
+
+				var count int64
+				if err := tx.Model(&model.SeriesEntry{}).Where("series_id = ?", s.ID).Count(&count).Error; err != nil {
+					return err
+				}
+				positions[k] = int(count)
+			}
+
+			positions[k]++
+			err := tx.Create(&model.SeriesEntry{
+				TenantID: book.TenantID,
+				SeriesID: series[k],
+				BookID:   book.ID,
+				Position: positions[k],
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"ninth-learn/outbox"
+	"os"
+	"time"
+)
+
+// OutboxPublisher opens the broker selected by OUTBOX_PUBLISHER, stdout,
+// file, nats or kafka. without one the events reach no broker, only the
+// webhooks and watchers of the books
+func OutboxPublisher() (outbox.EventPublisher, error) {
+	switch publisher := os.Getenv("OUTBOX_PUBLISHER"); publisher {
+	case "":
+		log.Printf("OUTBOX_PUBLISHER is not set, book events are published to no broker")
+		return outbox.NewWriterPublisher(io.Discard), nil
+	case "stdout":
+		return outbox.NewStdoutPublisher(), nil
+	case "file":
+		return outbox.NewFilePublisher(envOr("OUTBOX_FILE", "./data/outbox.jsonl"))
+	case "nats":
+		return outbox.NewNATSPublisher(envOr("NATS_URL", "nats://localhost:4222"), envOr("OUTBOX_SUBJECT", "catalogue"))
+	case "kafka":
+		return outbox.NewKafkaPublisher(envOr("KAFKA_REST_URL", "http://localhost:8082"), envOr("OUTBOX_TOPIC", "catalogue.events")), nil
+	default:
+		return nil, fmt.Errorf("unknown OUTBOX_PUBLISHER %q", publisher)
+	}
+}
+
+// OutboxInterval is how often the outbox is relayed, OUTBOX_INTERVAL takes
+// a Go duration such as 500ms
+func OutboxInterval() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("OUTBOX_INTERVAL")); err == nil && d > 0 {
+		return d
+	}
+	return time.Second
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
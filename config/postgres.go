@@ -2,7 +2,6 @@ package config
 
 import (
 	"fmt"
-	"ninth-learn/model"
 	"os"
 
 	"gorm.io/driver/postgres"
@@ -47,7 +46,7 @@ func InitPostgres() error {
 	}
 
 	// Migrate the schema
-	err = PSQL.DB.AutoMigrate(&model.Book{})
+	err = Migrate(PSQL.DB)
 	if err != nil {
 		return err
 	}
@@ -55,11 +54,14 @@ func InitPostgres() error {
 	return nil
 }
 
-func (p *Postgres) OpenConnection() error {
-	// init dsn
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", p.Address, p.Port, p.Username, p.Password, p.Database)
+// DSN is the connection string of the database. the application name tells
+// the instance that wrote a row to the books trigger
+func (p *Postgres) DSN() string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable application_name=%s", p.Address, p.Port, p.Username, p.Password, p.Database, InstanceID())
+}
 
-	dbConnection, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+func (p *Postgres) OpenConnection() error {
+	dbConnection, err := gorm.Open(postgres.Open(p.DSN()), &gorm.Config{})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,15 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// SchedulerInterval is how often background jobs run, SCHEDULER_INTERVAL
+// takes a Go duration such as 15m
+func SchedulerInterval() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("SCHEDULER_INTERVAL")); err == nil && d > 0 {
+		return d
+	}
+	return time.Hour
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"ninth-learn/storage"
+	"os"
+)
+
+var (
+	Blobs storage.BlobStore
+)
+
+// InitStorage opens the blob store selected by STORAGE_DRIVER, local
+// (default) or s3
+func InitStorage() error {
+	switch driver := os.Getenv("STORAGE_DRIVER"); driver {
+	case "", "local":
+		path := os.Getenv("STORAGE_PATH")
+		if path == "" {
+			path = "./data/blobs"
+		}
+
+		store, err := storage.NewLocalStore(path)
+		if err != nil {
+			return err
+		}
+		Blobs = store
+	case "s3":
+		Blobs = storage.NewS3Store(storage.S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Region:    os.Getenv("S3_REGION"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+		})
+	default:
+		return fmt.Errorf("unknown STORAGE_DRIVER %q", driver)
+	}
+
+	fmt.Println("Successfully opened blob storage")
+	return nil
+}
@@ -0,0 +1,15 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// WebhookInterval is how often due webhook deliveries are sent,
+// WEBHOOK_INTERVAL takes a Go duration such as 30s
+func WebhookInterval() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("WEBHOOK_INTERVAL")); err == nil && d > 0 {
+		return d
+	}
+	return 10 * time.Second
+}
@@ -0,0 +1,273 @@
+package graph
+
+import (
+	"context"
+	"ninth-learn/model"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+type bookPageResolver struct {
+	books  []model.Book
+	total  int64
+	offset int
+}
+
+func (r *bookPageResolver) Items() []*bookResolver {
+	items := make([]*bookResolver, 0, len(r.books))
+	for i := range r.books {
+		items = append(items, &bookResolver{book: &r.books[i]})
+	}
+	return items
+}
+
+func (r *bookPageResolver) Total() int32 {
+	return int32(r.total)
+}
+
+func (r *bookPageResolver) HasMore() bool {
+	return int64(r.offset+len(r.books)) < r.total
+}
+
+type bookResolver struct {
+	book *model.Book
+}
+
+func (r *bookResolver) ID() graphql.ID {
+	return graphql.ID(formatID(r.book.ID))
+}
+
+func (r *bookResolver) Title() string {
+	return r.book.Title
+}
+
+func (r *bookResolver) Subtitle() string {
+	return r.book.Subtitle
+}
+
+func (r *bookResolver) Author() string {
+	return r.book.Author
+}
+
+func (r *bookResolver) Description() string {
+	return r.book.Description
+}
+
+func (r *bookResolver) Isbn10() string {
+	return r.book.ISBN10
+}
+
+func (r *bookResolver) Isbn13() string {
+	return r.book.ISBN13
+}
+
+func (r *bookResolver) Publisher() string {
+	return r.book.Publisher
+}
+
+func (r *bookResolver) PublicationDate() *string {
+	if r.book.PublicationDate == nil {
+		return nil
+	}
+	date := r.book.PublicationDate.String()
+	return &date
+}
+
+func (r *bookResolver) Edition() string {
+	return r.book.Edition
+}
+
+func (r *bookResolver) Language() string {
+	return r.book.Language
+}
+
+func (r *bookResolver) DisplayLanguage() *string {
+	if r.book.DisplayLanguage == "" {
+		return nil
+	}
+	return &r.book.DisplayLanguage
+}
+
+func (r *bookResolver) PageCount() int32 {
+	return int32(r.book.PageCount)
+}
+
+func (r *bookResolver) Format() string {
+	return r.book.Format
+}
+
+func (r *bookResolver) AverageRating() float64 {
+	return r.book.RatingAverage
+}
+
+func (r *bookResolver) ReviewCount() int32 {
+	return int32(r.book.RatingCount)
+}
+
+func (r *bookResolver) TotalCopies() int32 {
+	return int32(r.book.TotalCopies)
+}
+
+func (r *bookResolver) AvailableCopies() int32 {
+	return int32(r.book.AvailableCopies)
+}
+
+func (r *bookResolver) HoldQueueLength() int32 {
+	return int32(r.book.HoldQueueLength)
+}
+
+func (r *bookResolver) Authors() []*contributorResolver {
+	authors := make([]*contributorResolver, 0, len(r.book.Authors))
+	for i := range r.book.Authors {
+		authors = append(authors, &contributorResolver{link: &r.book.Authors[i]})
+	}
+	return authors
+}
+
+func (r *bookResolver) Categories() []*categoryResolver {
+	categories := make([]*categoryResolver, 0, len(r.book.Categories))
+	for i := range r.book.Categories {
+		categories = append(categories, &categoryResolver{category: &r.book.Categories[i]})
+	}
+	return categories
+}
+
+func (r *bookResolver) Tags() []string {
+	tags := make([]string, 0, len(r.book.Tags))
+	for _, t := range r.book.Tags {
+		tags = append(tags, t.Name)
+	}
+	return tags
+}
+
+func (r *bookResolver) Series(ctx context.Context) ([]*seriesPositionResolver, error) {
+	series, err := stateFrom(ctx).series.Load(ctx, r.book.ID)()
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]*seriesPositionResolver, 0, len(series))
+	for i := range series {
+		positions = append(positions, &seriesPositionResolver{series: &series[i]})
+	}
+	return positions, nil
+}
+
+func (r *bookResolver) RelatedWorks(ctx context.Context) ([]*relatedWorkResolver, error) {
+	works, err := stateFrom(ctx).relatedWorks.Load(ctx, r.book.ID)()
+	if err != nil {
+		return nil, err
+	}
+
+	related := make([]*relatedWorkResolver, 0, len(works))
+	for i := range works {
+		related = append(related, &relatedWorkResolver{work: &works[i]})
+	}
+	return related, nil
+}
+
+func (r *bookResolver) CreatedAt() string {
+	return r.book.CreatedAt.Format(time.RFC3339)
+}
+
+func (r *bookResolver) UpdatedAt() string {
+	return r.book.UpdatedAt.Format(time.RFC3339)
+}
+
+type contributorResolver struct {
+	link *model.BookAuthor
+}
+
+func (r *contributorResolver) ID() graphql.ID {
+	return graphql.ID(formatID(r.link.AuthorID))
+}
+
+func (r *contributorResolver) Name() string {
+	if r.link.Author == nil {
+		return ""
+	}
+	return r.link.Author.Name
+}
+
+func (r *contributorResolver) Role() string {
+	return r.link.Role
+}
+
+type categoryResolver struct {
+	category *model.Category
+}
+
+func (r *categoryResolver) ID() graphql.ID {
+	return graphql.ID(formatID(r.category.ID))
+}
+
+func (r *categoryResolver) Name() string {
+	return r.category.Name
+}
+
+func (r *categoryResolver) ParentID() *graphql.ID {
+	if r.category.ParentID == nil {
+		return nil
+	}
+	id := graphql.ID(formatID(*r.category.ParentID))
+	return &id
+}
+
+type seriesPositionResolver struct {
+	series *model.BookSeries
+}
+
+func (r *seriesPositionResolver) SeriesID() graphql.ID {
+	return graphql.ID(formatID(r.series.SeriesID))
+}
+
+func (r *seriesPositionResolver) Name() string {
+	return r.series.Name
+}
+
+func (r *seriesPositionResolver) Position() int32 {
+	return int32(r.series.Position)
+}
+
+func (r *seriesPositionResolver) Total() int32 {
+	return int32(r.series.Total)
+}
+
+func (r *seriesPositionResolver) Previous(ctx context.Context) (*bookResolver, error) {
+	return loadBook(ctx, r.series.Previous)
+}
+
+func (r *seriesPositionResolver) Next(ctx context.Context) (*bookResolver, error) {
+	return loadBook(ctx, r.series.Next)
+}
+
+type relatedWorkResolver struct {
+	work *model.RelatedWork
+}
+
+func (r *relatedWorkResolver) ID() graphql.ID {
+	return graphql.ID(formatID(r.work.RelationID))
+}
+
+func (r *relatedWorkResolver) Type() string {
+	return r.work.Type
+}
+
+func (r *relatedWorkResolver) Book(ctx context.Context) (*bookResolver, error) {
+	return loadBook(ctx, r.work.Book)
+}
+
+// loadBook resolves a link to the full book, batched with the other links
+// of the request
+func loadBook(ctx context.Context, link *model.BookLink) (*bookResolver, error) {
+	if link == nil {
+		return nil, nil
+	}
+
+	book, err := stateFrom(ctx).books.Load(ctx, link.ID)()
+	if err != nil || book == nil {
+		return nil, err
+	}
+	return &bookResolver{book: book}, nil
+}
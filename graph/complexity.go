@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// pagedFields return a page of books, their selection is paid for once per
+// book of the page
+var pagedFields = map[string]bool{
+	"books":       true,
+	"searchBooks": true,
+}
+
+// complexity estimates the work a query asks for. every field costs one,
+// the selection of a paged field costs its limit times over.
+func complexity(query, operationName string, variables map[string]interface{}) (int, error) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return 0, err
+	}
+
+	op := doc.Operations.ForName(operationName)
+	if op == nil {
+		return 0, errors.New("unknown operation")
+	}
+
+	c := costCounter{doc: doc, variables: variables, visiting: map[string]bool{}}
+	return c.selectionCost(op.SelectionSet), nil
+}
+
+type costCounter struct {
+	doc       *ast.QueryDocument
+	variables map[string]interface{}
+	// fragments being counted, a fragment spreading itself counts once
+	visiting map[string]bool
+}
+
+func (c costCounter) selectionCost(set ast.SelectionSet) int {
+	cost := 0
+	for _, selection := range set {
+		switch s := selection.(type) {
+		case *ast.Field:
+			children := c.selectionCost(s.SelectionSet)
+			if pagedFields[s.Name] {
+				children *= c.limit(s)
+			}
+			cost += 1 + children
+		case *ast.InlineFragment:
+			cost += c.selectionCost(s.SelectionSet)
+		case *ast.FragmentSpread:
+			fragment := c.doc.Fragments.ForName(s.Name)
+			if fragment == nil || c.visiting[s.Name] {
+				continue
+			}
+			c.visiting[s.Name] = true
+			cost += c.selectionCost(fragment.SelectionSet)
+			delete(c.visiting, s.Name)
+		}
+	}
+	return cost
+}
+
+// limit reads the page size of a paged field, literal or from a variable
+func (c costCounter) limit(field *ast.Field) int {
+	arg := field.Arguments.ForName("limit")
+	if arg == nil || arg.Value == nil {
+		return 20
+	}
+
+	raw := arg.Value.Raw
+	if arg.Value.Kind == ast.Variable {
+		switch v := c.variables[arg.Value.Raw].(type) {
+		case float64:
+			return int(v)
+		case json.Number:
+			raw = v.String()
+		default:
+			return 20
+		}
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		return 20
+	}
+	return n
+}
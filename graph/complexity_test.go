@@ -0,0 +1,33 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Complexity(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		want      int
+	}{
+		{"single book", `{ book(id: 1) { id title } }`, nil, 3},
+		{"page of the default limit", `{ books { total items { id } } }`, nil, 1 + 20*3},
+		{"page of a literal limit", `{ books(limit: 5) { items { id title } } }`, nil, 1 + 5*3},
+		{"limit from a variable", `query($n: Int) { searchBooks(query: "x", limit: $n) { items { id } } }`, map[string]interface{}{"n": float64(50)}, 1 + 50*2},
+		{"fragments", `{ book(id: 1) { ...f } } fragment f on Book { id title }`, nil, 3},
+		{"nested pages multiply", `{ books(limit: 10) { items { relatedWorks { book { id } } } } }`, nil, 1 + 10*4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := complexity(tt.query, "", tt.variables)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := complexity(`{ book(id: 1) {`, "", nil)
+	assert.Error(t, err)
+}
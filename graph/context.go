@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"context"
+	"ninth-learn/model"
+	"ninth-learn/service"
+	"strconv"
+
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+type contextKey struct{}
+
+// requestState is what resolvers of one request share: the tenant's service
+// and loaders batching the lookups of related entities
+type requestState struct {
+	app            service.ServiceInterface
+	acceptLanguage string
+	books          *dataloader.Loader[int64, *model.Book]
+	series         *dataloader.Loader[int64, []model.BookSeries]
+	relatedWorks   *dataloader.Loader[int64, []model.RelatedWork]
+}
+
+func newRequestContext(ctx context.Context, app service.ServiceInterface, acceptLanguage string) context.Context {
+	state := &requestState{app: app, acceptLanguage: acceptLanguage}
+
+	state.books = dataloader.NewBatchedLoader(func(ctx context.Context, ids []int64) []*dataloader.Result[*model.Book] {
+		books, err := app.GetBooksByIds(ids)
+		if err != nil {
+			return failAll[*model.Book](len(ids), err)
+		}
+		byID := make(map[int64]*model.Book, len(books))
+		for i := range books {
+			byID[books[i].ID] = &books[i]
+		}
+		results := make([]*dataloader.Result[*model.Book], len(ids))
+		for i, id := range ids {
+			// a book deleted in the meantime resolves to null
+			results[i] = &dataloader.Result[*model.Book]{Data: byID[id]}
+		}
+		return results
+	})
+
+	state.series = dataloader.NewBatchedLoader(func(ctx context.Context, ids []int64) []*dataloader.Result[[]model.BookSeries] {
+		series, err := app.GetSeriesOfBooks(ids)
+		if err != nil {
+			return failAll[[]model.BookSeries](len(ids), err)
+		}
+		results := make([]*dataloader.Result[[]model.BookSeries], len(ids))
+		for i, id := range ids {
+			results[i] = &dataloader.Result[[]model.BookSeries]{Data: series[id]}
+		}
+		return results
+	})
+
+	state.relatedWorks = dataloader.NewBatchedLoader(func(ctx context.Context, ids []int64) []*dataloader.Result[[]model.RelatedWork] {
+		works, err := app.GetRelatedWorksOfBooks(ids)
+		if err != nil {
+			return failAll[[]model.RelatedWork](len(ids), err)
+		}
+		results := make([]*dataloader.Result[[]model.RelatedWork], len(ids))
+		for i, id := range ids {
+			results[i] = &dataloader.Result[[]model.RelatedWork]{Data: works[id]}
+		}
+		return results
+	})
+
+	return context.WithValue(ctx, contextKey{}, state)
+}
+
+func stateFrom(ctx context.Context) *requestState {
+	return ctx.Value(contextKey{}).(*requestState)
+}
+
+// primeBooks saves loading books already at hand again
+func (s *requestState) primeBooks(ctx context.Context, books []model.Book) {
+	for i := range books {
+		s.books.Prime(ctx, books[i].ID, &books[i])
+	}
+}
+
+func failAll[V any](n int, err error) []*dataloader.Result[V] {
+	results := make([]*dataloader.Result[V], n)
+	for i := range results {
+		results[i] = &dataloader.Result[V]{Error: err}
+	}
+	return results
+}
+
+func formatID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,45 @@
+package graph
+
+import (
+	"errors"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+)
+
+const (
+	codeBadInput = "BAD_USER_INPUT"
+	codeNotFound = "NOT_FOUND"
+	codeConflict = "CONFLICT"
+)
+
+// resolverError carries a machine readable code in the error's extensions
+type resolverError struct {
+	message string
+	code    string
+}
+
+func (e resolverError) Error() string {
+	return e.message
+}
+
+func (e resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+func badInput(err error) error {
+	return resolverError{message: err.Error(), code: codeBadInput}
+}
+
+// serviceError gives the errors the REST handlers map to 404 and 409 their
+// GraphQL codes
+func serviceError(err error) error {
+	switch {
+	case err.Error() == helper.ErrNotFound:
+		return resolverError{message: err.Error(), code: codeNotFound}
+	case err.Error() == helper.ErrDuplicatedKey:
+		return resolverError{message: "A book with this ISBN already exists", code: codeConflict}
+	case errors.Is(err, model.ErrBookHasCopies), errors.Is(err, model.ErrLoansActive), errors.Is(err, model.ErrLoansFined):
+		return resolverError{message: err.Error(), code: codeConflict}
+	}
+	return err
+}
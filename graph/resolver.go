@@ -0,0 +1,293 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"strconv"
+	"strings"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// Resolver is the root of the schema, every request brings its own service
+// through the context
+type Resolver struct{}
+
+type bookFilterInput struct {
+	CategoryID      *graphql.ID
+	Tags            *[]string
+	MatchAllTags    *bool
+	Publisher       *string
+	Language        *string
+	Format          *string
+	Series          *string
+	PublishedAfter  *string
+	PublishedBefore *string
+	Sort            *string
+}
+
+type contributorInput struct {
+	AuthorID graphql.ID
+	Role     string
+}
+
+type bookInput struct {
+	Title           string
+	Subtitle        *string
+	Author          string
+	Description     string
+	ISBN10          *string
+	ISBN13          *string
+	Publisher       *string
+	PublicationDate *string
+	Edition         *string
+	Language        *string
+	PageCount       *int32
+	Format          *string
+	SeriesName      *string
+	SeriesPosition  *float64
+	Authors         *[]contributorInput
+	CategoryIDs     *[]graphql.ID
+	Tags            *[]string
+}
+
+func (r *Resolver) Book(ctx context.Context, args struct{ ID graphql.ID }) (*bookResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	state := stateFrom(ctx)
+	book, err := state.app.GetLocalizedBook(id, state.acceptLanguage)
+	if err != nil {
+		if err := serviceError(err); isCode(err, codeNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &bookResolver{book: &book}, nil
+}
+
+func (r *Resolver) Books(ctx context.Context, args struct {
+	Limit  int32
+	Offset int32
+	Filter *bookFilterInput
+}) (*bookPageResolver, error) {
+	filter, err := args.Filter.toModel()
+	if err != nil {
+		return nil, badInput(err)
+	}
+	return books(ctx, filter, args.Limit, args.Offset)
+}
+
+func (r *Resolver) SearchBooks(ctx context.Context, args struct {
+	Query  string
+	Limit  int32
+	Offset int32
+}) (*bookPageResolver, error) {
+	query := strings.TrimSpace(args.Query)
+	if query == "" {
+		return nil, badInput(errors.New("query must not be blank"))
+	}
+	return books(ctx, model.BookFilter{Query: query}, args.Limit, args.Offset)
+}
+
+func (r *Resolver) CreateBook(ctx context.Context, args struct{ Input bookInput }) (*bookResolver, error) {
+	in, err := args.Input.toModel()
+	if err != nil {
+		return nil, badInput(err)
+	}
+
+	res, err := stateFrom(ctx).app.CreateBook(in)
+	if err != nil {
+		if err.Error() == helper.ErrNotFound {
+			return nil, badInput(errors.New("Unknown author or category"))
+		}
+		return nil, serviceError(err)
+	}
+	return &bookResolver{book: &res}, nil
+}
+
+func (r *Resolver) UpdateBook(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input bookInput
+}) (*bookResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	in, err := args.Input.toModel()
+	if err != nil {
+		return nil, badInput(err)
+	}
+	in.ID = id
+
+	res, err := stateFrom(ctx).app.UpdateBook(in)
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	return &bookResolver{book: &res}, nil
+}
+
+func (r *Resolver) DeleteBook(ctx context.Context, args struct {
+	ID    graphql.ID
+	Force bool
+}) (bool, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := stateFrom(ctx).app.DeleteBook(id, args.Force); err != nil {
+		return false, serviceError(err)
+	}
+	return true, nil
+}
+
+// books loads a page of the books matching the filter
+func books(ctx context.Context, filter model.BookFilter, limit, offset int32) (*bookPageResolver, error) {
+	filter.Limit, filter.Offset = int(limit), int(offset)
+	if filter.Limit < 1 || filter.Limit > maxPageSize {
+		return nil, badInput(errors.New("limit must be between 1 and " + strconv.Itoa(maxPageSize)))
+	}
+	if filter.Offset < 0 {
+		return nil, badInput(errors.New("offset must not be negative"))
+	}
+
+	state := stateFrom(ctx)
+	items, err := state.app.GetBooks(filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := state.app.CountBooks(filter)
+	if err != nil {
+		return nil, err
+	}
+	state.primeBooks(ctx, items)
+
+	return &bookPageResolver{books: items, total: total, offset: filter.Offset}, nil
+}
+
+func (f *bookFilterInput) toModel() (filter model.BookFilter, err error) {
+	if f == nil {
+		return filter, nil
+	}
+
+	if f.CategoryID != nil {
+		if filter.CategoryID, err = parseID(*f.CategoryID); err != nil {
+			return filter, err
+		}
+	}
+	if f.Tags != nil {
+		filter.Tags = *f.Tags
+	}
+	filter.MatchAllTags = f.MatchAllTags != nil && *f.MatchAllTags
+	filter.Publisher = value(f.Publisher)
+	filter.Language = strings.ToLower(value(f.Language))
+	filter.Format = strings.ToLower(value(f.Format))
+	filter.SeriesName = value(f.Series)
+
+	switch filter.Sort = value(f.Sort); filter.Sort {
+	case "", model.BookSortRating:
+	default:
+		return filter, errors.New("sort must be rating")
+	}
+
+	if f.PublishedAfter != nil {
+		date, err := model.ParseDate(*f.PublishedAfter)
+		if err != nil {
+			return filter, errors.New("publishedAfter " + err.Error())
+		}
+		filter.PublishedAfter = &date
+	}
+	if f.PublishedBefore != nil {
+		date, err := model.ParseDate(*f.PublishedBefore)
+		if err != nil {
+			return filter, errors.New("publishedBefore " + err.Error())
+		}
+		filter.PublishedBefore = &date
+	}
+
+	return filter, nil
+}
+
+// toModel builds the book the way the REST handlers bind and validate it
+func (in bookInput) toModel() (book model.Book, err error) {
+	book = model.Book{
+		Title:       in.Title,
+		Subtitle:    value(in.Subtitle),
+		Author:      in.Author,
+		Description: in.Description,
+		ISBN10:      value(in.ISBN10),
+		ISBN13:      value(in.ISBN13),
+		Publisher:   value(in.Publisher),
+		Edition:     value(in.Edition),
+		Language:    value(in.Language),
+		Format:      value(in.Format),
+		SeriesName:  value(in.SeriesName),
+	}
+	if in.PageCount != nil {
+		book.PageCount = int(*in.PageCount)
+	}
+	if in.SeriesPosition != nil {
+		book.SeriesPosition = *in.SeriesPosition
+	}
+	if in.PublicationDate != nil {
+		date, err := model.ParseDate(*in.PublicationDate)
+		if err != nil {
+			return book, errors.New("publicationDate " + err.Error())
+		}
+		book.PublicationDate = &date
+	}
+
+	if in.Authors != nil {
+		book.Authors = []model.BookAuthor{}
+		for _, a := range *in.Authors {
+			id, err := parseID(a.AuthorID)
+			if err != nil {
+				return book, err
+			}
+			book.Authors = append(book.Authors, model.BookAuthor{AuthorID: id, Role: a.Role})
+		}
+	}
+	if in.CategoryIDs != nil {
+		book.Categories = []model.Category{}
+		for _, categoryID := range *in.CategoryIDs {
+			id, err := parseID(categoryID)
+			if err != nil {
+				return book, err
+			}
+			book.Categories = append(book.Categories, model.Category{ID: id})
+		}
+	}
+	if in.Tags != nil {
+		book.Tags = []model.Tag{}
+		for _, name := range *in.Tags {
+			book.Tags = append(book.Tags, model.Tag{Name: name})
+		}
+	}
+
+	return book, book.Validation()
+}
+
+func parseID(id graphql.ID) (int64, error) {
+	n, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil || n < 1 {
+		return 0, badInput(errors.New("invalid ID " + strconv.Quote(string(id))))
+	}
+	return n, nil
+}
+
+func isCode(err error, code string) bool {
+	var e resolverError
+	return errors.As(err, &e) && e.code == code
+}
+
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,69 @@
+// Package graph serves the book catalogue over GraphQL, on top of the same
+// service as the REST handlers.
+package graph
+
+import (
+	_ "embed"
+	"net/http"
+	"ninth-learn/middleware"
+	"ninth-learn/service"
+
+	"github.com/gin-gonic/gin"
+	graphql "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// maxPageSize caps the limit of paged queries
+const maxPageSize = 100
+
+// Limits bound the queries a client may send
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// Server answers GraphQL queries
+type Server struct {
+	app    service.ServiceInterface
+	schema *graphql.Schema
+	limits Limits
+}
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func NewServer(app service.ServiceInterface, limits Limits) *Server {
+	schema := graphql.MustParseSchema(schemaSDL, &Resolver{},
+		graphql.MaxDepth(limits.MaxDepth),
+		// list items resolve side by side so their loads land in one batch
+		graphql.MaxParallelism(maxPageSize),
+	)
+	return &Server{app: app, schema: schema, limits: limits}
+}
+
+// Handle runs a query for the tenant of the request. queries over the
+// complexity limit are refused before anything is resolved.
+func (s *Server) Handle(c *gin.Context) {
+	in := request{}
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, graphql.Response{Errors: []*gqlerrors.QueryError{gqlerrors.Errorf("%s", err)}})
+		return
+	}
+
+	cost, err := complexity(in.Query, in.OperationName, in.Variables)
+	if err == nil && s.limits.MaxComplexity > 0 && cost > s.limits.MaxComplexity {
+		c.JSON(http.StatusOK, graphql.Response{Errors: []*gqlerrors.QueryError{
+			gqlerrors.Errorf("query complexity %d exceeds the limit of %d", cost, s.limits.MaxComplexity),
+		}})
+		return
+	}
+
+	ctx := newRequestContext(c.Request.Context(), s.app.WithTenant(middleware.TenantID(c)), c.GetHeader("Accept-Language"))
+	c.JSON(http.StatusOK, s.schema.Exec(ctx, in.Query, in.OperationName, in.Variables))
+}
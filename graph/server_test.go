@@ -0,0 +1,129 @@
+package graph
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"ninth-learn/model"
+	"ninth-learn/service"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeApp serves a fixed catalogue and counts the batch lookups
+type fakeApp struct {
+	service.ServiceInterface
+	mu        sync.Mutex
+	books     map[int64]model.Book
+	relations map[int64][]model.RelatedWork
+	byIDs     [][]int64
+	works     [][]int64
+}
+
+func (f *fakeApp) WithTenant(tenantID int64) service.ServiceInterface {
+	return f
+}
+
+func (f *fakeApp) GetBooks(filter model.BookFilter) ([]model.Book, error) {
+	return []model.Book{f.books[1], f.books[2]}, nil
+}
+
+func (f *fakeApp) CountBooks(filter model.BookFilter) (int64, error) {
+	return 2, nil
+}
+
+func (f *fakeApp) GetBooksByIds(ids []int64) ([]model.Book, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.byIDs = append(f.byIDs, ids)
+
+	res := []model.Book{}
+	for _, id := range ids {
+		if b, ok := f.books[id]; ok {
+			res = append(res, b)
+		}
+	}
+	return res, nil
+}
+
+func (f *fakeApp) GetRelatedWorksOfBooks(ids []int64) (map[int64][]model.RelatedWork, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.works = append(f.works, ids)
+	return f.relations, nil
+}
+
+func newFakeApp() *fakeApp {
+	return &fakeApp{
+		books: map[int64]model.Book{
+			1: {ID: 1, Title: "Dune"},
+			2: {ID: 2, Title: "Foundation"},
+			3: {ID: 3, Title: "Dune Messiah"},
+			4: {ID: 4, Title: "Foundation and Empire"},
+		},
+		relations: map[int64][]model.RelatedWork{
+			1: {{RelationID: 1, Type: model.RelationPrequelOf, Book: model.NewBookLink(3, "Dune Messiah")}},
+			2: {{RelationID: 2, Type: model.RelationPrequelOf, Book: model.NewBookLink(4, "Foundation and Empire")}},
+		},
+	}
+}
+
+func execute(t *testing.T, server *Server, query string) map[string]interface{} {
+	gin.SetMode(gin.TestMode)
+	body, _ := json.Marshal(map[string]interface{}{"query": query})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	server.Handle(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	return res
+}
+
+func Test_Server_BatchesRelatedBooks(t *testing.T) {
+	app := newFakeApp()
+	server := NewServer(app, Limits{MaxDepth: 8, MaxComplexity: 500})
+
+	res := execute(t, server, `{ books(limit: 2) { total items { title relatedWorks { type book { title } } } } }`)
+	assert.Nil(t, res["errors"])
+
+	items := res["data"].(map[string]interface{})["books"].(map[string]interface{})["items"].([]interface{})
+	assert.Len(t, items, 2)
+	work := items[0].(map[string]interface{})["relatedWorks"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, model.RelationPrequelOf, work["type"])
+	assert.Equal(t, "Dune Messiah", work["book"].(map[string]interface{})["title"])
+
+	// one lookup for the relations of the page, one for the related books
+	assert.Len(t, app.works, 1)
+	assert.ElementsMatch(t, []int64{1, 2}, app.works[0])
+	assert.Len(t, app.byIDs, 1)
+	assert.ElementsMatch(t, []int64{3, 4}, app.byIDs[0])
+}
+
+func Test_Server_Limits(t *testing.T) {
+	server := NewServer(newFakeApp(), Limits{MaxDepth: 3, MaxComplexity: 50})
+
+	res := execute(t, server, `{ books(limit: 100) { items { id } } }`)
+	assert.Nil(t, res["data"])
+	assert.Contains(t, res["errors"].([]interface{})[0].(map[string]interface{})["message"], "complexity")
+
+	res = execute(t, server, `{ books(limit: 1) { items { relatedWorks { book { id } } } } }`)
+	assert.NotNil(t, res["errors"])
+
+	res = execute(t, server, `{ books(limit: 1) { items { id } } }`)
+	assert.Nil(t, res["errors"])
+}
+
+func Test_Server_BadLimit(t *testing.T) {
+	res := execute(t, NewServer(newFakeApp(), Limits{MaxDepth: 8}), `{ books(limit: 0) { total } }`)
+	err := res["errors"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, codeBadInput, err["extensions"].(map[string]interface{})["code"])
+}
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"errors"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"ninth-learn/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateAuthor godoc
+// @Summary		 Creates a new Author
+// @Description  Create a new author
+// @Tags         authors
+// @Accept       json
+// @Produce      json
+// @Param 		 author_request body model.AuthorRequest true "Author request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /authors [post]
+func (h HttpServer) CreateAuthor(c *gin.Context) {
+	in := model.Author{}
+
+	err := c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).CreateAuthor(in)
+	if err != nil {
+		if errors.Is(err, service.ErrAuthorExists) {
+			helper.BadRequest(c, err.Error())
+			return
+		}
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetAuthorById godoc
+// @Summary      Show an author
+// @Description  get detail author by id
+// @Tags         authors
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Author ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /authors/{id} [get]
+func (h HttpServer) GetAuthorById(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid author ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetAuthorById(id)
+	if err != nil {
+		if err.Error() == helper.ErrNotFound {
+			helper.NotFound(c, err.Error())
+			return
+		}
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetAuthors godoc
+// @Summary      Show all author
+// @Description  get all author
+// @Tags         authors
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /authors [get]
+func (h HttpServer) GetAuthors(c *gin.Context) {
+	// call service
+	res, err := h.tenant(c).GetAuthors()
+	if err != nil {
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// UpdateAuthor godoc
+// @Summary      Update author
+// @Description  Update author by id
+// @Tags         authors
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Author ID"
+// @Param 		 author_request body model.AuthorRequest true "Author request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Router       /authors/{id} [put]
+func (h HttpServer) UpdateAuthor(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid author ID")
+		return
+	}
+
+	in := model.Author{}
+	err = c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+	in.ID = id
+	// call service
+	res, err := h.tenant(c).UpdateAuthor(in)
+	if err != nil {
+		helper.NotFound(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// DeleteAuthor godoc
+// @Summary      Delete author
+// @Description  Delete author by id, its books are kept
+// @Tags         authors
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Author ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Router       /authors/{id} [delete]
+func (h HttpServer) DeleteAuthor(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid author ID")
+		return
+	}
+
+	// call service
+	err = h.tenant(c).DeleteAuthor(id)
+	if err != nil {
+		helper.NotFound(c, err.Error())
+		return
+	}
+
+	helper.OkWithMessage(c, "Author deleted successfully")
+}
+
+// GetAuthorBooks godoc
+// @Summary      Show books of an author
+// @Description  get all book the author contributed to, in any role
+// @Tags         authors
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Author ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /authors/{id}/books [get]
+func (h HttpServer) GetAuthorBooks(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid author ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetAuthorBooks(id)
+	if err != nil {
+		if err.Error() == helper.ErrNotFound {
+			helper.NotFound(c, err.Error())
+			return
+		}
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
@@ -1,22 +1,26 @@
 package handler
 
 import (
+	"errors"
 	"ninth-learn/helper"
+	"ninth-learn/middleware"
 	"ninth-learn/model"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
 // CreateBook godoc
 // @Summary		 Creates a new Book
-// @Description  Create a new book
+// @Description  Create a new book, likely duplicates already in the catalogue are listed in possible_duplicates
 // @Tags         books
 // @Accept       json
 // @Produce      json
 // @Param 		 book_request body model.BookRequest true "Book request object"
 // @Success      200  {object}  helper.Response
 // @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
 // @Failure      500  {object}  helper.Response
 // @Router       /books [post]
 func (h HttpServer) CreateBook(c *gin.Context) {
@@ -35,8 +39,16 @@ func (h HttpServer) CreateBook(c *gin.Context) {
 	}
 
 	// call service
-	res, err := h.app.CreateBook(in)
+	res, err := h.tenant(c).CreateBook(in)
 	if err != nil {
+		if err.Error() == helper.ErrNotFound {
+			helper.BadRequest(c, "Unknown author or category")
+			return
+		}
+		if err.Error() == helper.ErrDuplicatedKey {
+			helper.Conflict(c, "A book with this ISBN already exists")
+			return
+		}
 		helper.InternalServerError(c, err.Error())
 		return
 	}
@@ -46,12 +58,17 @@ func (h HttpServer) CreateBook(c *gin.Context) {
 
 // GetBookById godoc
 // @Summary      Show a book
-// @Description  get detail book by id
+// @Description  get detail book by id, with the hold queue position of a member when member_id is given. the id of a book merged into another resolves to that book. title and description come from the translation best matching Accept-Language, the language used is in display_language. the book's place in its series and its related works are included. responses carry Last-Modified and an ETag, and are tagged with the book's surrogate key for CDN purges. with member_id the response is private.
 // @Tags         books
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "Book ID"
+// @Param        id                 path      int     true   "Book ID"
+// @Param        member_id          query     int     false  "Member ID"
+// @Param        Accept-Language    header    string  false  "Preferred languages"
+// @Param        If-None-Match      header    string  false  "ETag of the cached copy"
+// @Param        If-Modified-Since  header    string  false  "Last-Modified of the cached copy"
 // @Success      200  {object}  helper.Response
+// @Success      304
 // @Failure      400  {object}  helper.Response
 // @Failure      404  {object}  helper.Response
 // @Failure      500  {object}  helper.Response
@@ -64,8 +81,70 @@ func (h HttpServer) GetBookById(c *gin.Context) {
 	}
 
 	// call service
-	res, err := h.app.GetBookById(id)
+	res, err := h.tenant(c).GetBookDetail(id, c.GetHeader("Accept-Language"))
+	if err != nil {
+		if err.Error() == helper.ErrNotFound {
+			helper.NotFound(c, err.Error())
+			return
+		}
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+	if res.DisplayLanguage != "" {
+		c.Header("Content-Language", res.DisplayLanguage)
+	}
+
+	member := c.Query("member_id")
+	if member == "" {
+		keys := []string{model.BookSurrogateKey(res.ID)}
+		if res.ID != id {
+			keys = append(keys, model.BookSurrogateKey(id))
+		}
+		if h.cachedRead(c, res, lastModified(res), keys, "Accept-Language") {
+			return
+		}
+	} else {
+		memberID, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			helper.BadRequest(c, "Invalid member ID")
+			return
+		}
+
+		position, err := h.tenant(c).GetHoldPosition(id, memberID)
+		if err != nil {
+			helper.InternalServerError(c, err.Error())
+			return
+		}
+		res.HoldPosition = &position
+
+		// the hold position is the member's own
+		c.Header("Cache-Control", "private, no-store")
+		c.Header("Vary", "Accept-Language")
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetBookByISBN godoc
+// @Summary      Show a book by ISBN
+// @Description  get detail book by ISBN-10 or ISBN-13, hyphens are ignored
+// @Tags         books
+// @Accept       json
+// @Produce      json
+// @Param        isbn   path      string  true  "ISBN"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/isbn/{isbn} [get]
+func (h HttpServer) GetBookByISBN(c *gin.Context) {
+	// call service
+	res, err := h.tenant(c).GetBookByISBN(c.Param("isbn"))
 	if err != nil {
+		if errors.Is(err, model.ErrInvalidISBN) {
+			helper.BadRequest(c, "Invalid ISBN")
+			return
+		}
 		if err.Error() == helper.ErrNotFound {
 			helper.NotFound(c, err.Error())
 			return
@@ -79,23 +158,58 @@ func (h HttpServer) GetBookById(c *gin.Context) {
 
 // GetBooks godoc
 // @Summary      Show all book
-// @Description  get all book
+// @Description  get all book with their average rating, optionally filtered by category (including subcategories), tags and bibliographic metadata. responses carry Last-Modified, the latest change to any book of the tenant, and an ETag, and are tagged with the tenant's book list surrogate key for CDN purges.
 // @Tags         books
 // @Accept       json
 // @Produce      json
+// @Param        q           query     string  false  "Full-text search of titles and descriptions, translations included"
+// @Param        category    query     int     false  "Category ID"
+// @Param        tags        query     string  false  "Comma separated tag names"
+// @Param        tags_match  query     string  false  "Match any or all of the tags"  Enums(any, all)
+// @Param        publisher         query     string  false  "Publisher"
+// @Param        language          query     string  false  "ISO 639-1 language code"
+// @Param        format            query     string  false  "Format"  Enums(hardcover, paperback, ebook, audiobook)
+// @Param        series            query     string  false  "Series name, books come in series order"
+// @Param        published_after   query     string  false  "Published on or after, YYYY-MM-DD"
+// @Param        published_before  query     string  false  "Published on or before, YYYY-MM-DD"
+// @Param        sort              query     string  false  "Sort order, rating puts the best rated books first"  Enums(rating)
+// @Param        If-None-Match      header    string  false  "ETag of the cached copy"
+// @Param        If-Modified-Since  header    string  false  "Last-Modified of the cached copy"
 // @Success      200  {object}  helper.Response
+// @Success      304
 // @Failure      400  {object}  helper.Response
 // @Failure      404  {object}  helper.Response
 // @Failure      500  {object}  helper.Response
 // @Router       /books [get]
 func (h HttpServer) GetBooks(c *gin.Context) {
+	filter, err := bookFilter(c)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
 	// call service
-	res, err := h.app.GetBooks()
+	res, err := h.tenant(c).GetBooks(filter)
 	if err != nil {
 		helper.InternalServerError(c, err.Error())
 		return
 	}
 
+	// a book leaving the list dates the tenant's books rather than the list
+	modified, err := h.tenant(c).BooksModifiedAt()
+	if err != nil {
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+	if listed := lastModified(res...); listed.After(modified) {
+		modified = listed
+	}
+
+	keys := []string{model.BookListSurrogateKey(middleware.TenantID(c))}
+	if h.cachedRead(c, res, modified, keys) {
+		return
+	}
+
 	helper.Ok(c, res)
 }
 
@@ -110,6 +224,7 @@ func (h HttpServer) GetBooks(c *gin.Context) {
 // @Success      200  {object}  helper.Response
 // @Failure      400  {object}  helper.Response
 // @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
 // @Failure      500  {object}  helper.Response
 // @Router       /books/{id} [put]
 func (h HttpServer) UpdateBook(c *gin.Context) {
@@ -125,10 +240,20 @@ func (h HttpServer) UpdateBook(c *gin.Context) {
 		helper.BadRequest(c, err.Error())
 		return
 	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
 	in.ID = id
 	// call service
-	res, err := h.app.UpdateBook(in)
+	res, err := h.tenant(c).UpdateBook(in)
 	if err != nil {
+		if err.Error() == helper.ErrDuplicatedKey {
+			helper.Conflict(c, "A book with this ISBN already exists")
+			return
+		}
 		helper.NotFound(c, err.Error())
 		return
 	}
@@ -138,14 +263,16 @@ func (h HttpServer) UpdateBook(c *gin.Context) {
 
 // DeleteBook godoc
 // @Summary      Delete book
-// @Description  Delete book by id
+// @Description  Delete book by id, books with copies are only deleted when forced. books with copies on loan or fined loans on record are kept for the fines ledger
 // @Tags         books
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "Book ID"
+// @Param        id     path      int   true   "Book ID"
+// @Param        force  query     bool  false  "Also delete the copies of the book"
 // @Success      200  {object}  helper.Response
 // @Failure      400  {object}  helper.Response
 // @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
 // @Failure      500  {object}  helper.Response
 // @Router       /books/{id} [delete]
 func (h HttpServer) DeleteBook(c *gin.Context) {
@@ -155,12 +282,80 @@ func (h HttpServer) DeleteBook(c *gin.Context) {
 		return
 	}
 
+	force := false
+	if v := c.Query("force"); v != "" {
+		force, err = strconv.ParseBool(v)
+		if err != nil {
+			helper.BadRequest(c, "Invalid force flag")
+			return
+		}
+	}
+
 	// call service
-	err = h.app.DeleteBook(id)
+	err = h.tenant(c).DeleteBook(id, force)
 	if err != nil {
+		if errors.Is(err, model.ErrBookHasCopies) || errors.Is(err, model.ErrLoansActive) || errors.Is(err, model.ErrLoansFined) {
+			helper.Conflict(c, err.Error())
+			return
+		}
 		helper.NotFound(c, err.Error())
 		return
 	}
 
 	helper.OkWithMessage(c, "Book deleted successfully")
 }
+
+// bookFilter reads the GET /books query string
+func bookFilter(c *gin.Context) (filter model.BookFilter, err error) {
+	if category := c.Query("category"); category != "" {
+		filter.CategoryID, err = strconv.ParseInt(category, 10, 64)
+		if err != nil {
+			return filter, errors.New("Invalid category ID")
+		}
+	}
+
+	if tags := c.Query("tags"); tags != "" {
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				filter.Tags = append(filter.Tags, tag)
+			}
+		}
+	}
+
+	switch c.DefaultQuery("tags_match", "any") {
+	case "any":
+	case "all":
+		filter.MatchAllTags = true
+	default:
+		return filter, errors.New("tags_match must be any or all")
+	}
+
+	filter.Query = strings.TrimSpace(c.Query("q"))
+	filter.Publisher = c.Query("publisher")
+	filter.Language = strings.ToLower(c.Query("language"))
+	filter.Format = strings.ToLower(c.Query("format"))
+	filter.SeriesName = c.Query("series")
+
+	switch filter.Sort = c.Query("sort"); filter.Sort {
+	case "", model.BookSortRating:
+	default:
+		return filter, errors.New("sort must be rating")
+	}
+
+	if after := c.Query("published_after"); after != "" {
+		date, err := model.ParseDate(after)
+		if err != nil {
+			return filter, errors.New("published_after " + err.Error())
+		}
+		filter.PublishedAfter = &date
+	}
+	if before := c.Query("published_before"); before != "" {
+		date, err := model.ParseDate(before)
+		if err != nil {
+			return filter, errors.New("published_before " + err.Error())
+		}
+		filter.PublishedBefore = &date
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,92 @@
+package handler_test
+
+import (
+	"net/http"
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateBook_Metadata(t *testing.T) {
+	book := map[string]interface{}{
+		"title":       "Le Petit Prince",
+		"author":      "Antoine de Saint-Exupery",
+		"description": "A novella",
+	}
+	with := func(fields map[string]interface{}) map[string]interface{} {
+		res := map[string]interface{}{}
+		for k, v := range book {
+			res[k] = v
+		}
+		for k, v := range fields {
+			res[k] = v
+		}
+		return res
+	}
+
+	tests := []struct {
+		name       string
+		body       map[string]interface{}
+		wantStatus int
+		want       model.Book
+	}{
+		{
+			name:       "codes in any case and padding",
+			body:       with(map[string]interface{}{"language": " FR", "format": "Hardcover "}),
+			wantStatus: http.StatusOK,
+			want:       model.Book{Language: "fr", Format: model.FormatHardcover},
+		},
+		{
+			name:       "unknown format",
+			body:       with(map[string]interface{}{"format": "Scroll"}),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown language",
+			body:       with(map[string]interface{}{"language": " XX "}),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			books, duplicates := mocks.NewMockBookRepo(ctrl), mocks.NewMockDuplicateRepo(ctrl)
+			if tt.wantStatus == http.StatusOK {
+				books.EXPECT().CreateBook(gomock.Any()).DoAndReturn(func(in model.Book) (model.Book, error) {
+					assert.Equal(t, tt.want.Language, in.Language)
+					assert.Equal(t, tt.want.Format, in.Format)
+					in.ID = 5
+					return in, nil
+				})
+				duplicates.EXPECT().GetDuplicateCandidates(gomock.Any()).Return(nil, nil)
+			}
+
+			router := api(t, ctrl, mockRepo{MockBookRepo: books, MockDuplicateRepo: duplicates})
+			assertStatus(t, tt.wantStatus, serve(router, http.MethodPost, "/books", tt.body, nil))
+		})
+	}
+}
+
+func Test_UpdateBook_Metadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	books := mocks.NewMockBookRepo(ctrl)
+	books.EXPECT().UpdateBook(gomock.Any()).DoAndReturn(func(in model.Book) (model.Book, error) {
+		assert.Equal(t, int64(5), in.ID)
+		assert.Equal(t, "en", in.Language)
+		assert.Equal(t, model.FormatEbook, in.Format)
+		return in, nil
+	})
+
+	router := api(t, ctrl, mockRepo{MockBookRepo: books})
+	rec := serve(router, http.MethodPut, "/books/5", map[string]interface{}{
+		"title":       "Dune",
+		"author":      "Frank Herbert",
+		"description": "A novel",
+		"language":    "EN ",
+		"format":      " eBook",
+	}, nil)
+	assertStatus(t, http.StatusOK, rec)
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"ninth-learn/middleware"
+	"ninth-learn/model"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cachedRead sets the caching headers of a catalogue read and answers 304
+// when the client's copy is current. the ETag hashes the data, Last-Modified
+// is only sent for a non-zero modified. the response varies with the tenant
+// and with the negotiated headers listed in vary
+func (h HttpServer) cachedRead(c *gin.Context, data interface{}, modified time.Time, keys []string, vary ...string) (done bool) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return false
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	if h.cacheControl != "" {
+		c.Header("Cache-Control", h.cacheControl)
+	}
+	c.Header("Vary", strings.Join(append(vary, "Authorization", middleware.TenantHeader), ", "))
+	c.Header("ETag", etag)
+	if !modified.IsZero() {
+		c.Header("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+	c.Header("Surrogate-Key", strings.Join(keys, " "))
+
+	if notModified(c, etag, modified) {
+		c.Status(http.StatusNotModified)
+		return true
+	}
+	return false
+}
+
+// lastModified is the latest change to the books or their covers. every
+// write that changes a book's body, its copies, holds, ratings, translations,
+// relations and series included, dates the book
+func lastModified(books ...model.Book) time.Time {
+	var latest time.Time
+	for _, b := range books {
+		if b.UpdatedAt.After(latest) {
+			latest = b.UpdatedAt
+		}
+		if b.CoverUpdatedAt != nil && b.CoverUpdatedAt.After(latest) {
+			latest = *b.CoverUpdatedAt
+		}
+	}
+	return latest
+}
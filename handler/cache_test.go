@@ -0,0 +1,93 @@
+package handler_test
+
+import (
+	"net/http"
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetBooks_Caching(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	books := mocks.NewMockBookRepo(ctrl)
+	updated := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	deleted := updated.Add(time.Hour)
+	list := []model.Book{
+		{ID: 1, Title: "The Great Gatsby", UpdatedAt: updated.Add(-time.Hour)},
+		{ID: 2, Title: "Tender Is the Night", UpdatedAt: updated, AvailableCopies: 1},
+	}
+	checkedOut := []model.Book{list[0], list[1]}
+	checkedOut[1].AvailableCopies = 0
+	gomock.InOrder(
+		books.EXPECT().GetBooks(gomock.Any()).Return(list, nil).Times(3),
+		books.EXPECT().GetBooks(gomock.Any()).Return(list, nil),
+		books.EXPECT().GetBooks(gomock.Any()).Return(checkedOut, nil),
+	)
+	gomock.InOrder(
+		books.EXPECT().BooksModifiedAt().Return(updated, nil).Times(3),
+		// a book of the tenant was deleted since
+		books.EXPECT().BooksModifiedAt().Return(deleted, nil).Times(2),
+	)
+	router := api(t, ctrl, mockRepo{MockBookRepo: books})
+
+	rec := get(router, "/books", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, cacheControl, rec.Header().Get("Cache-Control"))
+	assert.Equal(t, updated.Format(http.TimeFormat), rec.Header().Get("Last-Modified"))
+	assert.Equal(t, "Authorization, X-Tenant-ID", rec.Header().Get("Vary"))
+	assert.Equal(t, "books-1", rec.Header().Get("Surrogate-Key"))
+	etag := rec.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	rec = get(router, "/books", http.Header{"If-None-Match": {etag}})
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.Equal(t, etag, rec.Header().Get("ETag"))
+
+	rec = get(router, "/books", http.Header{"If-Modified-Since": {updated.Format(http.TimeFormat)}})
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	// the delete dates the list though none of its books changed
+	rec = get(router, "/books", http.Header{"If-Modified-Since": {updated.Format(http.TimeFormat)}})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, deleted.Format(http.TimeFormat), rec.Header().Get("Last-Modified"))
+
+	rec = get(router, "/books", http.Header{"If-None-Match": {etag}})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+}
+
+func Test_GetBookById_Caching(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	books := mocks.NewMockBookRepo(ctrl)
+	series := mocks.NewMockSeriesRepo(ctrl)
+	relations := mocks.NewMockRelationRepo(ctrl)
+	holds := mocks.NewMockHoldRepo(ctrl)
+
+	updated := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	cover := updated.Add(time.Hour)
+	// 7 was merged into 8
+	books.EXPECT().GetBookById(int64(7)).Return(model.Book{ID: 8, Title: "Dune", UpdatedAt: updated, CoverUpdatedAt: &cover}, nil).Times(2)
+	series.EXPECT().GetBookSeries(int64(8)).Return(nil, nil).Times(2)
+	relations.EXPECT().GetBookRelations(int64(8)).Return(nil, nil).Times(2)
+	holds.EXPECT().GetHoldPosition(int64(7), int64(3)).Return(int64(2), nil)
+	router := api(t, ctrl, mockRepo{MockBookRepo: books, MockSeriesRepo: series, MockRelationRepo: relations, MockHoldRepo: holds})
+
+	rec := get(router, "/books/7", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, cacheControl, rec.Header().Get("Cache-Control"))
+	assert.Equal(t, cover.Format(http.TimeFormat), rec.Header().Get("Last-Modified"))
+	assert.Equal(t, "Accept-Language, Authorization, X-Tenant-ID", rec.Header().Get("Vary"))
+	assert.Equal(t, "book-8 book-7", rec.Header().Get("Surrogate-Key"))
+
+	// the hold position is the member's own, it is not for shared caches
+	rec = get(router, "/books/7?member_id=3", http.Header{"If-Modified-Since": {cover.Format(http.TimeFormat)}})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "private, no-store", rec.Header().Get("Cache-Control"))
+	assert.Empty(t, rec.Header().Get("ETag"))
+}
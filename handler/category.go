@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"errors"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"ninth-learn/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateCategory godoc
+// @Summary		 Creates a new Category
+// @Description  Create a new category, optionally below a parent category
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Param 		 category_request body model.CategoryRequest true "Category request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /categories [post]
+func (h HttpServer) CreateCategory(c *gin.Context) {
+	in := model.Category{}
+
+	err := c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).CreateCategory(in)
+	if err != nil {
+		if err.Error() == helper.ErrNotFound {
+			helper.NotFound(c, "Parent category not found")
+			return
+		}
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetCategoryById godoc
+// @Summary      Show a category
+// @Description  get detail category by id
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Category ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /categories/{id} [get]
+func (h HttpServer) GetCategoryById(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid category ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetCategoryById(id)
+	if err != nil {
+		if err.Error() == helper.ErrNotFound {
+			helper.NotFound(c, err.Error())
+			return
+		}
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetCategories godoc
+// @Summary      Show all category
+// @Description  get all category, top level categories first
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /categories [get]
+func (h HttpServer) GetCategories(c *gin.Context) {
+	// call service
+	res, err := h.tenant(c).GetCategories()
+	if err != nil {
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// UpdateCategory godoc
+// @Summary      Update category
+// @Description  Update category by id, moving it below itself is rejected
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Category ID"
+// @Param 		 category_request body model.CategoryRequest true "Category request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Router       /categories/{id} [put]
+func (h HttpServer) UpdateCategory(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid category ID")
+		return
+	}
+
+	in := model.Category{}
+	err = c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+	in.ID = id
+	// call service
+	res, err := h.tenant(c).UpdateCategory(in)
+	if err != nil {
+		if errors.Is(err, service.ErrCategoryCycle) {
+			helper.BadRequest(c, err.Error())
+			return
+		}
+		helper.NotFound(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// DeleteCategory godoc
+// @Summary      Delete category
+// @Description  Delete category by id, categories with subcategories are kept
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Category ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Router       /categories/{id} [delete]
+func (h HttpServer) DeleteCategory(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid category ID")
+		return
+	}
+
+	// call service
+	err = h.tenant(c).DeleteCategory(id)
+	if err != nil {
+		if errors.Is(err, service.ErrCategoryHasChildren) {
+			helper.BadRequest(c, err.Error())
+			return
+		}
+		helper.NotFound(c, err.Error())
+		return
+	}
+
+	helper.OkWithMessage(c, "Category deleted successfully")
+}
@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"errors"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"ninth-learn/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateCopy godoc
+// @Summary		 Adds a copy of a Book
+// @Description  Register a physical copy of a book
+// @Tags         copies
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Book ID"
+// @Param 		 copy_request body model.CopyRequest true "Copy request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/copies [post]
+func (h HttpServer) CreateCopy(c *gin.Context) {
+	bookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return
+	}
+
+	in := model.Copy{}
+	err = c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+	in.BookID = bookID
+
+	// call service
+	res, err := h.tenant(c).CreateCopy(in)
+	if err != nil {
+		if err.Error() == helper.ErrNotFound {
+			helper.NotFound(c, err.Error())
+			return
+		}
+		if err.Error() == helper.ErrDuplicatedKey {
+			helper.Conflict(c, "A copy with this barcode already exists")
+			return
+		}
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetCopies godoc
+// @Summary      Show all copies of a book
+// @Description  get the physical copies of a book
+// @Tags         copies
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Book ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/copies [get]
+func (h HttpServer) GetCopies(c *gin.Context) {
+	bookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetCopies(bookID)
+	if err != nil {
+		if err.Error() == helper.ErrNotFound {
+			helper.NotFound(c, err.Error())
+			return
+		}
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetCopyById godoc
+// @Summary      Show a copy
+// @Description  get detail copy by id
+// @Tags         copies
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true  "Book ID"
+// @Param        copy_id  path      int  true  "Copy ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/copies/{copy_id} [get]
+func (h HttpServer) GetCopyById(c *gin.Context) {
+	bookID, id, ok := copyParams(c)
+	if !ok {
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetCopyById(bookID, id)
+	if err != nil {
+		if err.Error() == helper.ErrNotFound {
+			helper.NotFound(c, err.Error())
+			return
+		}
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetCopyByBarcode godoc
+// @Summary      Show a copy by barcode
+// @Description  get the copy with the given barcode along with its book
+// @Tags         copies
+// @Accept       json
+// @Produce      json
+// @Param        barcode   path      string  true  "Barcode"
+// @Success      200  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /copies/barcode/{barcode} [get]
+func (h HttpServer) GetCopyByBarcode(c *gin.Context) {
+	// call service
+	res, err := h.tenant(c).GetCopyByBarcode(c.Param("barcode"))
+	if err != nil {
+		if err.Error() == helper.ErrNotFound {
+			helper.NotFound(c, err.Error())
+			return
+		}
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// UpdateCopy godoc
+// @Summary      Update copy
+// @Description  Update copy by id
+// @Tags         copies
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true  "Book ID"
+// @Param        copy_id  path      int  true  "Copy ID"
+// @Param 		 copy_request body model.CopyRequest true "Copy request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Router       /books/{id}/copies/{copy_id} [put]
+func (h HttpServer) UpdateCopy(c *gin.Context) {
+	bookID, id, ok := copyParams(c)
+	if !ok {
+		return
+	}
+
+	in := model.Copy{}
+	err := c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+	in.ID = id
+	in.BookID = bookID
+
+	// call service
+	res, err := h.tenant(c).UpdateCopy(in)
+	if err != nil {
+		if err.Error() == helper.ErrDuplicatedKey {
+			helper.Conflict(c, "A copy with this barcode already exists")
+			return
+		}
+		helper.NotFound(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// DeleteCopy godoc
+// @Summary      Delete copy
+// @Description  Delete copy by id, copies on loan, set aside for a hold or with fined loans on record cannot be deleted
+// @Tags         copies
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true  "Book ID"
+// @Param        copy_id  path      int  true  "Copy ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Router       /books/{id}/copies/{copy_id} [delete]
+func (h HttpServer) DeleteCopy(c *gin.Context) {
+	bookID, id, ok := copyParams(c)
+	if !ok {
+		return
+	}
+
+	// call service
+	err := h.tenant(c).DeleteCopy(bookID, id)
+	if err != nil {
+		if errors.Is(err, service.ErrCopyOnLoan) || errors.Is(err, service.ErrCopyOnHold) || errors.Is(err, model.ErrLoansFined) {
+			helper.Conflict(c, err.Error())
+			return
+		}
+		helper.NotFound(c, err.Error())
+		return
+	}
+
+	helper.OkWithMessage(c, "Copy deleted successfully")
+}
+
+// copyParams reads the book and copy ids of /books/:id/copies/:copy_id
+func copyParams(c *gin.Context) (bookID, id int64, ok bool) {
+	bookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return 0, 0, false
+	}
+
+	id, err = strconv.ParseInt(c.Param("copy_id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid copy ID")
+		return 0, 0, false
+	}
+
+	return bookID, id, true
+}
@@ -0,0 +1,79 @@
+package handler_test
+
+import (
+	"net/http"
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateCopy_Normalized(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       map[string]interface{}
+		wantStatus int
+		want       model.Copy
+	}{
+		{
+			name:       "codes in any case and padding",
+			body:       map[string]interface{}{"barcode": " LIB-1 ", "condition": "Good", "status": " IN_REPAIR"},
+			wantStatus: http.StatusOK,
+			want:       model.Copy{Barcode: "LIB-1", Condition: model.CopyConditionGood, Status: model.CopyStatusInRepair},
+		},
+		{
+			name:       "defaults",
+			body:       map[string]interface{}{"barcode": "LIB-1"},
+			wantStatus: http.StatusOK,
+			want:       model.Copy{Barcode: "LIB-1", Condition: model.CopyConditionGood, Status: model.CopyStatusAvailable},
+		},
+		{
+			name:       "blank barcode",
+			body:       map[string]interface{}{"barcode": "   "},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown condition",
+			body:       map[string]interface{}{"barcode": "LIB-1", "condition": "Mint"},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			books, copies := mocks.NewMockBookRepo(ctrl), mocks.NewMockCopyRepo(ctrl)
+			if tt.wantStatus == http.StatusOK {
+				books.EXPECT().GetBookById(int64(5)).Return(model.Book{ID: 5}, nil)
+				copies.EXPECT().CreateCopy(gomock.Any()).DoAndReturn(func(in model.Copy) (model.Copy, error) {
+					assert.Equal(t, tt.want.Barcode, in.Barcode)
+					assert.Equal(t, tt.want.Condition, in.Condition)
+					assert.Equal(t, tt.want.Status, in.Status)
+					return in, nil
+				})
+			}
+
+			router := api(t, ctrl, mockRepo{MockBookRepo: books, MockCopyRepo: copies})
+			assertStatus(t, tt.wantStatus, serve(router, http.MethodPost, "/books/5/copies", tt.body, nil))
+		})
+	}
+}
+
+func Test_UpdateCopy_Normalized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	copies := mocks.NewMockCopyRepo(ctrl)
+	copies.EXPECT().UpdateCopy(gomock.Any()).DoAndReturn(func(in model.Copy) (model.Copy, error) {
+		assert.Equal(t, model.CopyConditionPoor, in.Condition)
+		assert.Equal(t, model.CopyStatusWithdrawn, in.Status)
+		return in, nil
+	})
+
+	router := api(t, ctrl, mockRepo{MockCopyRepo: copies})
+	rec := serve(router, http.MethodPut, "/books/5/copies/2", map[string]interface{}{
+		"barcode":   "LIB-1",
+		"condition": "POOR",
+		"status":    "Withdrawn",
+	}, nil)
+	assertStatus(t, http.StatusOK, rec)
+}
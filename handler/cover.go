@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"ninth-learn/helper"
+	"ninth-learn/middleware"
+	"ninth-learn/model"
+	"ninth-learn/service"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultCoverMaxBytes = 5 << 20
+
+// coverMaxBytes is the largest accepted upload, COVER_MAX_BYTES overrides it
+func coverMaxBytes() int64 {
+	if n, err := strconv.ParseInt(os.Getenv("COVER_MAX_BYTES"), 10, 64); err == nil && n > 0 {
+		return n
+	}
+	return defaultCoverMaxBytes
+}
+
+// UploadBookCover godoc
+// @Summary      Upload book cover
+// @Description  Upload a JPEG, PNG or WebP cover, thumbnails are generated from it
+// @Tags         books
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id     path      int   true  "Book ID"
+// @Param        cover  formData  file  true  "Cover image"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      413  {object}  helper.Response
+// @Failure      415  {object}  helper.Response
+// @Router       /books/{id}/cover [put]
+func (h HttpServer) UploadBookCover(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return
+	}
+
+	// leave room for the multipart envelope around the file
+	limit := coverMaxBytes()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit+64<<10)
+
+	file, err := c.FormFile("cover")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			helper.PayloadTooLarge(c, fmt.Sprintf("Cover must not exceed %d bytes", limit))
+			return
+		}
+		helper.BadRequest(c, "Missing cover file")
+		return
+	}
+	if file.Size > limit {
+		helper.PayloadTooLarge(c, fmt.Sprintf("Cover must not exceed %d bytes", limit))
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, limit))
+	if err != nil {
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).UploadBookCover(id, data)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnsupportedCover):
+			helper.UnsupportedMediaType(c, err.Error())
+		case errors.Is(err, service.ErrCoverDimensions):
+			helper.BadRequest(c, err.Error())
+		case err.Error() == helper.ErrNotFound:
+			helper.NotFound(c, err.Error())
+		default:
+			helper.InternalServerError(c, err.Error())
+		}
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetBookCover godoc
+// @Summary      Show book cover
+// @Description  get the cover image of a book, either the original or a thumbnail
+// @Tags         books
+// @Produce      image/jpeg,image/png,image/webp
+// @Param        id    path   int     true   "Book ID"
+// @Param        size  query  string  false  "Rendition"  Enums(original, small, medium, large)  default(original)
+// @Success      200
+// @Success      304
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Router       /books/{id}/cover [get]
+func (h HttpServer) GetBookCover(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetBookCover(id, c.DefaultQuery("size", model.CoverSizeOriginal))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCoverSize):
+			helper.BadRequest(c, err.Error())
+		case err.Error() == helper.ErrNotFound:
+			helper.NotFound(c, "Book has no cover")
+		default:
+			helper.InternalServerError(c, err.Error())
+		}
+		return
+	}
+
+	// the same URL serves every tenant, so shared caches must key on it
+	etag := `"` + res.ETag + `"`
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.Header("Vary", "Authorization, "+middleware.TenantHeader)
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", res.UpdatedAt.UTC().Format(http.TimeFormat))
+
+	if notModified(c, etag, res.UpdatedAt) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, res.ContentType, res.Data)
+}
+
+// notModified evaluates the conditional request headers, If-None-Match wins
+// over If-Modified-Since
+func notModified(c *gin.Context, etag string, modified time.Time) bool {
+	if match := c.GetHeader("If-None-Match"); match != "" {
+		return match == etag || match == "*"
+	}
+	if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil && !modified.IsZero() {
+		return !modified.Truncate(time.Second).After(since)
+	}
+	return false
+}
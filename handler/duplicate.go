@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"errors"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetDuplicateBooks godoc
+// @Summary      Show likely duplicate books
+// @Description  get clusters of books that likely describe the same work, by ISBN or by similar title and author, most certain first
+// @Tags         books
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/duplicates [get]
+func (h HttpServer) GetDuplicateBooks(c *gin.Context) {
+	// call service
+	res, err := h.tenant(c).GetDuplicateBooks()
+	if err != nil {
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// MergeBooks godoc
+// @Summary		 Merges two Books
+// @Description  Merge the source book into the target, moving its copies, loans, holds, reviews, list entries and links. the source ID keeps resolving to the target.
+// @Tags         books
+// @Accept       json
+// @Produce      json
+// @Param 		 merge_request body model.MergeRequest true "Merge request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/merge [post]
+func (h HttpServer) MergeBooks(c *gin.Context) {
+	in := model.MergeRequest{}
+	err := c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).MergeBooks(in.SourceID, in.TargetID)
+	if err != nil {
+		if errors.Is(err, model.ErrMergeSameBook) {
+			helper.BadRequest(c, err.Error())
+			return
+		}
+		if err.Error() == helper.ErrNotFound {
+			helper.NotFound(c, err.Error())
+			return
+		}
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
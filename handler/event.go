@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"ninth-learn/model"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// eventHeartbeat keeps idle streams from being cut by proxies
+	eventHeartbeat = 15 * time.Second
+	// eventRetry is how long browsers wait before reconnecting
+	eventRetry = 3 * time.Second
+)
+
+// StreamBookEvents godoc
+// @Summary      Stream book changes
+// @Description  Server-Sent Events of books created, updated and deleted, named after the change with the event as data. send the id of the last event received in Last-Event-ID, or last_event_id where headers cannot be set, to pick up where the stream left off. a reset event means the changes since can no longer be replayed and the books should be reloaded. deletes pass the author and tag filters. a comment is sent every 15 seconds to keep the connection open.
+// @Tags         books
+// @Produce      text/event-stream
+// @Param        author         query     string  false  "Comma separated author names"
+// @Param        tag            query     string  false  "Comma separated tag names"
+// @Param        Last-Event-ID  header    string  false  "Resume after this event"
+// @Param        last_event_id  query     string  false  "Resume after this event"
+// @Success      200  {object}  model.BookEvent
+// @Router       /books/events [get]
+func (h HttpServer) StreamBookEvents(c *gin.Context) {
+	filter := model.BookEventFilter{
+		Authors: commaList(c.Query("author")),
+		Tags:    commaList(c.Query("tag")),
+	}
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	// call service
+	missed, events, stop, complete := h.tenant(c).ResumeBooks(lastEventID)
+	defer stop()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	w := c.Writer
+	fmt.Fprintf(w, "retry: %d\n\n", eventRetry.Milliseconds())
+	if !complete {
+		// the empty id clears the one the browser would resume from
+		fmt.Fprint(w, "id\nevent: reset\ndata: {}\n\n")
+	}
+	for _, e := range missed {
+		if filter.Matches(e) {
+			writeBookEvent(w, e)
+		}
+	}
+	w.Flush()
+
+	heartbeat := time.NewTicker(eventHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		case e, ok := <-events:
+			if !ok {
+				// the stream fell behind, the client reconnects and resumes
+				return
+			}
+			if !filter.Matches(e) {
+				continue
+			}
+			writeBookEvent(w, e)
+		}
+		w.Flush()
+	}
+}
+
+func writeBookEvent(w io.Writer, e model.BookEvent) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+}
+
+// commaList splits a comma separated query value, dropping blanks
+func commaList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
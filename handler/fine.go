@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMemberFines godoc
+// @Summary      Show fines of a member
+// @Description  get the accrued fines, payments, waivers and outstanding balance of a member, amounts in cents
+// @Tags         fines
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Member ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /members/{id}/fines [get]
+func (h HttpServer) GetMemberFines(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid member ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetMemberFines(id)
+	if err != nil {
+		loanError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// PayFine godoc
+// @Summary		 Records a fine payment
+// @Description  Record a payment towards the outstanding fines of a member
+// @Tags         fines
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Member ID"
+// @Param 		 fine_request body model.FineTransactionRequest true "Fine transaction request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /members/{id}/fines/payments [post]
+func (h HttpServer) PayFine(c *gin.Context) {
+	h.settleFine(c, h.tenant(c).PayFine)
+}
+
+// WaiveFine godoc
+// @Summary		 Records a fine waiver
+// @Description  Write off part of the outstanding fines of a member
+// @Tags         fines
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Member ID"
+// @Param 		 fine_request body model.FineTransactionRequest true "Fine transaction request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /members/{id}/fines/waivers [post]
+func (h HttpServer) WaiveFine(c *gin.Context) {
+	h.settleFine(c, h.tenant(c).WaiveFine)
+}
+
+// settleFine reads a payment or waiver and records it with the given service call
+func (h HttpServer) settleFine(c *gin.Context, record func(int64, model.FineTransaction) (model.FineTransaction, error)) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid member ID")
+		return
+	}
+
+	in := model.FineTransaction{}
+	err = c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := record(id, in)
+	if err != nil {
+		loanError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
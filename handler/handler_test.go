@@ -0,0 +1,94 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"ninth-learn/repository"
+	"ninth-learn/repository/mocks"
+	"ninth-learn/route"
+	"ninth-learn/service"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const cacheControl = "public, max-age=60"
+
+type mockRepo struct {
+	*mocks.MockBookRepo
+	*mocks.MockTenantRepo
+	*mocks.MockAuthorRepo
+	*mocks.MockCategoryRepo
+	*mocks.MockTagRepo
+	*mocks.MockCoverRepo
+	*mocks.MockCopyRepo
+	*mocks.MockMemberRepo
+	*mocks.MockLoanRepo
+	*mocks.MockHoldRepo
+	*mocks.MockFineRepo
+	*mocks.MockReviewRepo
+	*mocks.MockReadingListRepo
+	*mocks.MockDuplicateRepo
+	*mocks.MockTranslationRepo
+	*mocks.MockSeriesRepo
+	*mocks.MockRelationRepo
+	*mocks.MockWebhookRepo
+	*mocks.MockOutboxRepo
+}
+
+func (m mockRepo) WithTenant(tenantID int64) repository.RepoInterface {
+	return m
+}
+
+// api runs the real router over the repo, tenants resolve to themselves
+func api(t *testing.T, ctrl *gomock.Controller, repo mockRepo) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	if repo.MockTenantRepo == nil {
+		repo.MockTenantRepo = mocks.NewMockTenantRepo(ctrl)
+	}
+	repo.MockTenantRepo.EXPECT().GetTenantBySlug(gomock.Any()).DoAndReturn(func(slug string) (model.Tenant, error) {
+		if slug == "gone" {
+			return model.Tenant{}, errors.New(helper.ErrNotFound)
+		}
+		return model.Tenant{ID: 1, Slug: slug, Status: model.TenantStatusActive}, nil
+	}).AnyTimes()
+
+	router := gin.New()
+	route.RegisterApi(router, service.NewService(repo, model.LoanPolicy{}), cacheControl)
+	return router
+}
+
+func serve(router http.Handler, method, path string, body interface{}, header http.Header) *httptest.ResponseRecorder {
+	var req *http.Request
+	if body != nil {
+		data, _ := json.Marshal(body)
+		req = httptest.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req = httptest.NewRequest(method, path, nil)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func get(router http.Handler, path string, header http.Header) *httptest.ResponseRecorder {
+	return serve(router, http.MethodGet, path, nil, header)
+}
+
+func assertStatus(t *testing.T, want int, rec *httptest.ResponseRecorder) {
+	t.Helper()
+	assert.Equal(t, want, rec.Code, rec.Body.String())
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PlaceHold godoc
+// @Summary		 Place a hold on a Book
+// @Description  Queue a member for a book whose copies are all on loan
+// @Tags         holds
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Book ID"
+// @Param 		 hold_request body model.HoldRequest true "Hold request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/holds [post]
+func (h HttpServer) PlaceHold(c *gin.Context) {
+	bookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return
+	}
+
+	in := model.HoldRequest{}
+	err = c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).PlaceHold(bookID, in)
+	if err != nil {
+		loanError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetBookHolds godoc
+// @Summary      Show the hold queue of a book
+// @Description  get the holds of a book in the order they are served, waiting holds carry their queue position
+// @Tags         holds
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int   true   "Book ID"
+// @Param        active  query     bool  false  "Only waiting and ready holds"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/holds [get]
+func (h HttpServer) GetBookHolds(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return
+	}
+
+	active, err := activeOnly(c)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetBookHolds(id, active)
+	if err != nil {
+		loanError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetMemberHolds godoc
+// @Summary      Show holds of a member
+// @Description  get the holds of a member
+// @Tags         members
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int   true   "Member ID"
+// @Param        active  query     bool  false  "Only waiting and ready holds"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /members/{id}/holds [get]
+func (h HttpServer) GetMemberHolds(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid member ID")
+		return
+	}
+
+	active, err := activeOnly(c)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetMemberHolds(id, active)
+	if err != nil {
+		loanError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetHoldById godoc
+// @Summary      Show a hold
+// @Description  get detail hold by id
+// @Tags         holds
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Hold ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /holds/{id} [get]
+func (h HttpServer) GetHoldById(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid hold ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetHoldById(id)
+	if err != nil {
+		loanError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// CancelHold godoc
+// @Summary      Cancel a hold
+// @Description  Withdraw a hold, a copy set aside for it passes to the next member in the queue
+// @Tags         holds
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Hold ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /holds/{id}/cancel [put]
+func (h HttpServer) CancelHold(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid hold ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).CancelHold(id)
+	if err != nil {
+		loanError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
@@ -1,11 +1,23 @@
 package handler
 
-import "ninth-learn/service"
+import (
+	"ninth-learn/middleware"
+	"ninth-learn/service"
+
+	"github.com/gin-gonic/gin"
+)
 
 type HttpServer struct {
 	app service.ServiceInterface
+	// cacheControl is sent with the book reads shared caches may keep
+	cacheControl string
+}
+
+func NewHttpServer(app service.ServiceInterface, cacheControl string) HttpServer {
+	return HttpServer{app: app, cacheControl: cacheControl}
 }
 
-func NewHttpServer(app service.ServiceInterface) HttpServer {
-	return HttpServer{app: app}
+// tenant returns the service scoped to the tenant resolved for the request
+func (h HttpServer) tenant(c *gin.Context) service.ServiceInterface {
+	return h.app.WithTenant(middleware.TenantID(c))
 }
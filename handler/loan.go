@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"errors"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"ninth-learn/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// lendingErrors are the lending and hold rules a request can break,
+// answered with 409
+var lendingErrors = []error{
+	model.ErrCopyUnavailable,
+	model.ErrNoCopyAvailable,
+	model.ErrLoanLimitReached,
+	model.ErrMemberInactive,
+	model.ErrLoanReturned,
+	model.ErrRenewalLimitReached,
+	model.ErrHoldExists,
+	model.ErrHoldNotActive,
+	model.ErrBookHasNoCopies,
+	model.ErrCopyAvailableToLoan,
+	model.ErrAlreadyBorrowed,
+	model.ErrFinesOutstanding,
+	model.ErrFineExceedsBalance,
+	service.ErrFineLoanMismatch,
+}
+
+// CheckoutBook godoc
+// @Summary		 Checkout a Book
+// @Description  Lend a copy of a book to a member, any available copy when copy_id is left out
+// @Tags         loans
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Book ID"
+// @Param 		 checkout_request body model.CheckoutRequest true "Checkout request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/checkout [post]
+func (h HttpServer) CheckoutBook(c *gin.Context) {
+	bookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return
+	}
+
+	in := model.CheckoutRequest{}
+	err = c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).CheckoutBook(bookID, in)
+	if err != nil {
+		loanError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetBookLoans godoc
+// @Summary      Show loans of a book
+// @Description  get the loans of every copy of a book, most recent first
+// @Tags         loans
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int   true   "Book ID"
+// @Param        active  query     bool  false  "Only loans not yet returned"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/loans [get]
+func (h HttpServer) GetBookLoans(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return
+	}
+
+	active, err := activeOnly(c)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetBookLoans(id, active)
+	if err != nil {
+		loanError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetLoanById godoc
+// @Summary      Show a loan
+// @Description  get detail loan by id
+// @Tags         loans
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Loan ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /loans/{id} [get]
+func (h HttpServer) GetLoanById(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid loan ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetLoanById(id)
+	if err != nil {
+		loanError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetOverdueLoans godoc
+// @Summary      Show overdue loans
+// @Description  get the loans not yet returned that are past their due date, most recent first
+// @Tags         loans
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /loans/overdue [get]
+func (h HttpServer) GetOverdueLoans(c *gin.Context) {
+	// call service
+	res, err := h.tenant(c).GetOverdueLoans()
+	if err != nil {
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// ReturnLoan godoc
+// @Summary      Return a loan
+// @Description  Close the loan and put the copy back on the shelf
+// @Tags         loans
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Loan ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /loans/{id}/return [put]
+func (h HttpServer) ReturnLoan(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid loan ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).ReturnLoan(id)
+	if err != nil {
+		loanError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// RenewLoan godoc
+// @Summary      Renew a loan
+// @Description  Restart the loan period from today, up to the renewal limit
+// @Tags         loans
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Loan ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /loans/{id}/renew [put]
+func (h HttpServer) RenewLoan(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid loan ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).RenewLoan(id)
+	if err != nil {
+		loanError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// loanError answers broken lending rules with 409 and unknown ids with 404
+func loanError(c *gin.Context, err error) {
+	for _, target := range lendingErrors {
+		if errors.Is(err, target) {
+			helper.Conflict(c, err.Error())
+			return
+		}
+	}
+	if err.Error() == helper.ErrNotFound {
+		helper.NotFound(c, err.Error())
+		return
+	}
+	helper.InternalServerError(c, err.Error())
+}
+
+// activeOnly reads the optional active query flag
+func activeOnly(c *gin.Context) (bool, error) {
+	v := c.Query("active")
+	if v == "" {
+		return false, nil
+	}
+	active, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, errors.New("Invalid active flag")
+	}
+	return active, nil
+}
@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"errors"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"ninth-learn/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateMember godoc
+// @Summary		 Creates a new Member
+// @Description  Register a new library member
+// @Tags         members
+// @Accept       json
+// @Produce      json
+// @Param 		 member_request body model.MemberRequest true "Member request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /members [post]
+func (h HttpServer) CreateMember(c *gin.Context) {
+	in := model.Member{}
+
+	err := c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).CreateMember(in)
+	if err != nil {
+		if err.Error() == helper.ErrDuplicatedKey {
+			helper.Conflict(c, "A member with this email already exists")
+			return
+		}
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetMembers godoc
+// @Summary      Show all member
+// @Description  get all member
+// @Tags         members
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /members [get]
+func (h HttpServer) GetMembers(c *gin.Context) {
+	// call service
+	res, err := h.tenant(c).GetMembers()
+	if err != nil {
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetMemberById godoc
+// @Summary      Show a member
+// @Description  get detail member by id
+// @Tags         members
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Member ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /members/{id} [get]
+func (h HttpServer) GetMemberById(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid member ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetMemberById(id)
+	if err != nil {
+		if err.Error() == helper.ErrNotFound {
+			helper.NotFound(c, err.Error())
+			return
+		}
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// UpdateMember godoc
+// @Summary      Update member
+// @Description  Update member by id
+// @Tags         members
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Member ID"
+// @Param 		 member_request body model.MemberRequest true "Member request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Router       /members/{id} [put]
+func (h HttpServer) UpdateMember(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid member ID")
+		return
+	}
+
+	in := model.Member{}
+	err = c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+	in.ID = id
+	// call service
+	res, err := h.tenant(c).UpdateMember(in)
+	if err != nil {
+		if err.Error() == helper.ErrDuplicatedKey {
+			helper.Conflict(c, "A member with this email already exists")
+			return
+		}
+		helper.NotFound(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// DeleteMember godoc
+// @Summary      Delete member
+// @Description  Delete member by id, members with books on loan or on hold, or owing fines, cannot be deleted
+// @Tags         members
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Member ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Router       /members/{id} [delete]
+func (h HttpServer) DeleteMember(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid member ID")
+		return
+	}
+
+	// call service
+	err = h.tenant(c).DeleteMember(id)
+	if err != nil {
+		if errors.Is(err, service.ErrMemberHasLoans) || errors.Is(err, service.ErrMemberHasHolds) ||
+			errors.Is(err, model.ErrLoansActive) || errors.Is(err, model.ErrFinesOwed) {
+			helper.Conflict(c, err.Error())
+			return
+		}
+		helper.NotFound(c, err.Error())
+		return
+	}
+
+	helper.OkWithMessage(c, "Member deleted successfully")
+}
+
+// GetMemberLoans godoc
+// @Summary      Show loans of a member
+// @Description  get the loans of a member, most recent first
+// @Tags         members
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int   true   "Member ID"
+// @Param        active  query     bool  false  "Only loans not yet returned"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /members/{id}/loans [get]
+func (h HttpServer) GetMemberLoans(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid member ID")
+		return
+	}
+
+	active, err := activeOnly(c)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetMemberLoans(id, active)
+	if err != nil {
+		if err.Error() == helper.ErrNotFound {
+			helper.NotFound(c, err.Error())
+			return
+		}
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
@@ -0,0 +1,389 @@
+package handler
+
+import (
+	"errors"
+	"ninth-learn/helper"
+	"ninth-learn/middleware"
+	"ninth-learn/model"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMyLists godoc
+// @Summary      Show my reading lists
+// @Description  get the reading lists of the authenticated reader, the built-in want to read and currently reading lists are created on first use
+// @Tags         reading lists
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /me/lists [get]
+func (h HttpServer) GetMyLists(c *gin.Context) {
+	principal, _ := middleware.Principal(c)
+
+	// call service
+	res, err := h.tenant(c).GetMyLists(principal)
+	if err != nil {
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetMyList godoc
+// @Summary      Show a reading list
+// @Description  get one of my reading lists with its books in list order
+// @Tags         reading lists
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "List ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /me/lists/{id} [get]
+func (h HttpServer) GetMyList(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid list ID")
+		return
+	}
+
+	// call service
+	principal, _ := middleware.Principal(c)
+	res, err := h.tenant(c).GetMyList(principal, id)
+	if err != nil {
+		listError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// CreateList godoc
+// @Summary		 Creates a reading list
+// @Description  Create a custom shelf, private unless visibility is public
+// @Tags         reading lists
+// @Accept       json
+// @Produce      json
+// @Param 		 list_request body model.ReadingListRequest true "Reading list request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /me/lists [post]
+func (h HttpServer) CreateList(c *gin.Context) {
+	in := model.ReadingList{}
+	err := c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	principal, _ := middleware.Principal(c)
+	res, err := h.tenant(c).CreateList(principal, in)
+	if err != nil {
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// UpdateList godoc
+// @Summary      Update reading list
+// @Description  Rename one of my reading lists or change its visibility
+// @Tags         reading lists
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "List ID"
+// @Param 		 list_request body model.ReadingListRequest true "Reading list request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /me/lists/{id} [put]
+func (h HttpServer) UpdateList(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid list ID")
+		return
+	}
+
+	in := model.ReadingList{}
+	err = c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+	in.ID = id
+
+	// call service
+	principal, _ := middleware.Principal(c)
+	res, err := h.tenant(c).UpdateList(principal, in)
+	if err != nil {
+		listError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// ShareList godoc
+// @Summary      Renew share link
+// @Description  Issue a new share token for one of my reading lists, links shared before stop working
+// @Tags         reading lists
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "List ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /me/lists/{id}/share [put]
+func (h HttpServer) ShareList(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid list ID")
+		return
+	}
+
+	// call service
+	principal, _ := middleware.Principal(c)
+	res, err := h.tenant(c).ShareList(principal, id)
+	if err != nil {
+		listError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// DeleteList godoc
+// @Summary      Delete reading list
+// @Description  Delete one of my custom reading lists, built-in lists stay
+// @Tags         reading lists
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "List ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /me/lists/{id} [delete]
+func (h HttpServer) DeleteList(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid list ID")
+		return
+	}
+
+	// call service
+	principal, _ := middleware.Principal(c)
+	err = h.tenant(c).DeleteList(principal, id)
+	if err != nil {
+		listError(c, err)
+		return
+	}
+
+	helper.OkWithMessage(c, "Reading list deleted successfully")
+}
+
+// AddListBook godoc
+// @Summary		 Adds a book to a reading list
+// @Description  Put a book on one of my reading lists at the given position, at the end when position is left out
+// @Tags         reading lists
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "List ID"
+// @Param 		 entry_request body model.ReadingListEntryRequest true "Reading list entry request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /me/lists/{id}/books [post]
+func (h HttpServer) AddListBook(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid list ID")
+		return
+	}
+
+	in := model.ReadingListEntry{}
+	err = c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+	in.ListID = id
+
+	// call service
+	principal, _ := middleware.Principal(c)
+	res, err := h.tenant(c).AddListBook(principal, in)
+	if err != nil {
+		if err.Error() == helper.ErrDuplicatedKey {
+			helper.Conflict(c, "The book is already on this list")
+			return
+		}
+		listError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// MoveListBook godoc
+// @Summary      Move a book on a reading list
+// @Description  Move a book to another position on one of my reading lists
+// @Tags         reading lists
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true  "List ID"
+// @Param        book_id  path      int  true  "Book ID"
+// @Param 		 move_request body model.ReadingListMoveRequest true "Move request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /me/lists/{id}/books/{book_id} [put]
+func (h HttpServer) MoveListBook(c *gin.Context) {
+	id, bookID, ok := listBookParams(c)
+	if !ok {
+		return
+	}
+
+	in := model.ReadingListMoveRequest{}
+	err := c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	principal, _ := middleware.Principal(c)
+	res, err := h.tenant(c).MoveListBook(principal, id, bookID, in.Position)
+	if err != nil {
+		listError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// RemoveListBook godoc
+// @Summary      Remove a book from a reading list
+// @Description  Take a book off one of my reading lists, the books after it move up
+// @Tags         reading lists
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true  "List ID"
+// @Param        book_id  path      int  true  "Book ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /me/lists/{id}/books/{book_id} [delete]
+func (h HttpServer) RemoveListBook(c *gin.Context) {
+	id, bookID, ok := listBookParams(c)
+	if !ok {
+		return
+	}
+
+	// call service
+	principal, _ := middleware.Principal(c)
+	err := h.tenant(c).RemoveListBook(principal, id, bookID)
+	if err != nil {
+		listError(c, err)
+		return
+	}
+
+	helper.OkWithMessage(c, "Book removed from the list")
+}
+
+// GetSharedList godoc
+// @Summary      Show a shared reading list
+// @Description  get a public reading list through its share link
+// @Tags         reading lists
+// @Accept       json
+// @Produce      json
+// @Param        token   path      string  true  "Share token"
+// @Success      200  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /lists/{token} [get]
+func (h HttpServer) GetSharedList(c *gin.Context) {
+	// call service
+	res, err := h.tenant(c).GetSharedList(c.Param("token"))
+	if err != nil {
+		listError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+func listBookParams(c *gin.Context) (id, bookID int64, ok bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid list ID")
+		return 0, 0, false
+	}
+
+	bookID, err = strconv.ParseInt(c.Param("book_id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return 0, 0, false
+	}
+
+	return id, bookID, true
+}
+
+// listError answers deleting a built-in list with 409 and unknown ids with 404
+func listError(c *gin.Context, err error) {
+	if errors.Is(err, model.ErrBuiltInList) {
+		helper.Conflict(c, err.Error())
+		return
+	}
+	if err.Error() == helper.ErrNotFound {
+		helper.NotFound(c, err.Error())
+		return
+	}
+	helper.InternalServerError(c, err.Error())
+}
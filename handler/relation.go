@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"errors"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetBookRelations godoc
+// @Summary      Show related works of a book
+// @Description  get the works related to a book, relations pointing at the book are named from its side, such as has_sequel
+// @Tags         relations
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Book ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/relations [get]
+func (h HttpServer) GetBookRelations(c *gin.Context) {
+	bookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetBookRelations(bookID)
+	if err != nil {
+		relationError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// CreateBookRelation godoc
+// @Summary		 Relates two books
+// @Description  Record that the book is, for instance, a sequel of the related book. relations that would make a book its own sequel, translation or adaptation are refused
+// @Tags         relations
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Book ID"
+// @Param 		 relation_request body model.BookRelationRequest true "Relation request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/relations [post]
+func (h HttpServer) CreateBookRelation(c *gin.Context) {
+	bookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return
+	}
+
+	in := model.BookRelation{}
+	err = c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+	in.BookID = bookID
+
+	// call service
+	res, err := h.tenant(c).CreateBookRelation(in)
+	if err != nil {
+		if err.Error() == helper.ErrDuplicatedKey {
+			helper.Conflict(c, "The books are already related this way")
+			return
+		}
+		relationError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// DeleteBookRelation godoc
+// @Summary      Delete relation
+// @Description  Delete a relation from or to the book
+// @Tags         relations
+// @Accept       json
+// @Produce      json
+// @Param        id           path      int  true  "Book ID"
+// @Param        relation_id  path      int  true  "Relation ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/relations/{relation_id} [delete]
+func (h HttpServer) DeleteBookRelation(c *gin.Context) {
+	bookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("relation_id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid relation ID")
+		return
+	}
+
+	// call service
+	err = h.tenant(c).DeleteBookRelation(bookID, id)
+	if err != nil {
+		relationError(c, err)
+		return
+	}
+
+	helper.OkWithMessage(c, "Relation deleted successfully")
+}
+
+// relationError answers relations closing a loop with 409 and unknown ids
+// with 404
+func relationError(c *gin.Context, err error) {
+	if errors.Is(err, model.ErrRelationCycle) {
+		helper.Conflict(c, err.Error())
+		return
+	}
+	if err.Error() == helper.ErrNotFound {
+		helper.NotFound(c, err.Error())
+		return
+	}
+	helper.InternalServerError(c, err.Error())
+}
@@ -0,0 +1,267 @@
+package handler
+
+import (
+	"errors"
+	"ninth-learn/helper"
+	"ninth-learn/middleware"
+	"ninth-learn/model"
+	"ninth-learn/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetBookReviews godoc
+// @Summary      Show reviews of a book
+// @Description  get the approved reviews of a book, newest first. admins may list reviews of any status.
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int     true   "Book ID"
+// @Param        status  query     string  false  "Moderation status, admins only"  Enums(approved, rejected, all)
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      403  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/reviews [get]
+func (h HttpServer) GetBookReviews(c *gin.Context) {
+	bookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return
+	}
+
+	status := model.ReviewStatusApproved
+	if v := c.Query("status"); v != "" {
+		principal, _ := middleware.Principal(c)
+		if !principal.IsAdmin() {
+			helper.Forbidden(c, "Only admins may filter by status")
+			return
+		}
+		switch v {
+		case model.ReviewStatusApproved, model.ReviewStatusRejected:
+			status = v
+		case "all":
+			status = ""
+		default:
+			helper.BadRequest(c, "status must be approved, rejected or all")
+			return
+		}
+	}
+
+	// call service
+	res, err := h.tenant(c).GetBookReviews(bookID, status)
+	if err != nil {
+		reviewError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// CreateReview godoc
+// @Summary		 Reviews a Book
+// @Description  Rate and review a book as the authenticated reader, one review per reader and book
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Book ID"
+// @Param 		 review_request body model.ReviewRequest true "Review request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/reviews [post]
+func (h HttpServer) CreateReview(c *gin.Context) {
+	bookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return
+	}
+
+	in := model.Review{}
+	err = c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+	in.BookID = bookID
+
+	// call service
+	principal, _ := middleware.Principal(c)
+	res, err := h.tenant(c).CreateReview(principal, in)
+	if err != nil {
+		if err.Error() == helper.ErrDuplicatedKey {
+			helper.Conflict(c, "You have already reviewed this book")
+			return
+		}
+		reviewError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// UpdateReview godoc
+// @Summary      Update review
+// @Description  Change the rating and text of your own review
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int  true  "Book ID"
+// @Param        review_id  path      int  true  "Review ID"
+// @Param 		 review_request body model.ReviewRequest true "Review request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      403  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/reviews/{review_id} [put]
+func (h HttpServer) UpdateReview(c *gin.Context) {
+	bookID, id, ok := reviewParams(c)
+	if !ok {
+		return
+	}
+
+	in := model.Review{}
+	err := c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+	in.BookID = bookID
+	in.ID = id
+
+	// call service
+	principal, _ := middleware.Principal(c)
+	res, err := h.tenant(c).UpdateReview(principal, in)
+	if err != nil {
+		reviewError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// DeleteReview godoc
+// @Summary      Delete review
+// @Description  Delete your own review, admins may delete any review
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int  true  "Book ID"
+// @Param        review_id  path      int  true  "Review ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      403  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/reviews/{review_id} [delete]
+func (h HttpServer) DeleteReview(c *gin.Context) {
+	bookID, id, ok := reviewParams(c)
+	if !ok {
+		return
+	}
+
+	// call service
+	principal, _ := middleware.Principal(c)
+	err := h.tenant(c).DeleteReview(principal, bookID, id)
+	if err != nil {
+		reviewError(c, err)
+		return
+	}
+
+	helper.OkWithMessage(c, "Review deleted successfully")
+}
+
+// ModerateReview godoc
+// @Summary      Moderate review
+// @Description  Approve or reject a review, only approved reviews count towards the rating of the book
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int  true  "Book ID"
+// @Param        review_id  path      int  true  "Review ID"
+// @Param 		 moderation_request body model.ReviewModerationRequest true "Moderation request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      403  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/reviews/{review_id}/status [put]
+func (h HttpServer) ModerateReview(c *gin.Context) {
+	bookID, id, ok := reviewParams(c)
+	if !ok {
+		return
+	}
+
+	in := model.ReviewModerationRequest{}
+	err := c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).ModerateReview(bookID, id, in.Status)
+	if err != nil {
+		reviewError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+func reviewParams(c *gin.Context) (bookID, id int64, ok bool) {
+	bookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return 0, 0, false
+	}
+
+	id, err = strconv.ParseInt(c.Param("review_id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid review ID")
+		return 0, 0, false
+	}
+
+	return bookID, id, true
+}
+
+// reviewError answers changes to someone else's review with 403 and unknown
+// ids with 404
+func reviewError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrNotReviewAuthor) {
+		helper.Forbidden(c, err.Error())
+		return
+	}
+	if err.Error() == helper.ErrNotFound {
+		helper.NotFound(c, err.Error())
+		return
+	}
+	helper.InternalServerError(c, err.Error())
+}
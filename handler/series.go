@@ -0,0 +1,318 @@
+package handler
+
+import (
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetSeries godoc
+// @Summary      Show all series
+// @Description  get all series by name, each with its books in series order
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /series [get]
+func (h HttpServer) GetSeries(c *gin.Context) {
+	// call service
+	res, err := h.tenant(c).GetSeries()
+	if err != nil {
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetSeriesById godoc
+// @Summary      Show a series
+// @Description  get detail series by id with its books in series order
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Series ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /series/{id} [get]
+func (h HttpServer) GetSeriesById(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid series ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetSeriesById(id)
+	if err != nil {
+		seriesError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// CreateSeries godoc
+// @Summary		 Creates a new Series
+// @Description  Create a new series without books
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Param 		 series_request body model.SeriesRequest true "Series request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /series [post]
+func (h HttpServer) CreateSeries(c *gin.Context) {
+	in := model.Series{}
+
+	err := c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).CreateSeries(in)
+	if err != nil {
+		seriesError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// UpdateSeries godoc
+// @Summary      Update series
+// @Description  Rename a series or change its description
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Series ID"
+// @Param 		 series_request body model.SeriesRequest true "Series request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /series/{id} [put]
+func (h HttpServer) UpdateSeries(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid series ID")
+		return
+	}
+
+	in := model.Series{}
+	err = c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+	in.ID = id
+
+	// call service
+	res, err := h.tenant(c).UpdateSeries(in)
+	if err != nil {
+		seriesError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// DeleteSeries godoc
+// @Summary      Delete series
+// @Description  Delete series by id, its books are kept
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Series ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /series/{id} [delete]
+func (h HttpServer) DeleteSeries(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid series ID")
+		return
+	}
+
+	// call service
+	err = h.tenant(c).DeleteSeries(id)
+	if err != nil {
+		seriesError(c, err)
+		return
+	}
+
+	helper.OkWithMessage(c, "Series deleted successfully")
+}
+
+// AddSeriesBook godoc
+// @Summary		 Adds a book to a series
+// @Description  Put a book in a series at the given position, at the end when position is left out. the books from that position on move down
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Series ID"
+// @Param 		 entry_request body model.SeriesEntryRequest true "Series entry request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      409  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /series/{id}/books [post]
+func (h HttpServer) AddSeriesBook(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid series ID")
+		return
+	}
+
+	in := model.SeriesEntry{}
+	err = c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+	in.SeriesID = id
+
+	// call service
+	res, err := h.tenant(c).AddSeriesBook(in)
+	if err != nil {
+		if err.Error() == helper.ErrDuplicatedKey {
+			helper.Conflict(c, "The book is already in this series")
+			return
+		}
+		seriesError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// MoveSeriesBook godoc
+// @Summary      Move a book within a series
+// @Description  Move a book to another position in its series
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true  "Series ID"
+// @Param        book_id  path      int  true  "Book ID"
+// @Param 		 move_request body model.SeriesMoveRequest true "Move request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /series/{id}/books/{book_id} [put]
+func (h HttpServer) MoveSeriesBook(c *gin.Context) {
+	id, bookID, ok := seriesBookParams(c)
+	if !ok {
+		return
+	}
+
+	in := model.SeriesMoveRequest{}
+	err := c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).MoveSeriesBook(id, bookID, in.Position)
+	if err != nil {
+		seriesError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// RemoveSeriesBook godoc
+// @Summary      Remove a book from a series
+// @Description  Take a book out of a series, the books after it move up
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true  "Series ID"
+// @Param        book_id  path      int  true  "Book ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /series/{id}/books/{book_id} [delete]
+func (h HttpServer) RemoveSeriesBook(c *gin.Context) {
+	id, bookID, ok := seriesBookParams(c)
+	if !ok {
+		return
+	}
+
+	// call service
+	err := h.tenant(c).RemoveSeriesBook(id, bookID)
+	if err != nil {
+		seriesError(c, err)
+		return
+	}
+
+	helper.OkWithMessage(c, "Book removed from the series")
+}
+
+func seriesBookParams(c *gin.Context) (id, bookID int64, ok bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid series ID")
+		return 0, 0, false
+	}
+
+	bookID, err = strconv.ParseInt(c.Param("book_id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return 0, 0, false
+	}
+
+	return id, bookID, true
+}
+
+func seriesError(c *gin.Context, err error) {
+	if err.Error() == helper.ErrNotFound {
+		helper.NotFound(c, err.Error())
+		return
+	}
+	if err.Error() == helper.ErrDuplicatedKey {
+		helper.Conflict(c, "A series with this name already exists")
+		return
+	}
+	helper.InternalServerError(c, err.Error())
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"ninth-learn/helper"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultSimilarLimit = 10
+	maxSimilarLimit     = 50
+)
+
+// GetSimilarBooks godoc
+// @Summary      Show similar books
+// @Description  get the books closest to a book by title and description, shared authors, tags and categories, most similar first
+// @Tags         books
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int  true   "Book ID"
+// @Param        limit  query     int  false  "Number of books, at most 50"  default(10)
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/similar [get]
+func (h HttpServer) GetSimilarBooks(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return
+	}
+
+	limit := defaultSimilarLimit
+	if v := c.Query("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxSimilarLimit {
+			helper.BadRequest(c, "limit must be between 1 and 50")
+			return
+		}
+	}
+
+	// call service
+	res, err := h.tenant(c).GetSimilarBooks(id, limit)
+	if err != nil {
+		if err.Error() == helper.ErrNotFound {
+			helper.NotFound(c, err.Error())
+			return
+		}
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
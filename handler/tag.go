@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateTag godoc
+// @Summary		 Creates a new Tag
+// @Description  Create a new tag, names are stored lower case
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param 		 tag_request body model.TagRequest true "Tag request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /tags [post]
+func (h HttpServer) CreateTag(c *gin.Context) {
+	in := model.Tag{}
+
+	err := c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).CreateTag(in)
+	if err != nil {
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetTags godoc
+// @Summary      Show all tag
+// @Description  get all tag with the number of books using it, most used first
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /tags [get]
+func (h HttpServer) GetTags(c *gin.Context) {
+	// call service
+	res, err := h.tenant(c).GetTags()
+	if err != nil {
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// UpdateTag godoc
+// @Summary      Update tag
+// @Description  Rename tag by id
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Tag ID"
+// @Param 		 tag_request body model.TagRequest true "Tag request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Router       /tags/{id} [put]
+func (h HttpServer) UpdateTag(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid tag ID")
+		return
+	}
+
+	in := model.Tag{}
+	err = c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+	in.ID = id
+	// call service
+	res, err := h.tenant(c).UpdateTag(in)
+	if err != nil {
+		helper.NotFound(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// DeleteTag godoc
+// @Summary      Delete tag
+// @Description  Delete tag by id and remove it from its books
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Tag ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Router       /tags/{id} [delete]
+func (h HttpServer) DeleteTag(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid tag ID")
+		return
+	}
+
+	// call service
+	err = h.tenant(c).DeleteTag(id)
+	if err != nil {
+		helper.NotFound(c, err.Error())
+		return
+	}
+
+	helper.OkWithMessage(c, "Tag deleted successfully")
+}
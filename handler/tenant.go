@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateTenant godoc
+// @Summary		 Creates a new Tenant
+// @Description  Create a new tenant, platform admin only
+// @Tags         tenants
+// @Accept       json
+// @Produce      json
+// @Param 		 tenant_request body model.TenantRequest true "Tenant request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      403  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /admin/tenants [post]
+func (h HttpServer) CreateTenant(c *gin.Context) {
+	in := model.Tenant{}
+
+	err := c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := h.app.CreateTenant(in)
+	if err != nil {
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetTenants godoc
+// @Summary      Show all tenant
+// @Description  get all tenant, platform admin only
+// @Tags         tenants
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      403  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /admin/tenants [get]
+func (h HttpServer) GetTenants(c *gin.Context) {
+	// call service
+	res, err := h.app.GetTenants()
+	if err != nil {
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// SuspendTenant godoc
+// @Summary      Suspend tenant
+// @Description  Suspend tenant by id, its catalogue becomes unreachable, platform admin only
+// @Tags         tenants
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Tenant ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      403  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Router       /admin/tenants/{id}/suspend [put]
+func (h HttpServer) SuspendTenant(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	// call service
+	res, err := h.app.SuspendTenant(id)
+	if err != nil {
+		helper.NotFound(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// ActivateTenant godoc
+// @Summary      Activate tenant
+// @Description  Reactivate a suspended tenant by id, platform admin only
+// @Tags         tenants
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Tenant ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      403  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Router       /admin/tenants/{id}/activate [put]
+func (h HttpServer) ActivateTenant(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	// call service
+	res, err := h.app.ActivateTenant(id)
+	if err != nil {
+		helper.NotFound(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
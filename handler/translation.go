@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"errors"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetBookTranslations godoc
+// @Summary      Show translations of a book
+// @Description  get the titles and descriptions of a book in other languages
+// @Tags         translations
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Book ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/translations [get]
+func (h HttpServer) GetBookTranslations(c *gin.Context) {
+	bookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetBookTranslations(bookID)
+	if err != nil {
+		translationError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// PutBookTranslation godoc
+// @Summary      Translate a book
+// @Description  Set the title and description of a book in a language, replacing any earlier translation in that language
+// @Tags         translations
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int     true  "Book ID"
+// @Param        lang  path      string  true  "BCP 47 language tag"
+// @Param 		 translation_request body model.BookTranslationRequest true "Translation request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/translations/{lang} [put]
+func (h HttpServer) PutBookTranslation(c *gin.Context) {
+	bookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return
+	}
+
+	in := model.BookTranslation{}
+	err = c.BindJSON(&in)
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	err = in.Validation()
+	if err != nil {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).PutBookTranslation(bookID, c.Param("lang"), in)
+	if err != nil {
+		translationError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// DeleteBookTranslation godoc
+// @Summary      Delete translation
+// @Description  Delete the translation of a book in a language
+// @Tags         translations
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int     true  "Book ID"
+// @Param        lang  path      string  true  "BCP 47 language tag"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /books/{id}/translations/{lang} [delete]
+func (h HttpServer) DeleteBookTranslation(c *gin.Context) {
+	bookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid book ID")
+		return
+	}
+
+	// call service
+	err = h.tenant(c).DeleteBookTranslation(bookID, c.Param("lang"))
+	if err != nil {
+		translationError(c, err)
+		return
+	}
+
+	helper.OkWithMessage(c, "Translation deleted successfully")
+}
+
+func translationError(c *gin.Context, err error) {
+	if errors.Is(err, model.ErrInvalidLanguageTag) {
+		helper.BadRequest(c, err.Error())
+		return
+	}
+	if err.Error() == helper.ErrNotFound {
+		helper.NotFound(c, err.Error())
+		return
+	}
+	helper.InternalServerError(c, err.Error())
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetWebhooks godoc
+// @Summary      Show all webhooks
+// @Description  get all webhooks of the tenant, secrets are left out
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      403  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /webhooks [get]
+func (h HttpServer) GetWebhooks(c *gin.Context) {
+	// call service
+	res, err := h.tenant(c).GetWebhooks()
+	if err != nil {
+		helper.InternalServerError(c, err.Error())
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// GetWebhookById godoc
+// @Summary      Show a webhook
+// @Description  get detail webhook by id, the secret is left out
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Webhook ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      403  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /webhooks/{id} [get]
+func (h HttpServer) GetWebhookById(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetWebhookById(id)
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// CreateWebhook godoc
+// @Summary		 Creates a new Webhook
+// @Description  Subscribe a URL to book events. deliveries are POSTed with an X-Webhook-Signature header of t=<unix time>,v1=<hex HMAC-SHA256 of "<t>.<body>" keyed with the secret>. the secret is generated when left empty and is only shown in this response
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param 		 webhook_request body model.WebhookRequest true "Webhook request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      403  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /webhooks [post]
+func (h HttpServer) CreateWebhook(c *gin.Context) {
+	in, ok := bindWebhook(c)
+	if !ok {
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).CreateWebhook(in)
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// UpdateWebhook godoc
+// @Summary      Update webhook
+// @Description  Update webhook by id, the secret is kept when left empty
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Webhook ID"
+// @Param 		 webhook_request body model.WebhookRequest true "Webhook request object"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      403  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /webhooks/{id} [put]
+func (h HttpServer) UpdateWebhook(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	in, ok := bindWebhook(c)
+	if !ok {
+		return
+	}
+	in.ID = id
+
+	// call service
+	res, err := h.tenant(c).UpdateWebhook(in)
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// DeleteWebhook godoc
+// @Summary      Delete webhook
+// @Description  Delete webhook by id along with its delivery log
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Webhook ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      403  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /webhooks/{id} [delete]
+func (h HttpServer) DeleteWebhook(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	// call service
+	err = h.tenant(c).DeleteWebhook(id)
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+
+	helper.OkWithMessage(c, "Webhook deleted successfully")
+}
+
+// GetWebhookDeliveries godoc
+// @Summary      Show webhook deliveries
+// @Description  get the latest deliveries of a webhook, most recent first, with the outcome of their last attempt
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Webhook ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      403  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /webhooks/{id}/deliveries [get]
+func (h HttpServer) GetWebhookDeliveries(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).GetWebhookDeliveries(id)
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// RedeliverWebhook godoc
+// @Summary      Redeliver a webhook delivery
+// @Description  Send the payload of a delivery again right away, as a new delivery that is retried like any other
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        id           path      int  true  "Webhook ID"
+// @Param        delivery_id  path      int  true  "Delivery ID"
+// @Success      200  {object}  helper.Response
+// @Failure      400  {object}  helper.Response
+// @Failure      401  {object}  helper.Response
+// @Failure      403  {object}  helper.Response
+// @Failure      404  {object}  helper.Response
+// @Failure      500  {object}  helper.Response
+// @Router       /webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h HttpServer) RedeliverWebhook(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+	deliveryID, err := strconv.ParseInt(c.Param("delivery_id"), 10, 64)
+	if err != nil {
+		helper.BadRequest(c, "Invalid delivery ID")
+		return
+	}
+
+	// call service
+	res, err := h.tenant(c).RedeliverWebhook(id, deliveryID)
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+
+	helper.Ok(c, res)
+}
+
+// bindWebhook reads and validates a webhook request, webhooks are active
+// unless the request says otherwise
+func bindWebhook(c *gin.Context) (model.Webhook, bool) {
+	req := model.WebhookRequest{}
+	if err := c.BindJSON(&req); err != nil {
+		helper.BadRequest(c, err.Error())
+		return model.Webhook{}, false
+	}
+
+	in := model.Webhook{
+		URL:    req.URL,
+		Events: req.Events,
+		Secret: req.Secret,
+		Active: req.Active == nil || *req.Active,
+	}
+	if err := in.Validation(); err != nil {
+		helper.BadRequest(c, err.Error())
+		return in, false
+	}
+	return in, true
+}
+
+func webhookError(c *gin.Context, err error) {
+	if err.Error() == helper.ErrNotFound {
+		helper.NotFound(c, err.Error())
+		return
+	}
+	helper.InternalServerError(c, err.Error())
+}
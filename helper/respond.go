@@ -8,7 +8,8 @@ import (
 )
 
 var (
-	ErrNotFound = "record not found"
+	ErrNotFound      = "record not found"
+	ErrDuplicatedKey = "duplicated key not allowed"
 )
 
 type Response struct {
@@ -69,6 +70,15 @@ func NotFound(c *gin.Context, message string) {
 	c.JSON(http.StatusNotFound, response)
 }
 
+func Conflict(c *gin.Context, message string) {
+	response := Response{
+		Status:  http.StatusConflict,
+		Message: message,
+		Data:    nil,
+	}
+	c.JSON(http.StatusConflict, response)
+}
+
 func InternalServerError(c *gin.Context, message string) {
 	response := Response{
 		Status:  http.StatusInternalServerError,
@@ -77,3 +87,39 @@ func InternalServerError(c *gin.Context, message string) {
 	}
 	c.JSON(http.StatusInternalServerError, response)
 }
+
+func Unauthorized(c *gin.Context, message string) {
+	response := Response{
+		Status:  http.StatusUnauthorized,
+		Message: message,
+		Data:    nil,
+	}
+	c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+}
+
+func Forbidden(c *gin.Context, message string) {
+	response := Response{
+		Status:  http.StatusForbidden,
+		Message: message,
+		Data:    nil,
+	}
+	c.AbortWithStatusJSON(http.StatusForbidden, response)
+}
+
+func PayloadTooLarge(c *gin.Context, message string) {
+	response := Response{
+		Status:  http.StatusRequestEntityTooLarge,
+		Message: message,
+		Data:    nil,
+	}
+	c.JSON(http.StatusRequestEntityTooLarge, response)
+}
+
+func UnsupportedMediaType(c *gin.Context, message string) {
+	response := Response{
+		Status:  http.StatusUnsupportedMediaType,
+		Message: message,
+		Data:    nil,
+	}
+	c.JSON(http.StatusUnsupportedMediaType, response)
+}
@@ -14,6 +14,11 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+
+	err = config.InitStorage()
+	if err != nil {
+		panic(err)
+	}
 }
 
 func main() {
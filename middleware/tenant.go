@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"errors"
+	"net"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"ninth-learn/service"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	tenantKey    = "tenant"
+	TenantHeader = "X-Tenant-ID"
+)
+
+type TenantResolver interface {
+	ResolveTenant(slug string) (model.Tenant, error)
+}
+
+// Tenant resolves the tenant of a request, in order of precedence, from the
+// principal, the subdomain of TENANT_BASE_DOMAIN or the X-Tenant-ID header,
+// falling back to DEFAULT_TENANT. a header naming a different tenant than
+// the principal is rejected.
+func Tenant(resolver TenantResolver) gin.HandlerFunc {
+	baseDomain := strings.ToLower(os.Getenv("TENANT_BASE_DOMAIN"))
+	fallback := os.Getenv("DEFAULT_TENANT")
+	if fallback == "" {
+		fallback = model.DefaultTenantSlug
+	}
+
+	return func(c *gin.Context) {
+		slug := ""
+		if principal, ok := Principal(c); ok && principal.Tenant != "" {
+			slug = principal.Tenant
+		}
+
+		if sub := subdomain(c.Request.Host, baseDomain); sub != "" {
+			if slug != "" && slug != sub {
+				helper.Forbidden(c, "Tenant mismatch")
+				return
+			}
+			slug = sub
+		}
+
+		if header := c.GetHeader(TenantHeader); header != "" {
+			if slug != "" && slug != header {
+				helper.Forbidden(c, "Tenant mismatch")
+				return
+			}
+			slug = header
+		}
+
+		if slug == "" {
+			slug = fallback
+		}
+
+		tenant, err := resolver.ResolveTenant(slug)
+		if err != nil {
+			switch {
+			case err.Error() == helper.ErrNotFound:
+				helper.NotFound(c, "Unknown tenant")
+			case errors.Is(err, service.ErrTenantSuspended):
+				helper.Forbidden(c, err.Error())
+			default:
+				helper.InternalServerError(c, err.Error())
+			}
+			c.Abort()
+			return
+		}
+
+		c.Set(tenantKey, tenant.ID)
+		c.Next()
+	}
+}
+
+// TenantID returns the tenant resolved for the request
+func TenantID(c *gin.Context) int64 {
+	return c.GetInt64(tenantKey)
+}
+
+func subdomain(host, baseDomain string) string {
+	if baseDomain == "" {
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	host = strings.ToLower(host)
+	if !strings.HasSuffix(host, "."+baseDomain) {
+		return ""
+	}
+	return strings.TrimSuffix(host, "."+baseDomain)
+}
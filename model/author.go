@@ -0,0 +1,73 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	AuthorRoleAuthor     = "author"
+	AuthorRoleEditor     = "editor"
+	AuthorRoleTranslator = "translator"
+)
+
+type Author struct {
+	ID             int64     `json:"id" gorm:"column:id"`
+	TenantID       int64     `json:"-" gorm:"column:tenant_id;uniqueIndex:idx_authors_tenant_name"`
+	Name           string    `json:"name" gorm:"column:name"`
+	NormalizedName string    `json:"-" gorm:"column:normalized_name;uniqueIndex:idx_authors_tenant_name"`
+	Bio            string    `json:"bio" gorm:"column:bio"`
+	CreatedAt      time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+type AuthorRequest struct {
+	Name string `json:"name" example:"F. Scott Fitzgerald"`
+	Bio  string `json:"bio" example:"American novelist of the Jazz Age"`
+}
+
+// BookAuthor links a book to one of its contributors
+type BookAuthor struct {
+	BookID   int64   `json:"-" gorm:"column:book_id;primaryKey"`
+	AuthorID int64   `json:"author_id" gorm:"column:author_id;primaryKey;index"`
+	Role     string  `json:"role" gorm:"column:role;primaryKey"`
+	Author   *Author `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+}
+
+type BookAuthorRequest struct {
+	AuthorID int64  `json:"author_id" example:"1"`
+	Role     string `json:"role" example:"author"`
+}
+
+func (m *Author) TableName() string {
+	return "public.authors"
+}
+
+func (m *BookAuthor) TableName() string {
+	return "public.book_authors"
+}
+
+func (e Author) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Name, validation.Required, validation.Length(3, 100)),
+		validation.Field(&e.Bio, validation.Length(0, 1000)))
+}
+
+// Validate lets ozzo validate each link of a book
+func (e BookAuthor) Validate() error {
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.AuthorID, validation.Required),
+		validation.Field(&e.Role, validation.Required, validation.In(AuthorRoleAuthor, AuthorRoleEditor, AuthorRoleTranslator)))
+}
+
+// NormalizeAuthorName folds case, punctuation and spacing so that
+// "F. Scott Fitzgerald" and "F Scott Fitzgerald" compare equal
+func NormalizeAuthorName(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
@@ -1,24 +1,98 @@
 package model
 
 import (
+	"errors"
+	"strings"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 )
 
+const (
+	FormatHardcover = "hardcover"
+	FormatPaperback = "paperback"
+	FormatEbook     = "ebook"
+	FormatAudiobook = "audiobook"
+)
+
 type Book struct {
-	ID          int64     `json:"id" gorm:"column:id"`
-	Title       string    `json:"title" gorm:"column:title" validate:"required,min=3,max=100"`
-	Author      string    `json:"author" gorm:"column:author" validate:"required,min=3,max=100"`
-	Description string    `json:"description" gorm:"column:description" validate:"required,min=3,max=1000"`
-	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at"`
-	UpdatedAt   time.Time `json:"updated_at" gorm:"column:updated_at"`
+	ID              int64            `json:"id" gorm:"column:id"`
+	TenantID        int64            `json:"-" gorm:"column:tenant_id;index;uniqueIndex:idx_books_tenant_isbn13"`
+	Title           string           `json:"title" gorm:"column:title" validate:"required,min=3,max=100"`
+	Subtitle        string           `json:"subtitle" gorm:"column:subtitle;not null;default:''"`
+	Author          string           `json:"author" gorm:"column:author" validate:"required,min=3,max=100"`
+	Description     string           `json:"description" gorm:"column:description" validate:"required,min=3,max=1000"`
+	ISBN10          string           `json:"isbn_10" gorm:"column:isbn_10;not null;default:''"`
+	ISBN13          string           `json:"isbn_13" gorm:"column:isbn_13;not null;default:'';uniqueIndex:idx_books_tenant_isbn13,where:isbn_13 <> ''"`
+	Publisher       string           `json:"publisher" gorm:"column:publisher;not null;default:'';index"`
+	PublicationDate *Date            `json:"publication_date" gorm:"column:publication_date"`
+	Edition         string           `json:"edition" gorm:"column:edition;not null;default:''"`
+	Language        string           `json:"language" gorm:"column:language;not null;default:'';index"`
+	PageCount       int              `json:"page_count" gorm:"column:page_count;not null;default:0"`
+	Format          string           `json:"format" gorm:"column:format;not null;default:''"`
+	SeriesName      string           `json:"series_name" gorm:"column:series_name;not null;default:'';index"`
+	SeriesPosition  float64          `json:"series_position" gorm:"column:series_position;not null;default:0"`
+	Authors         []BookAuthor     `json:"authors" gorm:"foreignKey:BookID"`
+	Categories      []Category       `json:"categories" gorm:"many2many:book_categories"`
+	Tags            []Tag            `json:"tags" gorm:"many2many:book_tags"`
+	CoverETag       string           `json:"-" gorm:"column:cover_etag;not null;default:''"`
+	CoverUpdatedAt  *time.Time       `json:"cover_updated_at" gorm:"column:cover_updated_at"`
+	RatingAverage   float64          `json:"average_rating" gorm:"column:rating_average;not null;default:0;index"`
+	RatingCount     int64            `json:"review_count" gorm:"column:rating_count;not null;default:0"`
+	RatingSum       int64            `json:"-" gorm:"column:rating_sum;not null;default:0"`
+	TotalCopies     int64            `json:"total_copies" gorm:"-"`
+	AvailableCopies int64            `json:"available_copies" gorm:"-"`
+	HoldQueueLength int64            `json:"hold_queue_length" gorm:"-"`
+	HoldPosition    *int64           `json:"hold_position,omitempty" gorm:"-"`
+	Duplicates      []DuplicateMatch `json:"possible_duplicates,omitempty" gorm:"-"`
+	DisplayLanguage string           `json:"display_language,omitempty" gorm:"-"`
+	Series          []BookSeries     `json:"series,omitempty" gorm:"-"`
+	RelatedWorks    []RelatedWork    `json:"related_works,omitempty" gorm:"-"`
+	CreatedAt       time.Time        `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt       time.Time        `json:"updated_at" gorm:"column:updated_at"`
 }
 
 type BookRequest struct {
-	Title       string `json:"title" example:"Test Book"`
-	Author      string `json:"author" example:"Test Author"`
-	Description string `json:"description" example:"Test Desc"`
+	Title           string                `json:"title" example:"Test Book"`
+	Subtitle        string                `json:"subtitle" example:"A Novel"`
+	Author          string                `json:"author" example:"Test Author"`
+	Description     string                `json:"description" example:"Test Desc"`
+	ISBN10          string                `json:"isbn_10" example:"0-7432-7356-7"`
+	ISBN13          string                `json:"isbn_13" example:"978-0-7432-7356-5"`
+	Publisher       string                `json:"publisher" example:"Scribner"`
+	PublicationDate string                `json:"publication_date,omitempty" example:"2004-09-30" format:"date"`
+	Edition         string                `json:"edition" example:"Reprint"`
+	Language        string                `json:"language" example:"en"`
+	PageCount       int                   `json:"page_count" example:"180"`
+	Format          string                `json:"format" example:"paperback" enums:"hardcover,paperback,ebook,audiobook"`
+	SeriesName      string                `json:"series_name" example:"Test Series"`
+	SeriesPosition  float64               `json:"series_position" example:"1"`
+	Authors         []BookAuthorRequest   `json:"authors"`
+	Categories      []BookCategoryRequest `json:"categories"`
+	Tags            []TagRequest          `json:"tags"`
+}
+
+// SimilarBook is a book related to another, similarity runs from 0 to 1
+type SimilarBook struct {
+	Book
+	Similarity float64 `json:"similarity"`
+}
+
+// BookFilter narrows down GET /books
+type BookFilter struct {
+	CategoryID      int64
+	Tags            []string
+	MatchAllTags    bool
+	Publisher       string
+	Language        string
+	Format          string
+	SeriesName      string
+	PublishedAfter  *Date
+	PublishedBefore *Date
+	Sort            string
+	Query           string
+	Limit           int
+	Offset          int
 }
 
 func (m *Book) TableName() string {
@@ -26,8 +100,80 @@ func (m *Book) TableName() string {
 }
 
 func (e Book) Validation() error { // custom validation
+	// the codes are checked the way they are stored
+	e.NormalizeMetadata()
 	return validation.ValidateStruct(&e,
 		validation.Field(&e.Title, validation.Required, validation.Length(3, 100)),
 		validation.Field(&e.Author, validation.Required, validation.Length(3, 100)),
-		validation.Field(&e.Description, validation.Required, validation.Length(3, 1000)))
+		validation.Field(&e.Description, validation.Required, validation.Length(3, 1000)),
+		validation.Field(&e.ISBN10, isbn10Rule),
+		validation.Field(&e.ISBN13, isbn13Rule, validation.By(e.matchesISBN10)),
+		validation.Field(&e.Subtitle, validation.Length(0, 200)),
+		validation.Field(&e.Publisher, validation.Length(0, 100)),
+		validation.Field(&e.PublicationDate, validation.By(notInFuture)),
+		validation.Field(&e.Edition, validation.Length(0, 50)),
+		validation.Field(&e.Language, validation.By(isLanguage)),
+		validation.Field(&e.PageCount, validation.Min(0), validation.Max(100000)),
+		validation.Field(&e.Format, validation.In(FormatHardcover, FormatPaperback, FormatEbook, FormatAudiobook)),
+		validation.Field(&e.SeriesName, validation.Length(0, 100)),
+		validation.Field(&e.SeriesPosition, validation.Min(float64(0)), validation.By(e.seriesComplete)),
+		validation.Field(&e.Authors),
+		validation.Field(&e.Categories),
+		validation.Field(&e.Tags))
+}
+
+// NormalizeISBN strips hyphenation and fills in whichever of the ISBN-10
+// and ISBN-13 can be derived from the other
+func (m *Book) NormalizeISBN() {
+	m.ISBN10 = NormalizeISBN(m.ISBN10)
+	m.ISBN13 = NormalizeISBN(m.ISBN13)
+
+	if m.ISBN13 == "" && ValidISBN10(m.ISBN10) {
+		m.ISBN13 = ISBN10To13(m.ISBN10)
+	}
+	if m.ISBN10 == "" && ValidISBN13(m.ISBN13) {
+		m.ISBN10, _ = ISBN13To10(m.ISBN13)
+	}
+}
+
+func (e Book) matchesISBN10(value interface{}) error {
+	isbn10, isbn13 := NormalizeISBN(e.ISBN10), NormalizeISBN(e.ISBN13)
+	if !ValidISBN10(isbn10) || !ValidISBN13(isbn13) {
+		return nil
+	}
+	if ISBN10To13(isbn10) != isbn13 {
+		return ErrISBNMismatch
+	}
+	return nil
+}
+
+// NormalizeMetadata lower cases the codes clients may send in any case
+func (m *Book) NormalizeMetadata() {
+	m.Language = strings.ToLower(strings.TrimSpace(m.Language))
+	m.Format = strings.ToLower(strings.TrimSpace(m.Format))
+}
+
+// seriesComplete requires the series name and position to come together
+func (e Book) seriesComplete(value interface{}) error {
+	if e.SeriesName != "" && e.SeriesPosition == 0 {
+		return errors.New("is required with series_name")
+	}
+	if e.SeriesName == "" && e.SeriesPosition != 0 {
+		return errors.New("requires series_name")
+	}
+	return nil
+}
+
+func notInFuture(value interface{}) error {
+	if d, _ := value.(*Date); d != nil && d.After(time.Now()) {
+		return errors.New("must not be in the future")
+	}
+	return nil
+}
+
+func isLanguage(value interface{}) error {
+	if s, _ := value.(string); s != "" && !ValidLanguage(s) {
+		return errors.New("must be an ISO 639-1 language code")
+	}
+	return nil
 }
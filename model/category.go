@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type Category struct {
+	ID        int64     `json:"id" gorm:"column:id"`
+	TenantID  int64     `json:"-" gorm:"column:tenant_id;index"`
+	ParentID  *int64    `json:"parent_id" gorm:"column:parent_id;index"`
+	Name      string    `json:"name" gorm:"column:name"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+type CategoryRequest struct {
+	ParentID *int64 `json:"parent_id" example:"1"`
+	Name     string `json:"name" example:"Science Fiction"`
+}
+
+// BookCategory is the join table between books and categories
+type BookCategory struct {
+	BookID     int64 `gorm:"column:book_id;primaryKey"`
+	CategoryID int64 `gorm:"column:category_id;primaryKey;index"`
+}
+
+type BookCategoryRequest struct {
+	ID int64 `json:"id" example:"1"`
+}
+
+func (m *Category) TableName() string {
+	return "public.categories"
+}
+
+func (m *BookCategory) TableName() string {
+	return "public.book_categories"
+}
+
+func (e Category) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Name, validation.Required, validation.Length(2, 100)))
+}
+
+// Validate lets ozzo validate each category assigned to a book
+func (e Category) Validate() error {
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.ID, validation.Required))
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+var ErrBookHasCopies = errors.New("book still has copies, delete them first or force the delete")
+
+const (
+	CopyStatusAvailable = "available"
+	CopyStatusOnLoan    = "on_loan"
+	CopyStatusOnHold    = "on_hold"
+	CopyStatusInRepair  = "in_repair"
+	CopyStatusLost      = "lost"
+	CopyStatusWithdrawn = "withdrawn"
+)
+
+const (
+	CopyConditionNew     = "new"
+	CopyConditionGood    = "good"
+	CopyConditionFair    = "fair"
+	CopyConditionPoor    = "poor"
+	CopyConditionDamaged = "damaged"
+)
+
+// Copy is a physical item of a book held by the library
+type Copy struct {
+	ID            int64     `json:"id" gorm:"column:id"`
+	TenantID      int64     `json:"-" gorm:"column:tenant_id;uniqueIndex:idx_copies_tenant_barcode"`
+	BookID        int64     `json:"book_id" gorm:"column:book_id;index"`
+	Barcode       string    `json:"barcode" gorm:"column:barcode;uniqueIndex:idx_copies_tenant_barcode"`
+	ShelfLocation string    `json:"shelf_location" gorm:"column:shelf_location;not null;default:''"`
+	Condition     string    `json:"condition" gorm:"column:condition;not null;default:'good'"`
+	Status        string    `json:"status" gorm:"column:status;not null;default:'available';index"`
+	AcquiredAt    *Date     `json:"acquired_at" gorm:"column:acquired_at"`
+	Book          *Book     `json:"book,omitempty" gorm:"foreignKey:BookID"`
+	CreatedAt     time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+type CopyRequest struct {
+	Barcode       string `json:"barcode" example:"LIB-000123"`
+	ShelfLocation string `json:"shelf_location" example:"Fiction A-12"`
+	Condition     string `json:"condition" example:"good" enums:"new,good,fair,poor,damaged"`
+	Status        string `json:"status" example:"available" enums:"available,in_repair,lost,withdrawn"`
+	AcquiredAt    string `json:"acquired_at,omitempty" example:"2023-01-15" format:"date"`
+}
+
+// CopyCount is the number of copies of a book, in total and on the shelf
+type CopyCount struct {
+	BookID    int64 `gorm:"column:book_id"`
+	Total     int64 `gorm:"column:total"`
+	Available int64 `gorm:"column:available"`
+}
+
+func (m *Copy) TableName() string {
+	return "public.copies"
+}
+
+func (e Copy) Validation() error { // custom validation
+	// the copy is checked the way it is stored
+	e.Normalize()
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Barcode, validation.Required, validation.Length(1, 50)),
+		validation.Field(&e.ShelfLocation, validation.Length(0, 100)),
+		validation.Field(&e.Condition, validation.In(CopyConditionNew, CopyConditionGood, CopyConditionFair, CopyConditionPoor, CopyConditionDamaged)),
+		validation.Field(&e.Status, validation.In(CopyStatusAvailable, CopyStatusInRepair, CopyStatusLost, CopyStatusWithdrawn)),
+		validation.Field(&e.AcquiredAt, validation.By(notInFuture)))
+}
+
+// Normalize trims the barcode and fills in the defaults
+func (m *Copy) Normalize() {
+	m.Barcode = strings.TrimSpace(m.Barcode)
+	m.Condition = strings.ToLower(strings.TrimSpace(m.Condition))
+	m.Status = strings.ToLower(strings.TrimSpace(m.Status))
+	if m.Condition == "" {
+		m.Condition = CopyConditionGood
+	}
+	if m.Status == "" {
+		m.Status = CopyStatusAvailable
+	}
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	CoverSizeOriginal = "original"
+	CoverSizeSmall    = "small"
+	CoverSizeMedium   = "medium"
+	CoverSizeLarge    = "large"
+)
+
+// ErrCoverLeft is returned by the repo writes that removed a book but not
+// its cover, the book is gone regardless and the write succeeded
+var ErrCoverLeft = errors.New("book removed, its cover could not be deleted")
+
+// CoverWidths are the widths in pixels of the generated thumbnails
+var CoverWidths = map[string]int{
+	CoverSizeSmall:  150,
+	CoverSizeMedium: 300,
+	CoverSizeLarge:  600,
+}
+
+// CoverSizes lists every stored rendition of a cover
+var CoverSizes = []string{CoverSizeOriginal, CoverSizeSmall, CoverSizeMedium, CoverSizeLarge}
+
+// CoverImage is one rendition of a book cover
+type CoverImage struct {
+	Size        string
+	ContentType string
+	Data        []byte
+	ETag        string
+	UpdatedAt   time.Time
+}
+
+func ValidCoverSize(size string) bool {
+	for _, s := range CoverSizes {
+		if s == size {
+			return true
+		}
+	}
+	return false
+}
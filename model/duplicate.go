@@ -0,0 +1,70 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	DuplicateReasonISBN    = "isbn"
+	DuplicateReasonSimilar = "similar"
+)
+
+// books whose normalized titles and authors are at least this similar are
+// reported as likely duplicates
+const (
+	DuplicateTitleSimilarity  = 0.55
+	DuplicateAuthorSimilarity = 0.5
+)
+
+var ErrMergeSameBook = errors.New("a book cannot be merged into itself")
+
+// BookSummary is the part of a book duplicate detection looks at
+type BookSummary struct {
+	ID     int64  `json:"id"`
+	Title  string `json:"title"`
+	Author string `json:"author"`
+	ISBN10 string `json:"isbn_10"`
+	ISBN13 string `json:"isbn_13"`
+}
+
+// DuplicateMatch is a book that likely describes the same work as another
+type DuplicateMatch struct {
+	BookID  int64   `json:"book_id"`
+	OtherID int64   `json:"other_id"`
+	Score   float64 `json:"score"`
+	Reason  string  `json:"reason"`
+}
+
+// DuplicateCluster groups books linked by likely duplicate matches
+type DuplicateCluster struct {
+	Books   []BookSummary    `json:"books"`
+	Matches []DuplicateMatch `json:"matches"`
+	Score   float64          `json:"score"`
+}
+
+// BookRedirect keeps the ID of a book merged into another resolving to
+// the book it was merged into
+type BookRedirect struct {
+	FromID    int64     `json:"from_id" gorm:"column:from_id;primaryKey;autoIncrement:false"`
+	TenantID  int64     `json:"-" gorm:"column:tenant_id;index"`
+	ToID      int64     `json:"to_id" gorm:"column:to_id;index"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+type MergeRequest struct {
+	SourceID int64 `json:"source_id" example:"2"`
+	TargetID int64 `json:"target_id" example:"1"`
+}
+
+func (m *BookRedirect) TableName() string {
+	return "public.book_redirects"
+}
+
+func (e MergeRequest) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.SourceID, validation.Required),
+		validation.Field(&e.TargetID, validation.Required))
+}
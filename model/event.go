@@ -0,0 +1,88 @@
+package model
+
+import "time"
+
+const (
+	BookCreated = "book.created"
+	BookUpdated = "book.updated"
+	BookDeleted = "book.deleted"
+)
+
+// BookEvent is a change to the catalogue, the book is left out of deletes
+type BookEvent struct {
+	// ID orders the events of this process, watchers resume after it
+	ID         string    `json:"-"`
+	Type       string    `json:"type"`
+	TenantID   int64     `json:"-"`
+	BookID     int64     `json:"book_id"`
+	Book       *Book     `json:"book,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// BookEventFilter picks the events of books by one of the authors and with
+// one of the tags, an empty list picks any. deletes carry no book and pass
+// every filter
+type BookEventFilter struct {
+	Authors []string
+	Tags    []string
+}
+
+func (f BookEventFilter) Matches(e BookEvent) bool {
+	if e.Book == nil {
+		return true
+	}
+	return f.matchesAuthor(*e.Book) && f.matchesTag(*e.Book)
+}
+
+func (f BookEventFilter) matchesAuthor(book Book) bool {
+	if len(f.Authors) == 0 {
+		return true
+	}
+
+	names := []string{NormalizeAuthorName(book.Author)}
+	for _, a := range book.Authors {
+		if a.Author != nil {
+			names = append(names, NormalizeAuthorName(a.Author.Name))
+		}
+	}
+	for _, want := range f.Authors {
+		want = NormalizeAuthorName(want)
+		for _, name := range names {
+			if want != "" && name == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (f BookEventFilter) matchesTag(book Book) bool {
+	if len(f.Tags) == 0 {
+		return true
+	}
+
+	for _, want := range f.Tags {
+		want = NormalizeTagName(want)
+		for _, tag := range book.Tags {
+			if NormalizeTagName(tag.Name) == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+const (
+	BookChangeInsert = "INSERT"
+	BookChangeUpdate = "UPDATE"
+	BookChangeDelete = "DELETE"
+)
+
+// BookChange is a write to public.books as the database notifies it, origin
+// names the instance that made it
+type BookChange struct {
+	Op       string `json:"op"`
+	BookID   int64  `json:"id"`
+	TenantID int64  `json:"tenant_id"`
+	Origin   string `json:"origin"`
+}
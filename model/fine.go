@@ -0,0 +1,56 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	FineKindPayment = "payment"
+	FineKindWaiver  = "waiver"
+)
+
+var (
+	ErrFineExceedsBalance = errors.New("amount exceeds the outstanding balance")
+	ErrFinesOwed          = errors.New("member still owes fines, settle them first")
+)
+
+// FineTransaction settles part of a member's fines, amounts are in cents
+type FineTransaction struct {
+	ID          int64     `json:"id" gorm:"column:id"`
+	TenantID    int64     `json:"-" gorm:"column:tenant_id;index"`
+	MemberID    int64     `json:"member_id" gorm:"column:member_id;index"`
+	LoanID      *int64    `json:"loan_id" gorm:"column:loan_id"`
+	Kind        string    `json:"kind" gorm:"column:kind"`
+	AmountCents int64     `json:"amount_cents" gorm:"column:amount_cents"`
+	Note        string    `json:"note" gorm:"column:note;not null;default:''"`
+	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+type FineTransactionRequest struct {
+	AmountCents int64  `json:"amount_cents" example:"250"`
+	LoanID      *int64 `json:"loan_id" example:"1"`
+	Note        string `json:"note" example:"Paid at the front desk"`
+}
+
+// FineSummary is the fine account of a member
+type FineSummary struct {
+	MemberID     int64             `json:"member_id"`
+	AccruedCents int64             `json:"accrued_cents"`
+	PaidCents    int64             `json:"paid_cents"`
+	WaivedCents  int64             `json:"waived_cents"`
+	BalanceCents int64             `json:"balance_cents"`
+	Transactions []FineTransaction `json:"transactions"`
+}
+
+func (m *FineTransaction) TableName() string {
+	return "public.fine_transactions"
+}
+
+func (e FineTransaction) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.AmountCents, validation.Required, validation.Min(int64(1))),
+		validation.Field(&e.Note, validation.Length(0, 500)))
+}
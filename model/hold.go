@@ -0,0 +1,67 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	HoldStatusWaiting   = "waiting"
+	HoldStatusReady     = "ready"
+	HoldStatusFulfilled = "fulfilled"
+	HoldStatusCancelled = "cancelled"
+	HoldStatusExpired   = "expired"
+)
+
+var (
+	ErrHoldExists          = errors.New("member already has a hold on this book")
+	ErrHoldNotActive       = errors.New("hold is no longer active")
+	ErrBookHasNoCopies     = errors.New("book has no copies to hold")
+	ErrCopyAvailableToLoan = errors.New("a copy is available, check it out instead")
+	ErrAlreadyBorrowed     = errors.New("member already has this book on loan")
+)
+
+// Hold is a member's place in the queue for a book. A waiting hold becomes
+// ready once a copy is set aside for it, and expires when not picked up
+// before ExpiresAt.
+type Hold struct {
+	ID        int64      `json:"id" gorm:"column:id"`
+	TenantID  int64      `json:"-" gorm:"column:tenant_id;index"`
+	BookID    int64      `json:"book_id" gorm:"column:book_id;index"`
+	MemberID  int64      `json:"member_id" gorm:"column:member_id;index"`
+	CopyID    *int64     `json:"copy_id" gorm:"column:copy_id"`
+	Status    string     `json:"status" gorm:"column:status;not null;default:'waiting';index"`
+	PlacedAt  time.Time  `json:"placed_at" gorm:"column:placed_at"`
+	ReadyAt   *time.Time `json:"ready_at" gorm:"column:ready_at"`
+	ExpiresAt *time.Time `json:"expires_at" gorm:"column:expires_at"`
+	Position  int64      `json:"position,omitempty" gorm:"-"`
+	Member    *Member    `json:"member,omitempty" gorm:"foreignKey:MemberID"`
+	CreatedAt time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time  `json:"updated_at" gorm:"column:updated_at"`
+}
+
+type HoldRequest struct {
+	MemberID int64 `json:"member_id" example:"1"`
+}
+
+// HoldFilter narrows down hold listings
+type HoldFilter struct {
+	BookID     int64
+	MemberID   int64
+	ActiveOnly bool
+}
+
+func (m *Hold) TableName() string {
+	return "public.holds"
+}
+
+func (m Hold) IsActive() bool {
+	return m.Status == HoldStatusWaiting || m.Status == HoldStatusReady
+}
+
+func (e HoldRequest) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.MemberID, validation.Required))
+}
@@ -0,0 +1,123 @@
+package model
+
+import (
+	"errors"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+var (
+	ErrInvalidISBN  = errors.New("must be a valid ISBN")
+	ErrISBNMismatch = errors.New("isbn_10 and isbn_13 must identify the same book")
+)
+
+// NormalizeISBN strips hyphens and spaces and upper cases the ISBN-10 check digit
+func NormalizeISBN(isbn string) string {
+	isbn = strings.NewReplacer("-", "", " ", "").Replace(isbn)
+	return strings.ToUpper(isbn)
+}
+
+// ValidISBN10 checks a normalized ISBN-10, weighting digits 10 down to 1
+// and requiring the sum to be a multiple of 11. the check digit may be X.
+func ValidISBN10(isbn string) bool {
+	if len(isbn) != 10 {
+		return false
+	}
+
+	sum := 0
+	for i, r := range isbn {
+		var d int
+		switch {
+		case r >= '0' && r <= '9':
+			d = int(r - '0')
+		case r == 'X' && i == 9:
+			d = 10
+		default:
+			return false
+		}
+		sum += (10 - i) * d
+	}
+	return sum%11 == 0
+}
+
+// ValidISBN13 checks a normalized ISBN-13, weighting digits alternately by
+// 1 and 3 and requiring the sum to be a multiple of 10
+func ValidISBN13(isbn string) bool {
+	if len(isbn) != 13 || !(strings.HasPrefix(isbn, "978") || strings.HasPrefix(isbn, "979")) {
+		return false
+	}
+
+	sum := 0
+	for i, r := range isbn {
+		if r < '0' || r > '9' {
+			return false
+		}
+		sum += isbn13Weight(i) * int(r-'0')
+	}
+	return sum%10 == 0
+}
+
+// ISBN10To13 converts a valid normalized ISBN-10 to its ISBN-13 form
+func ISBN10To13(isbn string) string {
+	body := "978" + isbn[:9]
+
+	sum := 0
+	for i, r := range body {
+		sum += isbn13Weight(i) * int(r-'0')
+	}
+	return body + string(rune('0'+(10-sum%10)%10))
+}
+
+// ISBN13To10 converts a valid normalized ISBN-13 to its ISBN-10 form, only
+// 978 prefixed ISBNs have one
+func ISBN13To10(isbn string) (string, bool) {
+	if !strings.HasPrefix(isbn, "978") {
+		return "", false
+	}
+	body := isbn[3:12]
+
+	sum := 0
+	for i, r := range body {
+		sum += (10 - i) * int(r-'0')
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return body + "X", true
+	}
+	return body + string(rune('0'+check)), true
+}
+
+// CanonicalISBN returns the ISBN-13 form of any valid ISBN
+func CanonicalISBN(isbn string) (string, error) {
+	isbn = NormalizeISBN(isbn)
+	switch {
+	case ValidISBN13(isbn):
+		return isbn, nil
+	case ValidISBN10(isbn):
+		return ISBN10To13(isbn), nil
+	}
+	return "", ErrInvalidISBN
+}
+
+func isbn13Weight(i int) int {
+	if i%2 == 0 {
+		return 1
+	}
+	return 3
+}
+
+var (
+	isbn10Rule = validation.By(func(value interface{}) error {
+		if s, _ := value.(string); s != "" && !ValidISBN10(NormalizeISBN(s)) {
+			return ErrInvalidISBN
+		}
+		return nil
+	})
+	isbn13Rule = validation.By(func(value interface{}) error {
+		if s, _ := value.(string); s != "" && !ValidISBN13(NormalizeISBN(s)) {
+			return ErrInvalidISBN
+		}
+		return nil
+	})
+)
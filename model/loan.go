@@ -0,0 +1,112 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+var (
+	ErrCopyUnavailable     = errors.New("copy is not available for loan")
+	ErrNoCopyAvailable     = errors.New("no copy of this book is available")
+	ErrLoanLimitReached    = errors.New("member has reached the loan limit")
+	ErrMemberInactive      = errors.New("member is not active")
+	ErrLoanReturned        = errors.New("loan has already been returned")
+	ErrRenewalLimitReached = errors.New("loan has reached the renewal limit")
+	ErrFinesOutstanding    = errors.New("member has outstanding fines above the checkout limit")
+	ErrLoansActive         = errors.New("copies are still on loan, return them first")
+	ErrLoansFined          = errors.New("loans with fines are on record, they are kept for the fines ledger")
+)
+
+// LoanPolicy holds the lending rules of a library
+type LoanPolicy struct {
+	LoanDays       int // days until a new or renewed loan is due
+	MaxLoans       int // open loans a member may hold at once
+	MaxRenewals    int // renewals allowed per loan
+	HoldPickupDays int // days a copy set aside for a hold waits for pickup
+
+	FineDailyCents     int64 // fine per full day overdue
+	FineGraceDays      int   // days overdue before fines start
+	FineCapCents       int64 // most a single loan can be fined, 0 for no cap
+	FineBlockThreshold int64 // balance above which checkouts are refused
+}
+
+func DefaultLoanPolicy() LoanPolicy {
+	return LoanPolicy{
+		LoanDays:           14,
+		MaxLoans:           5,
+		MaxRenewals:        2,
+		HoldPickupDays:     3,
+		FineDailyCents:     25,
+		FineGraceDays:      2,
+		FineCapCents:       1000,
+		FineBlockThreshold: 500,
+	}
+}
+
+// FineFor is the fine of a loan due at due that is still out, or was
+// returned, at at. Only full days past the grace period are charged.
+func (p LoanPolicy) FineFor(due, at time.Time) int64 {
+	days := int64(at.Sub(due)/(24*time.Hour)) - int64(p.FineGraceDays)
+	if days <= 0 {
+		return 0
+	}
+
+	fine := days * p.FineDailyCents
+	if p.FineCapCents > 0 && fine > p.FineCapCents {
+		fine = p.FineCapCents
+	}
+	return fine
+}
+
+// Loan is a copy lent to a member, open until ReturnedAt is set
+type Loan struct {
+	ID         int64      `json:"id" gorm:"column:id"`
+	TenantID   int64      `json:"-" gorm:"column:tenant_id;index"`
+	CopyID     int64      `json:"copy_id" gorm:"column:copy_id;index"`
+	BookID     int64      `json:"book_id" gorm:"column:book_id;index"`
+	MemberID   int64      `json:"member_id" gorm:"column:member_id;index"`
+	BorrowedAt time.Time  `json:"borrowed_at" gorm:"column:borrowed_at"`
+	DueAt      time.Time  `json:"due_at" gorm:"column:due_at"`
+	ReturnedAt *time.Time `json:"returned_at" gorm:"column:returned_at"`
+	Renewals   int        `json:"renewals" gorm:"column:renewals;not null;default:0"`
+	Overdue    bool       `json:"overdue" gorm:"column:overdue;not null;default:false;index"`
+	FineCents  int64      `json:"fine_cents" gorm:"column:fine_cents;not null;default:0"`
+	// CarriedFineCents is the fine charged for the loan periods before the
+	// last renewal, lateness since then is charged on top of it
+	CarriedFineCents int64     `json:"-" gorm:"column:carried_fine_cents;not null;default:0"`
+	Copy             *Copy     `json:"copy,omitempty" gorm:"foreignKey:CopyID"`
+	Member           *Member   `json:"member,omitempty" gorm:"foreignKey:MemberID"`
+	CreatedAt        time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+// CheckoutRequest lends a copy of a book, any available copy when
+// copy_id is left out
+type CheckoutRequest struct {
+	MemberID int64 `json:"member_id" example:"1"`
+	CopyID   int64 `json:"copy_id" example:"1"`
+}
+
+// LoanFilter narrows down loan listings
+type LoanFilter struct {
+	MemberID   int64
+	BookID     int64
+	ActiveOnly bool
+	DueBefore  *time.Time
+}
+
+func (m *Loan) TableName() string {
+	return "public.loans"
+}
+
+func (m Loan) IsActive() bool {
+	return m.ReturnedAt == nil
+}
+
+func (e CheckoutRequest) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.MemberID, validation.Required),
+		validation.Field(&e.CopyID, validation.Min(int64(0))))
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	MemberStatusActive    = "active"
+	MemberStatusSuspended = "suspended"
+)
+
+var emailRegex = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// Member is a patron allowed to borrow copies
+type Member struct {
+	ID        int64     `json:"id" gorm:"column:id"`
+	TenantID  int64     `json:"-" gorm:"column:tenant_id;uniqueIndex:idx_members_tenant_email"`
+	Name      string    `json:"name" gorm:"column:name"`
+	Email     string    `json:"email" gorm:"column:email;uniqueIndex:idx_members_tenant_email"`
+	Phone     string    `json:"phone" gorm:"column:phone;not null;default:''"`
+	Status    string    `json:"status" gorm:"column:status;not null;default:'active'"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+type MemberRequest struct {
+	Name   string `json:"name" example:"Jane Doe"`
+	Email  string `json:"email" example:"jane@example.com"`
+	Phone  string `json:"phone" example:"+1 555 0100"`
+	Status string `json:"status" example:"active" enums:"active,suspended"`
+}
+
+func (m *Member) TableName() string {
+	return "public.members"
+}
+
+func (m Member) IsActive() bool {
+	return m.Status == MemberStatusActive
+}
+
+func (e Member) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Name, validation.Required, validation.Length(2, 100)),
+		validation.Field(&e.Email, validation.Required, validation.Length(3, 254), validation.Match(emailRegex)),
+		validation.Field(&e.Phone, validation.Length(0, 30)),
+		validation.Field(&e.Status, validation.In(MemberStatusActive, MemberStatusSuspended)))
+}
+
+// Normalize lower cases the email so it stays unique regardless of case
+func (m *Member) Normalize() {
+	m.Email = strings.ToLower(strings.TrimSpace(m.Email))
+	m.Status = strings.ToLower(strings.TrimSpace(m.Status))
+	if m.Status == "" {
+		m.Status = MemberStatusActive
+	}
+}
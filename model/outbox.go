@@ -0,0 +1,38 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+// OutboxEvent is a domain event written in the same transaction as the
+// change it describes, the relay publishes it afterwards. ids grow with
+// every write, so they order the events of a book.
+type OutboxEvent struct {
+	ID            int64      `json:"id" gorm:"column:id;index:idx_outbox_events_pending,where:published_at IS NULL;index:idx_outbox_events_published,priority:2"`
+	TenantID      int64      `json:"tenant_id" gorm:"column:tenant_id;index"`
+	AggregateType string     `json:"aggregate_type" gorm:"column:aggregate_type"`
+	AggregateID   int64      `json:"aggregate_id" gorm:"column:aggregate_id"`
+	Type          string     `json:"type" gorm:"column:type"`
+	Payload       string     `json:"payload" gorm:"column:payload;type:text"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"column:created_at"`
+	PublishedAt   *time.Time `json:"published_at" gorm:"column:published_at;index:idx_outbox_events_published,priority:1"`
+}
+
+// OutboxCursor is a place in the order the relay published the events in
+type OutboxCursor struct {
+	PublishedAt time.Time
+	ID          int64
+}
+
+// AggregateBook is the aggregate type of book events
+const AggregateBook = "book"
+
+func (m *OutboxEvent) TableName() string {
+	return "public.outbox_events"
+}
+
+// Key identifies the aggregate, events sharing a key are published in order
+func (m OutboxEvent) Key() string {
+	return m.AggregateType + ":" + strconv.FormatInt(m.AggregateID, 10)
+}
@@ -0,0 +1,22 @@
+package model
+
+const (
+	RoleAdmin = "admin"
+)
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	Subject string `json:"sub"`
+	Tenant  string `json:"tenant"`
+	Role    string `json:"role"`
+}
+
+func (p Principal) IsAdmin() bool {
+	return p.Role == RoleAdmin
+}
+
+// IsPlatformAdmin is an admin bound to no tenant, the one who runs every
+// library rather than one of them
+func (p Principal) IsPlatformAdmin() bool {
+	return p.IsAdmin() && p.Tenant == ""
+}
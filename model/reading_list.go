@@ -0,0 +1,96 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	ListKindWantToRead       = "want_to_read"
+	ListKindCurrentlyReading = "currently_reading"
+	ListKindCustom           = "custom"
+)
+
+const (
+	ListVisibilityPrivate = "private"
+	ListVisibilityPublic  = "public"
+)
+
+var ErrBuiltInList = errors.New("built-in lists cannot be deleted")
+
+// DefaultLists are the shelves every reader starts with
+var DefaultLists = []ReadingList{
+	{Name: "Want to read", Kind: ListKindWantToRead, Visibility: ListVisibilityPrivate},
+	{Name: "Currently reading", Kind: ListKindCurrentlyReading, Visibility: ListVisibilityPrivate},
+}
+
+// ReadingList is a reader's ordered shelf of books. Public lists can be
+// read by anyone holding the share token.
+type ReadingList struct {
+	ID         int64              `json:"id" gorm:"column:id"`
+	TenantID   int64              `json:"-" gorm:"column:tenant_id;index;uniqueIndex:idx_reading_lists_owner_kind,where:kind <> 'custom'"`
+	Owner      string             `json:"owner" gorm:"column:owner;index;uniqueIndex:idx_reading_lists_owner_kind,where:kind <> 'custom'"`
+	Name       string             `json:"name" gorm:"column:name"`
+	Kind       string             `json:"kind" gorm:"column:kind;not null;default:'custom';uniqueIndex:idx_reading_lists_owner_kind,where:kind <> 'custom'"`
+	Visibility string             `json:"visibility" gorm:"column:visibility;not null;default:'private'"`
+	ShareToken string             `json:"share_token" gorm:"column:share_token;uniqueIndex"`
+	Entries    []ReadingListEntry `json:"entries" gorm:"foreignKey:ListID"`
+	CreatedAt  time.Time          `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt  time.Time          `json:"updated_at" gorm:"column:updated_at"`
+}
+
+// ReadingListEntry places a book on a list, positions run from 1 without
+// gaps
+type ReadingListEntry struct {
+	ID       int64     `json:"id" gorm:"column:id"`
+	TenantID int64     `json:"-" gorm:"column:tenant_id;index"`
+	ListID   int64     `json:"list_id" gorm:"column:list_id;uniqueIndex:idx_reading_list_entries_list_book"`
+	BookID   int64     `json:"book_id" gorm:"column:book_id;index;uniqueIndex:idx_reading_list_entries_list_book"`
+	Position int       `json:"position" gorm:"column:position"`
+	Note     string    `json:"note" gorm:"column:note;not null;default:''"`
+	AddedAt  time.Time `json:"added_at" gorm:"column:added_at"`
+	Book     *Book     `json:"book,omitempty" gorm:"foreignKey:BookID"`
+}
+
+type ReadingListRequest struct {
+	Name       string `json:"name" example:"Summer holiday"`
+	Visibility string `json:"visibility" example:"public" enums:"private,public"`
+}
+
+type ReadingListEntryRequest struct {
+	BookID   int64  `json:"book_id" example:"1"`
+	Position int    `json:"position" example:"1"`
+	Note     string `json:"note" example:"Recommended by Sam"`
+}
+
+type ReadingListMoveRequest struct {
+	Position int `json:"position" example:"1"`
+}
+
+func (m *ReadingList) TableName() string {
+	return "public.reading_lists"
+}
+
+func (m *ReadingListEntry) TableName() string {
+	return "public.reading_list_entries"
+}
+
+func (e ReadingList) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&e.Visibility, validation.In(ListVisibilityPrivate, ListVisibilityPublic)))
+}
+
+func (e ReadingListEntry) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.BookID, validation.Required),
+		validation.Field(&e.Position, validation.Min(0)),
+		validation.Field(&e.Note, validation.Length(0, 500)))
+}
+
+func (e ReadingListMoveRequest) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Position, validation.Required, validation.Min(1)))
+}
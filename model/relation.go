@@ -0,0 +1,78 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	RelationSequelOf      = "sequel_of"
+	RelationPrequelOf     = "prequel_of"
+	RelationTranslationOf = "translation_of"
+	RelationAdaptationOf  = "adaptation_of"
+)
+
+// inverseRelations name a relation as seen from the related book
+var inverseRelations = map[string]string{
+	RelationSequelOf:      "has_sequel",
+	RelationPrequelOf:     "has_prequel",
+	RelationTranslationOf: "has_translation",
+	RelationAdaptationOf:  "has_adaptation",
+}
+
+// oppositeRelations say the same thing from the other book, a sequel of a
+// book makes the book its prequel
+var oppositeRelations = map[string]string{
+	RelationSequelOf:  RelationPrequelOf,
+	RelationPrequelOf: RelationSequelOf,
+}
+
+var ErrRelationCycle = errors.New("relation would lead the book back to itself")
+
+// BookRelation says the book is, for instance, a sequel of the related
+// book. Relations of one type, counting their opposites, never form a cycle.
+type BookRelation struct {
+	ID        int64     `json:"id" gorm:"column:id"`
+	TenantID  int64     `json:"-" gorm:"column:tenant_id;index"`
+	BookID    int64     `json:"book_id" gorm:"column:book_id;uniqueIndex:idx_book_relations_book_related_type"`
+	RelatedID int64     `json:"related_id" gorm:"column:related_id;index;uniqueIndex:idx_book_relations_book_related_type"`
+	Type      string    `json:"type" gorm:"column:type;uniqueIndex:idx_book_relations_book_related_type"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	Book      *Book     `json:"-" gorm:"foreignKey:BookID"`
+	Related   *Book     `json:"-" gorm:"foreignKey:RelatedID"`
+}
+
+// RelatedWork is a relation as listed on one of its two books
+type RelatedWork struct {
+	RelationID int64     `json:"relation_id"`
+	Type       string    `json:"type"`
+	Book       *BookLink `json:"book"`
+}
+
+type BookRelationRequest struct {
+	RelatedID int64  `json:"related_id" example:"2"`
+	Type      string `json:"type" example:"sequel_of" enums:"sequel_of,prequel_of,translation_of,adaptation_of"`
+}
+
+func (m *BookRelation) TableName() string {
+	return "public.book_relations"
+}
+
+// InverseRelation names the relation type from the related book's side
+func InverseRelation(relationType string) string {
+	return inverseRelations[relationType]
+}
+
+// OppositeRelation is the type saying the same as the relation type from the
+// related book, if any
+func OppositeRelation(relationType string) string {
+	return oppositeRelations[relationType]
+}
+
+func (e BookRelation) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.RelatedID, validation.Required),
+		validation.Field(&e.Type, validation.Required, validation.In(RelationSequelOf, RelationPrequelOf, RelationTranslationOf, RelationAdaptationOf)))
+}
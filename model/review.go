@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+const (
+	BookSortRating = "rating"
+)
+
+// Review is a reader's rating of a book. Only approved reviews count
+// towards the rating of the book.
+type Review struct {
+	ID        int64     `json:"id" gorm:"column:id"`
+	TenantID  int64     `json:"-" gorm:"column:tenant_id;uniqueIndex:idx_reviews_tenant_book_reviewer"`
+	BookID    int64     `json:"book_id" gorm:"column:book_id;index;uniqueIndex:idx_reviews_tenant_book_reviewer"`
+	Reviewer  string    `json:"reviewer" gorm:"column:reviewer;uniqueIndex:idx_reviews_tenant_book_reviewer"`
+	Rating    int       `json:"rating" gorm:"column:rating"`
+	Text      string    `json:"text" gorm:"column:text;not null;default:''"`
+	Status    string    `json:"status" gorm:"column:status;not null;default:'approved';index"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+type ReviewRequest struct {
+	Rating int    `json:"rating" example:"4"`
+	Text   string `json:"text" example:"A sharp, sad book about wanting more."`
+}
+
+type ReviewModerationRequest struct {
+	Status string `json:"status" example:"rejected" enums:"approved,rejected"`
+}
+
+// ReviewFilter narrows down review listings, an empty status lists every
+// review
+type ReviewFilter struct {
+	BookID int64
+	Status string
+}
+
+func (m *Review) TableName() string {
+	return "public.reviews"
+}
+
+// Counts reports whether the review is part of the book's rating
+func (m Review) Counts() bool {
+	return m.Status == ReviewStatusApproved
+}
+
+func (e Review) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Rating, validation.Required, validation.Min(1), validation.Max(5)),
+		validation.Field(&e.Text, validation.Length(0, 5000)))
+}
+
+func (e ReviewModerationRequest) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Status, validation.Required, validation.In(ReviewStatusApproved, ReviewStatusRejected)))
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"strconv"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Series is an ordered run of books, such as the volumes of a trilogy
+type Series struct {
+	ID          int64         `json:"id" gorm:"column:id"`
+	TenantID    int64         `json:"-" gorm:"column:tenant_id;uniqueIndex:idx_series_tenant_name"`
+	Name        string        `json:"name" gorm:"column:name;uniqueIndex:idx_series_tenant_name"`
+	Description string        `json:"description" gorm:"column:description;not null;default:''"`
+	Entries     []SeriesEntry `json:"books" gorm:"foreignKey:SeriesID"`
+	CreatedAt   time.Time     `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt   time.Time     `json:"updated_at" gorm:"column:updated_at"`
+}
+
+// SeriesEntry places a book in a series, positions run from 1 without gaps
+type SeriesEntry struct {
+	ID       int64 `json:"id" gorm:"column:id"`
+	TenantID int64 `json:"-" gorm:"column:tenant_id;index"`
+	SeriesID int64 `json:"series_id" gorm:"column:series_id;uniqueIndex:idx_series_entries_series_book"`
+	BookID   int64 `json:"book_id" gorm:"column:book_id;index;uniqueIndex:idx_series_entries_series_book"`
+	Position int   `json:"position" gorm:"column:position"`
+	Book     *Book `json:"book,omitempty" gorm:"foreignKey:BookID"`
+}
+
+// BookLink points at another book
+type BookLink struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+	Href  string `json:"href"`
+}
+
+// BookSeries is where a book stands in one of its series
+type BookSeries struct {
+	SeriesID int64     `json:"series_id"`
+	Name     string    `json:"name"`
+	Position int       `json:"position"`
+	Total    int       `json:"total"`
+	Previous *BookLink `json:"previous,omitempty"`
+	Next     *BookLink `json:"next,omitempty"`
+}
+
+type SeriesRequest struct {
+	Name        string `json:"name" example:"The Lord of the Rings"`
+	Description string `json:"description" example:"Epic high fantasy in three volumes"`
+}
+
+type SeriesEntryRequest struct {
+	BookID   int64 `json:"book_id" example:"1"`
+	Position int   `json:"position" example:"1"`
+}
+
+type SeriesMoveRequest struct {
+	Position int `json:"position" example:"1"`
+}
+
+func (m *Series) TableName() string {
+	return "public.series"
+}
+
+func (m *SeriesEntry) TableName() string {
+	return "public.series_entries"
+}
+
+// NewBookLink links to the book's own resource
+func NewBookLink(id int64, title string) *BookLink {
+	return &BookLink{ID: id, Title: title, Href: "/books/" + strconv.FormatInt(id, 10)}
+}
+
+func (e Series) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&e.Description, validation.Length(0, 1000)))
+}
+
+func (e SeriesEntry) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.BookID, validation.Required),
+		validation.Field(&e.Position, validation.Min(0)))
+}
+
+func (e SeriesMoveRequest) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Position, validation.Required, validation.Min(1)))
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+)
+
+// leadingArticles are dropped from titles before they are compared, so
+// "The Great Gatsby" and "Great Gatsby" match
+var leadingArticles = map[string]bool{"the": true, "a": true, "an": true}
+
+// NormalizeTitle folds case, punctuation, spacing and a leading article
+func NormalizeTitle(title string) string {
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(fields) > 1 && leadingArticles[fields[0]] {
+		fields = fields[1:]
+	}
+	return strings.Join(fields, " ")
+}
+
+// Trigrams returns the set of trigrams of a normalized string the way
+// pg_trgm builds them, every word padded with two spaces in front and one
+// behind
+func Trigrams(s string) map[string]bool {
+	out := map[string]bool{}
+	for _, word := range strings.Fields(s) {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			out[string(runes[i:i+3])] = true
+		}
+	}
+	return out
+}
+
+// TrigramSimilarity is the share of trigrams two strings have in common,
+// from 0 for nothing in common to 1 for the same trigrams
+func TrigramSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for t := range a {
+		if b[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
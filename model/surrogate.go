@@ -0,0 +1,14 @@
+package model
+
+import "strconv"
+
+// BookSurrogateKey tags the cached responses showing the book, book ids are
+// unique across tenants
+func BookSurrogateKey(id int64) string {
+	return "book-" + strconv.FormatInt(id, 10)
+}
+
+// BookListSurrogateKey tags the cached book lists of the tenant
+func BookListSurrogateKey(tenantID int64) string {
+	return "books-" + strconv.FormatInt(tenantID, 10)
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type Tag struct {
+	ID        int64     `json:"id" gorm:"column:id"`
+	TenantID  int64     `json:"-" gorm:"column:tenant_id;uniqueIndex:idx_tags_tenant_name"`
+	Name      string    `json:"name" gorm:"column:name;uniqueIndex:idx_tags_tenant_name"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+type TagRequest struct {
+	Name string `json:"name" example:"jazz-age"`
+}
+
+// TagUsage is a tag with the number of books carrying it
+type TagUsage struct {
+	Tag       `gorm:"embedded"`
+	BookCount int64 `json:"book_count" gorm:"column:book_count"`
+}
+
+// BookTag is the join table between books and tags
+type BookTag struct {
+	BookID int64 `gorm:"column:book_id;primaryKey"`
+	TagID  int64 `gorm:"column:tag_id;primaryKey;index"`
+}
+
+func (m *Tag) TableName() string {
+	return "public.tags"
+}
+
+func (m *BookTag) TableName() string {
+	return "public.book_tags"
+}
+
+func (e Tag) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Name, validation.Required, validation.Length(1, 50)))
+}
+
+// Validate lets ozzo validate each tag assigned to a book
+func (e Tag) Validate() error {
+	return e.Validation()
+}
+
+// NormalizeTagName keeps tags case and whitespace insensitive
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
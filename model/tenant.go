@@ -0,0 +1,49 @@
+package model
+
+import (
+	"regexp"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	DefaultTenantSlug = "default"
+
+	TenantStatusActive    = "active"
+	TenantStatusSuspended = "suspended"
+)
+
+// slugs double as subdomains, so keep them to a single dns label
+var tenantSlugRegex = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?$`)
+
+type Tenant struct {
+	ID     int64  `json:"id" gorm:"column:id"`
+	Slug   string `json:"slug" gorm:"column:slug;uniqueIndex"`
+	Name   string `json:"name" gorm:"column:name"`
+	Status string `json:"status" gorm:"column:status;default:active"`
+	// BooksDeletedAt dates the last book deleted, lists it left behind
+	// change with it
+	BooksDeletedAt *time.Time `json:"-" gorm:"column:books_deleted_at"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"column:updated_at"`
+}
+
+type TenantRequest struct {
+	Slug string `json:"slug" example:"city-library"`
+	Name string `json:"name" example:"City Library"`
+}
+
+func (m *Tenant) TableName() string {
+	return "public.tenants"
+}
+
+func (m Tenant) IsActive() bool {
+	return m.Status == TenantStatusActive
+}
+
+func (e Tenant) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Slug, validation.Required, validation.Length(3, 63), validation.Match(tenantSlugRegex)),
+		validation.Field(&e.Name, validation.Required, validation.Length(3, 100)))
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// BookTranslation is the title and description of a book in another
+// language, one per BCP 47 language tag
+type BookTranslation struct {
+	ID          int64     `json:"id" gorm:"column:id"`
+	TenantID    int64     `json:"-" gorm:"column:tenant_id;index"`
+	BookID      int64     `json:"book_id" gorm:"column:book_id;uniqueIndex:idx_book_translations_book_language"`
+	Language    string    `json:"language" gorm:"column:language;uniqueIndex:idx_book_translations_book_language"`
+	Title       string    `json:"title" gorm:"column:title"`
+	Subtitle    string    `json:"subtitle" gorm:"column:subtitle;not null;default:''"`
+	Description string    `json:"description" gorm:"column:description"`
+	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+type BookTranslationRequest struct {
+	Title       string `json:"title" example:"Der große Gatsby"`
+	Subtitle    string `json:"subtitle" example:"Roman"`
+	Description string `json:"description" example:"Ein Roman über die Dekadenz der Jazz-Ära"`
+}
+
+func (m *BookTranslation) TableName() string {
+	return "public.book_translations"
+}
+
+func (e BookTranslation) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Title, validation.Required, validation.Length(3, 100)),
+		validation.Field(&e.Subtitle, validation.Length(0, 200)),
+		validation.Field(&e.Description, validation.Required, validation.Length(3, 1000)))
+}
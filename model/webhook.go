@@ -0,0 +1,106 @@
+package model
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusFailed    = "failed"
+)
+
+// WebhookMaxAttempts is how often a delivery is tried before it is given up
+const WebhookMaxAttempts = 8
+
+// WebhookEvents are the events a webhook can subscribe to
+var WebhookEvents = []interface{}{BookCreated, BookUpdated, BookDeleted}
+
+// Webhook posts the book events it subscribes to to its URL, signed with
+// its secret
+type Webhook struct {
+	ID        int64     `json:"id" gorm:"column:id"`
+	TenantID  int64     `json:"-" gorm:"column:tenant_id;index"`
+	URL       string    `json:"url" gorm:"column:url"`
+	Events    []string  `json:"events" gorm:"column:events;serializer:json"`
+	Secret    string    `json:"secret,omitempty" gorm:"column:secret"`
+	Active    bool      `json:"active" gorm:"column:active;not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+// WebhookDelivery is one event on its way to a webhook, the log of its
+// last attempt included. redeliveries are deliveries of their own.
+type WebhookDelivery struct {
+	ID             int64      `json:"id" gorm:"column:id"`
+	TenantID       int64      `json:"-" gorm:"column:tenant_id;index"`
+	WebhookID      int64      `json:"webhook_id" gorm:"column:webhook_id;index"`
+	EventID        string     `json:"event_id" gorm:"column:event_id;index"`
+	Event          string     `json:"event" gorm:"column:event"`
+	Payload        string     `json:"payload" gorm:"column:payload;type:text"`
+	Status         string     `json:"status" gorm:"column:status;not null;default:'pending';index:idx_webhook_deliveries_due,priority:1"`
+	Attempts       int        `json:"attempts" gorm:"column:attempts;not null;default:0"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" gorm:"column:next_attempt_at;index:idx_webhook_deliveries_due,priority:2"`
+	LastStatusCode int        `json:"last_status_code" gorm:"column:last_status_code;not null;default:0"`
+	LastError      string     `json:"last_error" gorm:"column:last_error;not null;default:''"`
+	DeliveredAt    *time.Time `json:"delivered_at" gorm:"column:delivered_at"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"column:updated_at"`
+	Webhook        *Webhook   `json:"-" gorm:"foreignKey:WebhookID"`
+}
+
+type WebhookRequest struct {
+	URL    string   `json:"url" example:"https://example.com/hooks/books"`
+	Events []string `json:"events" example:"book.created,book.updated,book.deleted"`
+	// Secret signs the deliveries, one is generated when left empty
+	Secret string `json:"secret" example:"s3cr3t-shared-with-the-receiver"`
+	Active *bool  `json:"active" example:"true"`
+}
+
+func (m *Webhook) TableName() string {
+	return "public.webhooks"
+}
+
+func (m *WebhookDelivery) TableName() string {
+	return "public.webhook_deliveries"
+}
+
+// Subscribes tells whether the webhook wants the event
+func (m Webhook) Subscribes(event string) bool {
+	for _, e := range m.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Webhook) Validation() error { // custom validation
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.URL, validation.Required, validation.Length(1, 2000), validation.By(isWebhookURL)),
+		validation.Field(&e.Events, validation.Required, validation.Each(validation.In(WebhookEvents...))),
+		validation.Field(&e.Secret, validation.Length(16, 200)))
+}
+
+func isWebhookURL(value interface{}) error {
+	s, _ := value.(string)
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("must be an absolute http or https URL")
+	}
+	return nil
+}
+
+// WebhookPayload is the body of a delivery, id stays the same across the
+// retries and redeliveries of one event so receivers can drop repeats
+type WebhookPayload struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	BookID     int64     `json:"book_id"`
+	Book       *Book     `json:"book,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,58 @@
+package outbox
+
+import (
+	"ninth-learn/model"
+	"sync"
+)
+
+// FollowStore is the outbox table as the relay left it
+type FollowStore interface {
+	OutboxHead() (res model.OutboxCursor, err error)
+	GetPublishedOutbox(after model.OutboxCursor, limit int) ([]model.OutboxEvent, error)
+}
+
+// Follower hands the events the relay published, on whichever instance it
+// ran, to this process in the order they were published. it picks up after
+// the events published before its first run
+type Follower struct {
+	store  FollowStore
+	handle func(m Message)
+
+	mu     sync.Mutex
+	cursor *model.OutboxCursor
+}
+
+func NewFollower(store FollowStore, handle func(m Message)) *Follower {
+	return &Follower{store: store, handle: handle}
+}
+
+// Run hands over the events published since the last run, batch after
+// batch, until none are left
+func (f *Follower) Run() (followed int64, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.cursor == nil {
+		head, err := f.store.OutboxHead()
+		if err != nil {
+			return 0, err
+		}
+		f.cursor = &head
+		return 0, nil
+	}
+
+	for {
+		events, err := f.store.GetPublishedOutbox(*f.cursor, relayBatch)
+		if err != nil {
+			return followed, err
+		}
+		for _, e := range events {
+			f.handle(NewMessage(e))
+			f.cursor = &model.OutboxCursor{PublishedAt: *e.PublishedAt, ID: e.ID}
+			followed++
+		}
+		if len(events) < relayBatch {
+			return followed, nil
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package outbox
+
+import (
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func publishedEvent(id int64, at time.Time) model.OutboxEvent {
+	e := bookEvent(id, id)
+	e.PublishedAt = &at
+	return e
+}
+
+func Test_Follower_Run(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	outboxRepo := mocks.NewMockOutboxRepo(mockCtrl)
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	head := model.OutboxCursor{PublishedAt: start, ID: 4}
+	full := make([]model.OutboxEvent, relayBatch)
+	for i := range full {
+		full[i] = publishedEvent(int64(i+5), start.Add(time.Second))
+	}
+	last := model.OutboxCursor{PublishedAt: start.Add(time.Second), ID: relayBatch + 4}
+	gomock.InOrder(
+		outboxRepo.EXPECT().OutboxHead().Return(head, nil),
+		outboxRepo.EXPECT().GetPublishedOutbox(head, relayBatch).Return(full, nil),
+		outboxRepo.EXPECT().GetPublishedOutbox(last, relayBatch).Return([]model.OutboxEvent{publishedEvent(2, start.Add(2*time.Second))}, nil),
+		outboxRepo.EXPECT().GetPublishedOutbox(model.OutboxCursor{PublishedAt: start.Add(2 * time.Second), ID: 2}, relayBatch).Return(nil, nil),
+	)
+
+	var handled []int64
+	f := NewFollower(outboxRepo, func(m Message) {
+		handled = append(handled, m.ID)
+	})
+
+	// the first run finds the place to start from
+	followed, err := f.Run()
+	assert.NoError(t, err)
+	assert.Zero(t, followed)
+
+	// events come in the order they were published, whatever their ids
+	followed, err = f.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(relayBatch+1), followed)
+	assert.Equal(t, int64(5), handled[0])
+	assert.Equal(t, int64(2), handled[len(handled)-1])
+
+	followed, err = f.Run()
+	assert.NoError(t, err)
+	assert.Zero(t, followed)
+}
@@ -0,0 +1,90 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// KafkaPublisher produces to a Kafka topic through a REST proxy speaking the
+// Confluent v2 API, such as the Confluent REST Proxy or Redpanda's
+// pandaproxy. records are keyed by aggregate, so the events of a book land
+// on one partition in order
+type KafkaPublisher struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewKafkaPublisher(proxyURL, topic string) *KafkaPublisher {
+	return &KafkaPublisher{
+		endpoint: strings.TrimSuffix(proxyURL, "/") + "/topics/" + url.PathEscape(topic),
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type kafkaRecord struct {
+	Key   string  `json:"key"`
+	Value Message `json:"value"`
+}
+
+type kafkaOffset struct {
+	Partition int     `json:"partition"`
+	Offset    int64   `json:"offset"`
+	ErrorCode *int    `json:"error_code"`
+	Error     *string `json:"error"`
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, m Message) error {
+	body, err := json.Marshal(struct {
+		Records []kafkaRecord `json:"records"`
+	}{Records: []kafkaRecord{{Key: m.Key, Value: m}}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 4<<10))
+		return fmt.Errorf("kafka proxy answered %s: %s", res.Status, strings.TrimSpace(string(msg)))
+	}
+
+	// the proxy answers 200 even when a record was refused
+	produced := struct {
+		Offsets []kafkaOffset `json:"offsets"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(&produced); err != nil {
+		return err
+	}
+	for _, offset := range produced.Offsets {
+		if offset.ErrorCode != nil {
+			msg := ""
+			if offset.Error != nil {
+				msg = *offset.Error
+			}
+			return fmt.Errorf("kafka refused the record with code %d: %s", *offset.ErrorCode, msg)
+		}
+	}
+	return nil
+}
+
+func (p *KafkaPublisher) Close() error {
+	return nil
+}
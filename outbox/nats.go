@@ -0,0 +1,187 @@
+package outbox
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// NATSPublisher publishes each message to <subject>.<event type> over the
+// NATS client protocol. a PING after every message confirms the server took
+// it, and a Nats-Msg-Id header lets a JetStream stream on the subjects drop
+// the repeats
+type NATSPublisher struct {
+	addr    string
+	user    string
+	pass    string
+	token   string
+	subject string
+
+	mu   sync.Mutex
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// NewNATSPublisher takes a nats://[user:password@]host[:port] URL, a lone
+// user name is sent as a token. nothing is dialled before the first publish
+func NewNATSPublisher(rawURL, subject string) (*NATSPublisher, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "nats" || u.Hostname() == "" {
+		return nil, fmt.Errorf("unsupported NATS URL %q", rawURL)
+	}
+
+	p := &NATSPublisher{addr: u.Host, subject: subject}
+	if u.Port() == "" {
+		p.addr = net.JoinHostPort(u.Hostname(), "4222")
+	}
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			p.user, p.pass = u.User.Username(), pass
+		} else {
+			p.token = u.User.Username()
+		}
+	}
+	return p, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, m Message) error {
+	body, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	header := "NATS/1.0\r\nNats-Msg-Id: " + strconv.FormatInt(m.ID, 10) + "\r\n\r\n"
+
+	var frame bytes.Buffer
+	fmt.Fprintf(&frame, "HPUB %s.%s %d %d\r\n", p.subject, m.Type, len(header), len(header)+len(body))
+	frame.WriteString(header)
+	frame.Write(body)
+	frame.WriteString("\r\nPING\r\n")
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return err
+		}
+	}
+	p.setDeadline(ctx)
+
+	if _, err := p.conn.Write(frame.Bytes()); err != nil {
+		p.reset()
+		return err
+	}
+	if err := p.awaitPong(); err != nil {
+		p.reset()
+		return err
+	}
+	return nil
+}
+
+func (p *NATSPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.reset()
+	return nil
+}
+
+func (p *NATSPublisher) connect(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", p.addr)
+	if err != nil {
+		return err
+	}
+	p.conn, p.r = conn, bufio.NewReader(conn)
+	p.setDeadline(ctx)
+
+	line, err := p.r.ReadString('\n')
+	if err != nil {
+		p.reset()
+		return err
+	}
+	info := struct {
+		TLSRequired bool `json:"tls_required"`
+		Headers     bool `json:"headers"`
+	}{}
+	if !strings.HasPrefix(line, "INFO ") || json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), &info) != nil {
+		p.reset()
+		return errors.New("nats: unexpected greeting")
+	}
+	if info.TLSRequired || !info.Headers {
+		p.reset()
+		return errors.New("nats: server needs TLS or lacks header support")
+	}
+
+	options, err := json.Marshal(map[string]interface{}{
+		"verbose":    false,
+		"pedantic":   false,
+		"headers":    true,
+		"protocol":   1,
+		"lang":       "go",
+		"version":    "1.0.0",
+		"name":       "ninth-learn-outbox",
+		"user":       p.user,
+		"pass":       p.pass,
+		"auth_token": p.token,
+	})
+	if err != nil {
+		p.reset()
+		return err
+	}
+	if _, err := p.conn.Write([]byte("CONNECT " + string(options) + "\r\nPING\r\n")); err != nil {
+		p.reset()
+		return err
+	}
+	if err := p.awaitPong(); err != nil {
+		p.reset()
+		return err
+	}
+	return nil
+}
+
+// awaitPong reads up to the PONG answering our PING, a -ERR on the way
+// means the server refused what came before it
+func (p *NATSPublisher) awaitPong() error {
+	for {
+		line, err := p.r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+
+		switch line = strings.TrimRight(line, "\r\n"); {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("nats: %s", strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")), "'"))
+		}
+		// +OK and INFO updates need no answer
+	}
+}
+
+func (p *NATSPublisher) setDeadline(ctx context.Context) {
+	deadline, _ := ctx.Deadline()
+	p.conn.SetDeadline(deadline)
+}
+
+// reset drops the connection, the next publish dials again
+func (p *NATSPublisher) reset() {
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	p.conn, p.r = nil, nil
+}
@@ -0,0 +1,111 @@
+// Package outbox relays the events written to the outbox table to a
+// message broker, at least once and in order per aggregate.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"ninth-learn/model"
+	"os"
+	"sync"
+	"time"
+)
+
+// Message is an outbox event on the wire. consumers may see a message more
+// than once and should drop repeats by id
+type Message struct {
+	ID         int64           `json:"id"`
+	Type       string          `json:"type"`
+	Key        string          `json:"key"`
+	TenantID   int64           `json:"tenant_id"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// EventPublisher hands messages to a broker, Publish returns once the
+// broker has taken the message
+type EventPublisher interface {
+	Publish(ctx context.Context, m Message) error
+	Close() error
+}
+
+// PublisherFunc publishes within the process, there is nothing to close
+type PublisherFunc func(ctx context.Context, m Message) error
+
+func (f PublisherFunc) Publish(ctx context.Context, m Message) error {
+	return f(ctx, m)
+}
+
+func (f PublisherFunc) Close() error {
+	return nil
+}
+
+func NewMessage(e model.OutboxEvent) Message {
+	return Message{
+		ID:         e.ID,
+		Type:       e.Type,
+		Key:        e.Key(),
+		TenantID:   e.TenantID,
+		Payload:    json.RawMessage(e.Payload),
+		OccurredAt: e.CreatedAt,
+	}
+}
+
+// WriterPublisher writes messages as JSON lines
+type WriterPublisher struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{enc: json.NewEncoder(w)}
+}
+
+// NewStdoutPublisher prints the messages, handy to watch the events locally
+func NewStdoutPublisher() *WriterPublisher {
+	return NewWriterPublisher(os.Stdout)
+}
+
+func (p *WriterPublisher) Publish(ctx context.Context, m Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.enc.Encode(m)
+}
+
+func (p *WriterPublisher) Close() error {
+	return nil
+}
+
+// FilePublisher appends messages as JSON lines to a file, each synced to
+// disk before it counts as published
+type FilePublisher struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FilePublisher{file: file}, nil
+}
+
+func (p *FilePublisher) Publish(ctx context.Context, m Message) error {
+	line, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return p.file.Sync()
+}
+
+func (p *FilePublisher) Close() error {
+	return p.file.Close()
+}
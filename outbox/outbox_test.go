@@ -0,0 +1,191 @@
+package outbox
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func message(id int64) Message {
+	return Message{ID: id, Type: "book.created", Key: "book:" + strconv.FormatInt(id, 10), TenantID: 1, Payload: json.RawMessage(`{"book_id":1}`)}
+}
+
+func Test_WriterPublisher(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewWriterPublisher(&buf)
+
+	assert.NoError(t, p.Publish(context.Background(), message(1)))
+	assert.NoError(t, p.Publish(context.Background(), message(2)))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	got := Message{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &got))
+	assert.Equal(t, int64(2), got.ID)
+	assert.JSONEq(t, `{"book_id":1}`, string(got.Payload))
+}
+
+func Test_FilePublisher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	p, err := NewFilePublisher(path)
+	assert.NoError(t, err)
+	assert.NoError(t, p.Publish(context.Background(), message(1)))
+	assert.NoError(t, p.Close())
+
+	// a restart appends
+	p, err = NewFilePublisher(path)
+	assert.NoError(t, err)
+	assert.NoError(t, p.Publish(context.Background(), message(2)))
+	assert.NoError(t, p.Close())
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(data), "\n"))
+}
+
+// natsServer speaks just enough of the NATS protocol to take publishes,
+// subjects ending in .refused are answered with -ERR
+type natsServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	received map[string][]string
+	connects int
+}
+
+func newNATSServer(t *testing.T) *natsServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	s := &natsServer{listener: listener, received: map[string][]string{}}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *natsServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	fmt.Fprint(conn, "INFO {\"server_id\":\"test\",\"headers\":true}\r\n")
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "CONNECT":
+			s.mu.Lock()
+			s.connects++
+			s.mu.Unlock()
+		case "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		case "HPUB":
+			headerLen, _ := strconv.Atoi(fields[2])
+			total, _ := strconv.Atoi(fields[3])
+			frame := make([]byte, total+2)
+			if _, err := io.ReadFull(r, frame); err != nil {
+				return
+			}
+			if strings.HasSuffix(fields[1], ".refused") {
+				fmt.Fprint(conn, "-ERR 'Permissions Violation for Publish'\r\n")
+				return
+			}
+			s.mu.Lock()
+			s.received[fields[1]] = append(s.received[fields[1]], string(frame[:headerLen]), string(frame[headerLen:total]))
+			s.mu.Unlock()
+		}
+	}
+}
+
+func Test_NATSPublisher(t *testing.T) {
+	server := newNATSServer(t)
+	p, err := NewNATSPublisher("nats://"+server.listener.Addr().String(), "catalogue")
+	assert.NoError(t, err)
+	defer p.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	assert.NoError(t, p.Publish(ctx, message(7)))
+	server.mu.Lock()
+	got := server.received["catalogue.book.created"]
+	server.mu.Unlock()
+	assert.Len(t, got, 2)
+	assert.Contains(t, got[0], "Nats-Msg-Id: 7\r\n")
+	decoded := Message{}
+	assert.NoError(t, json.Unmarshal([]byte(got[1]), &decoded))
+	assert.Equal(t, "book:7", decoded.Key)
+
+	// the server drops the connection on refusal, the next publish dials again
+	refused := message(8)
+	refused.Type = "refused"
+	assert.EqualError(t, p.Publish(ctx, refused), "nats: Permissions Violation for Publish")
+	assert.NoError(t, p.Publish(ctx, message(9)))
+	server.mu.Lock()
+	assert.Equal(t, 2, server.connects)
+	server.mu.Unlock()
+
+	_, err = NewNATSPublisher("http://localhost:4222", "catalogue")
+	assert.Error(t, err)
+}
+
+func Test_KafkaPublisher(t *testing.T) {
+	var refuse bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/topics/catalogue.events", r.URL.Path)
+		assert.Equal(t, "application/vnd.kafka.json.v2+json", r.Header.Get("Content-Type"))
+
+		body := struct {
+			Records []struct {
+				Key   string  `json:"key"`
+				Value Message `json:"value"`
+			} `json:"records"`
+		}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Len(t, body.Records, 1)
+		assert.Equal(t, "book:3", body.Records[0].Key)
+		assert.Equal(t, int64(3), body.Records[0].Value.ID)
+
+		if refuse {
+			w.Write([]byte(`{"offsets":[{"partition":null,"offset":null,"error_code":50301,"error":"topic not found"}]}`))
+			return
+		}
+		w.Write([]byte(`{"offsets":[{"partition":0,"offset":12,"error_code":null,"error":null}]}`))
+	}))
+	defer server.Close()
+
+	p := NewKafkaPublisher(server.URL+"/", "catalogue.events")
+	assert.NoError(t, p.Publish(context.Background(), message(3)))
+
+	refuse = true
+	assert.EqualError(t, p.Publish(context.Background(), message(3)), "kafka refused the record with code 50301: topic not found")
+}
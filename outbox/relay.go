@@ -0,0 +1,93 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"ninth-learn/model"
+	"time"
+)
+
+const (
+	// relayBatch is how many events one round reads
+	relayBatch = 100
+	// publishTimeout bounds handing one message to the broker
+	publishTimeout = 10 * time.Second
+)
+
+// Store is the outbox table
+type Store interface {
+	RelayOutbox(limit int, publish func(events []model.OutboxEvent) (published []int64)) (relayed int64, err error)
+	PruneOutbox(before time.Time) (pruned int64, err error)
+}
+
+// Relay publishes the outbox in order to every publisher, the broker and
+// the webhooks of the books among them. an event counts as published once
+// all of them took it, one that fails holds back the later events of its
+// aggregate until it goes through, events of other aggregates carry on
+type Relay struct {
+	store      Store
+	publishers []EventPublisher
+}
+
+func NewRelay(store Store, publishers ...EventPublisher) *Relay {
+	return &Relay{store: store, publishers: publishers}
+}
+
+// Run publishes the pending events, batch after batch, until none are left
+// or a batch goes through only in part
+func (r *Relay) Run() (published int64, err error) {
+	for {
+		full := false
+		relayed, err := r.store.RelayOutbox(relayBatch, func(events []model.OutboxEvent) []int64 {
+			ids := r.publish(events)
+			full = len(events) == relayBatch && len(ids) == len(events)
+			return ids
+		})
+		published += relayed
+		if err != nil || !full {
+			return published, err
+		}
+	}
+}
+
+// Prune drops the events published longer ago than the age
+func (r *Relay) Prune(age time.Duration) (int64, error) {
+	return r.store.PruneOutbox(time.Now().Add(-age))
+}
+
+// publish returns the ids of the events the broker took
+func (r *Relay) publish(events []model.OutboxEvent) []int64 {
+	published := make([]int64, 0, len(events))
+	held := map[string]bool{}
+
+	for _, e := range events {
+		key := e.Key()
+		if held[key] {
+			continue
+		}
+
+		if err := r.publishEvent(e); err != nil {
+			log.Printf("outbox: publish event %d of %s: %v", e.ID, key, err)
+			held[key] = true
+			continue
+		}
+		published = append(published, e.ID)
+	}
+
+	return published
+}
+
+// publishEvent hands the event to every publisher in turn, the ones that
+// took it before one failed see it again on the next round
+func (r *Relay) publishEvent(e model.OutboxEvent) error {
+	m := NewMessage(e)
+	for _, p := range r.publishers {
+		ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+		err := p.Publish(ctx, m)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder fails the events listed in failures
+type recorder struct {
+	failures  map[int64]bool
+	published []int64
+}
+
+func (r *recorder) Publish(ctx context.Context, m Message) error {
+	if r.failures[m.ID] {
+		return errors.New("broker unavailable")
+	}
+	r.published = append(r.published, m.ID)
+	return nil
+}
+
+func (r *recorder) Close() error {
+	return nil
+}
+
+func bookEvent(id, bookID int64) model.OutboxEvent {
+	return model.OutboxEvent{ID: id, AggregateType: model.AggregateBook, AggregateID: bookID, Type: model.BookUpdated, Payload: `{}`}
+}
+
+func Test_Relay_Run(t *testing.T) {
+	type testCase struct {
+		name          string
+		events        []model.OutboxEvent
+		failures      map[int64]bool
+		wantPublished []int64
+	}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:          "in order",
+		events:        []model.OutboxEvent{bookEvent(1, 1), bookEvent(2, 2), bookEvent(3, 1)},
+		wantPublished: []int64{1, 2, 3},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "failure holds back the book",
+		events:        []model.OutboxEvent{bookEvent(1, 1), bookEvent(2, 2), bookEvent(3, 1), bookEvent(4, 2)},
+		failures:      map[int64]bool{1: true},
+		wantPublished: []int64{2, 4},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "later failure keeps the earlier events",
+		events:        []model.OutboxEvent{bookEvent(1, 1), bookEvent(2, 1), bookEvent(3, 1)},
+		failures:      map[int64]bool{2: true},
+		wantPublished: []int64{1},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			outboxRepo := mocks.NewMockOutboxRepo(mockCtrl)
+			outboxRepo.EXPECT().RelayOutbox(relayBatch, gomock.Any()).
+				DoAndReturn(func(limit int, publish func([]model.OutboxEvent) []int64) (int64, error) {
+					ids := publish(testCase.events)
+					assert.Equal(t, testCase.wantPublished, ids)
+					return int64(len(ids)), nil
+				}).Times(1)
+
+			publisher := &recorder{failures: testCase.failures}
+			published, err := NewRelay(outboxRepo, publisher).Run()
+
+			assert.Nil(t, err)
+			assert.Equal(t, int64(len(testCase.wantPublished)), published)
+			assert.Equal(t, testCase.wantPublished, publisher.published)
+		})
+	}
+}
+
+func Test_Relay_Run_Batches(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	outboxRepo := mocks.NewMockOutboxRepo(mockCtrl)
+
+	full := make([]model.OutboxEvent, relayBatch)
+	for i := range full {
+		full[i] = bookEvent(int64(i+1), int64(i%7))
+	}
+	gomock.InOrder(
+		outboxRepo.EXPECT().RelayOutbox(relayBatch, gomock.Any()).
+			DoAndReturn(func(limit int, publish func([]model.OutboxEvent) []int64) (int64, error) {
+				return int64(len(publish(full))), nil
+			}),
+		outboxRepo.EXPECT().RelayOutbox(relayBatch, gomock.Any()).
+			DoAndReturn(func(limit int, publish func([]model.OutboxEvent) []int64) (int64, error) {
+				return int64(len(publish([]model.OutboxEvent{bookEvent(relayBatch+1, 1)}))), nil
+			}),
+	)
+
+	published, err := NewRelay(outboxRepo, &recorder{}).Run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(relayBatch+1), published)
+}
+
+func Test_Relay_Prune(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	outboxRepo := mocks.NewMockOutboxRepo(mockCtrl)
+	outboxRepo.EXPECT().PruneOutbox(gomock.Any()).DoAndReturn(func(before time.Time) (int64, error) {
+		assert.WithinDuration(t, time.Now().Add(-24*time.Hour), before, time.Minute)
+		return 3, nil
+	})
+
+	pruned, err := NewRelay(outboxRepo, &recorder{}).Prune(24 * time.Hour)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), pruned)
+}
+
+func Test_Relay_Run_Publishers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	outboxRepo := mocks.NewMockOutboxRepo(mockCtrl)
+	outboxRepo.EXPECT().RelayOutbox(relayBatch, gomock.Any()).
+		DoAndReturn(func(limit int, publish func([]model.OutboxEvent) []int64) (int64, error) {
+			ids := publish([]model.OutboxEvent{bookEvent(1, 1), bookEvent(2, 2), bookEvent(3, 1)})
+			assert.Equal(t, []int64{2}, ids)
+			return int64(len(ids)), nil
+		}).Times(1)
+
+	// the broker took the event the webhooks failed, it sees it again later
+	broker := &recorder{}
+	webhooks := &recorder{failures: map[int64]bool{1: true}}
+	published, err := NewRelay(outboxRepo, broker, webhooks).Run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), published)
+	assert.Equal(t, []int64{1, 2}, broker.published)
+	assert.Equal(t, []int64{2}, webhooks.published)
+}
@@ -0,0 +1,288 @@
+package recommend
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// weights of the signals making up the similarity of two books
+const (
+	TextWeight     = 0.6
+	AuthorWeight   = 0.2
+	TagWeight      = 0.1
+	CategoryWeight = 0.1
+)
+
+// Document is what the index knows about a book
+type Document struct {
+	ID         int64
+	Terms      map[string]int
+	Authors    []int64
+	Tags       []int64
+	Categories []int64
+}
+
+// Result is a book similar to the one asked about, scores run from 0 to 1
+type Result struct {
+	ID    int64
+	Score float64
+}
+
+// Index ranks books by TF-IDF cosine similarity of their text combined with
+// the authors, tags and categories they share. every tenant has a corpus
+// of its own, loaded once and kept up to date document by document. a nil
+// index ignores updates.
+type Index struct {
+	mu      sync.Mutex
+	corpora map[int64]*corpus
+	// changes counts the updates of each tenant and forgets the calls to
+	// Forget, a corpus read before either moved on is out of date
+	changes map[int64]uint64
+	forgets uint64
+}
+
+// Version is how far the books of a tenant had come when a corpus was read
+type Version struct {
+	forgets uint64
+	changes uint64
+}
+
+type corpus struct {
+	docs  map[int64]Document
+	df    map[string]int
+	norms map[int64]float64
+	cache map[int64][]Result
+}
+
+func NewIndex() *Index {
+	return &Index{corpora: map[int64]*corpus{}, changes: map[int64]uint64{}}
+}
+
+// Loaded reports whether the corpus of the tenant has been loaded
+func (x *Index) Loaded(tenantID int64) bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	_, ok := x.corpora[tenantID]
+	return ok
+}
+
+// Version is taken before reading the books a corpus is loaded from
+func (x *Index) Version(tenantID int64) Version {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.version(tenantID)
+}
+
+func (x *Index) version(tenantID int64) Version {
+	return Version{forgets: x.forgets, changes: x.changes[tenantID]}
+}
+
+// Load sets the corpus of the tenant read at the version. it refuses, and
+// returns false, when a corpus was loaded meanwhile or the books changed
+// since, the updates missed would be lost
+func (x *Index) Load(tenantID int64, docs []Document, v Version) bool {
+	c := &corpus{docs: map[int64]Document{}, df: map[string]int{}}
+	for _, d := range docs {
+		c.add(d)
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if _, ok := x.corpora[tenantID]; ok || x.version(tenantID) != v {
+		return false
+	}
+	x.corpora[tenantID] = c
+	return true
+}
+
+// Upsert adds or replaces a document of a loaded tenant, tenants not loaded
+// yet pick it up when they are
+func (x *Index) Upsert(tenantID int64, d Document) {
+	if x == nil {
+		return
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.changes[tenantID]++
+	c, ok := x.corpora[tenantID]
+	if !ok {
+		return
+	}
+	c.remove(d.ID)
+	c.add(d)
+}
+
+// Remove drops a document from a loaded tenant
+func (x *Index) Remove(tenantID, id int64) {
+	if x == nil {
+		return
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.changes[tenantID]++
+	if c, ok := x.corpora[tenantID]; ok {
+		c.remove(id)
+	}
+}
+
+// Forget drops every corpus, each is loaded again when next asked for
+func (x *Index) Forget() {
+	if x == nil {
+		return
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.forgets++
+	x.corpora = map[int64]*corpus{}
+}
+
+// Similar returns up to limit documents most similar to the given one, best
+// first. the ranking is cached until the corpus changes.
+func (x *Index) Similar(tenantID, id int64, limit int) []Result {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	c, ok := x.corpora[tenantID]
+	if !ok {
+		return nil
+	}
+	doc, ok := c.docs[id]
+	if !ok {
+		return nil
+	}
+
+	results, ok := c.cache[id]
+	if !ok {
+		results = c.rank(doc)
+		c.cache[id] = results
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return append([]Result(nil), results...)
+}
+
+func (c *corpus) add(d Document) {
+	c.docs[d.ID] = d
+	for term := range d.Terms {
+		c.df[term]++
+	}
+	c.invalidate()
+}
+
+func (c *corpus) remove(id int64) {
+	d, ok := c.docs[id]
+	if !ok {
+		return
+	}
+	delete(c.docs, id)
+	for term := range d.Terms {
+		if c.df[term]--; c.df[term] <= 0 {
+			delete(c.df, term)
+		}
+	}
+	c.invalidate()
+}
+
+// invalidate drops what depends on the whole corpus, any change moves the
+// document frequencies and so every weight
+func (c *corpus) invalidate() {
+	c.norms = map[int64]float64{}
+	c.cache = map[int64][]Result{}
+}
+
+// rank scores every other document against d
+func (c *corpus) rank(d Document) []Result {
+	var results []Result
+	for id, other := range c.docs {
+		if id == d.ID {
+			continue
+		}
+
+		score := TextWeight*c.cosine(d, other) +
+			AuthorWeight*jaccard(d.Authors, other.Authors) +
+			TagWeight*jaccard(d.Tags, other.Tags) +
+			CategoryWeight*jaccard(d.Categories, other.Categories)
+		if score > 0 {
+			results = append(results, Result{ID: id, Score: math.Round(score*1000) / 1000})
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+	return results
+}
+
+// idf is the smoothed inverse document frequency of a term
+func (c *corpus) idf(term string) float64 {
+	return math.Log(float64(len(c.docs)+1)/float64(c.df[term]+1)) + 1
+}
+
+func (c *corpus) weight(d Document, term string) float64 {
+	return float64(d.Terms[term]) * c.idf(term)
+}
+
+func (c *corpus) norm(d Document) float64 {
+	if n, ok := c.norms[d.ID]; ok {
+		return n
+	}
+
+	sum := 0.0
+	for term := range d.Terms {
+		w := c.weight(d, term)
+		sum += w * w
+	}
+	c.norms[d.ID] = math.Sqrt(sum)
+	return c.norms[d.ID]
+}
+
+// cosine is the cosine similarity of the TF-IDF vectors of two documents
+func (c *corpus) cosine(a, b Document) float64 {
+	if len(b.Terms) < len(a.Terms) {
+		a, b = b, a
+	}
+
+	dot := 0.0
+	for term := range a.Terms {
+		if _, ok := b.Terms[term]; ok {
+			dot += c.weight(a, term) * c.weight(b, term)
+		}
+	}
+	if dot == 0 {
+		return 0
+	}
+	return dot / (c.norm(a) * c.norm(b))
+}
+
+func jaccard(a, b []int64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	set := make(map[int64]bool, len(a))
+	for _, id := range a {
+		set[id] = true
+	}
+	shared, union := 0, len(set)
+	seen := map[int64]bool{}
+	for _, id := range b {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if set[id] {
+			shared++
+		} else {
+			union++
+		}
+	}
+	return float64(shared) / float64(union)
+}
@@ -0,0 +1,93 @@
+package recommend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func docs() []Document {
+	return []Document{
+		{ID: 1, Terms: Terms("Dune", "A desert planet, spice and a noble family at war"), Authors: []int64{1}},
+		{ID: 2, Terms: Terms("Dune Messiah", "The emperor of the desert planet faces a holy war"), Authors: []int64{1}},
+		{ID: 3, Terms: Terms("Foundation", "An empire in decline and a plan to shorten the dark age"), Authors: []int64{2}},
+		{ID: 4, Terms: Terms("Pride and Prejudice", "Manners, marriage and money in Regency England"), Authors: []int64{3}},
+	}
+}
+
+func Test_Terms(t *testing.T) {
+	assert.Equal(t, map[string]int{"desert": 2, "planet": 1}, Terms("The desert PLANET,", "a desert"))
+}
+
+func Test_Index_Similar(t *testing.T) {
+	x := NewIndex()
+	assert.False(t, x.Loaded(1))
+	assert.Nil(t, x.Similar(1, 1, 10))
+
+	x.Load(1, docs(), x.Version(1))
+	assert.True(t, x.Loaded(1))
+
+	res := x.Similar(1, 1, 10)
+	assert.Equal(t, int64(2), res[0].ID)
+	for _, r := range res {
+		assert.NotEqual(t, int64(4), r.ID, "nothing in common")
+	}
+
+	// tenants do not see each other's books
+	assert.Nil(t, x.Similar(2, 1, 10))
+
+	assert.Len(t, x.Similar(1, 1, 1), 1)
+}
+
+func Test_Index_Incremental(t *testing.T) {
+	x := NewIndex()
+	x.Load(1, docs(), x.Version(1))
+	assert.Empty(t, x.Similar(1, 3, 10))
+
+	// an update shows up in the cached ranking right away
+	x.Upsert(1, Document{ID: 4, Terms: Terms("Second Foundation", "The empire in decline searches for the hidden plan"), Authors: []int64{2}})
+	assert.Equal(t, int64(4), x.Similar(1, 3, 10)[0].ID)
+
+	x.Remove(1, 4)
+	assert.Empty(t, x.Similar(1, 3, 10))
+
+	// unloaded tenants and nil indexes ignore updates
+	x.Upsert(2, Document{ID: 9})
+	assert.False(t, x.Loaded(2))
+	var none *Index
+	none.Upsert(1, Document{ID: 9})
+	none.Remove(1, 9)
+	none.Forget()
+
+	// forgotten tenants are loaded again
+	x.Forget()
+	assert.False(t, x.Loaded(1))
+}
+
+func Test_Index_Load_Overtaken(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(x *Index)
+	}{
+		{name: "loaded meanwhile", write: func(x *Index) { x.Load(1, docs()[2:], x.Version(1)) }},
+		{name: "book updated", write: func(x *Index) { x.Upsert(1, Document{ID: 9}) }},
+		{name: "book removed", write: func(x *Index) { x.Remove(1, 4) }},
+		{name: "forgotten", write: func(x *Index) { x.Forget() }},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			x := NewIndex()
+			v := x.Version(1)
+			tc.write(x)
+
+			assert.False(t, x.Load(1, docs()[:2], v))
+			assert.Empty(t, x.Similar(1, 1, 10), "the stale corpus is not loaded")
+		})
+	}
+
+	// other tenants' writes leave the version alone
+	x := NewIndex()
+	v := x.Version(1)
+	x.Upsert(2, Document{ID: 9})
+	assert.True(t, x.Load(1, docs(), v))
+}
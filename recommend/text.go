@@ -0,0 +1,37 @@
+package recommend
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopWords carry no meaning of their own and are left out of documents
+var stopWords = map[string]bool{
+	"a": true, "about": true, "after": true, "all": true, "also": true, "an": true, "and": true,
+	"any": true, "are": true, "as": true, "at": true, "be": true, "been": true, "but": true,
+	"by": true, "can": true, "for": true, "from": true, "had": true, "has": true, "have": true,
+	"her": true, "his": true, "how": true, "in": true, "into": true, "is": true, "it": true,
+	"its": true, "more": true, "most": true, "not": true, "of": true, "on": true, "one": true,
+	"or": true, "our": true, "she": true, "so": true, "than": true, "that": true, "the": true,
+	"their": true, "them": true, "then": true, "there": true, "these": true, "they": true,
+	"this": true, "to": true, "was": true, "were": true, "what": true, "when": true,
+	"which": true, "who": true, "will": true, "with": true, "would": true, "you": true,
+}
+
+// Terms counts the words of a text, folded to lower case, without stop
+// words and words shorter than three letters
+func Terms(texts ...string) map[string]int {
+	out := map[string]int{}
+	for _, text := range texts {
+		words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, w := range words {
+			if len([]rune(w)) < 3 || stopWords[w] {
+				continue
+			}
+			out[w]++
+		}
+	}
+	return out
+}
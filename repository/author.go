@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"ninth-learn/model"
+
+	"gorm.io/gorm"
+)
+
+// interface Author
+type AuthorRepo interface {
+	GetAuthors() ([]model.Author, error)
+	CreateAuthor(in model.Author) (res model.Author, err error)
+	GetAuthorById(id int64) (res model.Author, err error)
+	GetAuthorByName(name string) (res model.Author, err error)
+	// UpdateAuthor and DeleteAuthor return the books that showed the author
+	UpdateAuthor(in model.Author) (res model.Author, bookIDs []int64, err error)
+	DeleteAuthor(id int64) (bookIDs []int64, err error)
+	GetBooksByAuthor(id int64) ([]model.Book, error)
+}
+
+func (r Repo) GetAuthors() ([]model.Author, error) {
+	var authors []model.Author
+	err := r.db.Order("name").Find(&authors).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return authors, nil
+}
+
+func (r Repo) CreateAuthor(in model.Author) (res model.Author, err error) {
+	in.TenantID = r.tenantID
+	in.NormalizedName = model.NormalizeAuthorName(in.Name)
+
+	result := r.db.Create(&in)
+	if result.Error != nil {
+		return res, result.Error
+	}
+
+	return in, nil
+}
+
+func (r Repo) GetAuthorById(id int64) (res model.Author, err error) {
+	if err := r.db.Where("id = ?", id).First(&res).Error; err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// GetAuthorByName matches on the normalized name, so spelling variants of
+// the same author resolve to the same row
+func (r Repo) GetAuthorByName(name string) (res model.Author, err error) {
+	if err := r.db.Where("normalized_name = ?", model.NormalizeAuthorName(name)).First(&res).Error; err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+func (r Repo) UpdateAuthor(in model.Author) (res model.Author, bookIDs []int64, err error) {
+	// Find the author to update
+	author := model.Author{}
+	if err := r.db.Where("id = ?", in.ID).First(&author).Error; err != nil {
+		return in, nil, err
+	}
+
+	// Update the author
+	author.Name = in.Name
+	author.NormalizedName = model.NormalizeAuthorName(in.Name)
+	author.Bio = in.Bio
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&author).Error; err != nil {
+			return err
+		}
+		bookIDs, err = touchLinked(tx, &model.BookAuthor{}, "author_id", author.ID)
+		return err
+	})
+	if err != nil {
+		return res, nil, err
+	}
+
+	res = author
+	return res, bookIDs, nil
+}
+
+func (r Repo) DeleteAuthor(id int64) (bookIDs []int64, err error) {
+	// Find the author to delete
+	author := model.Author{}
+	if err := r.db.Where("id = ?", id).First(&author).Error; err != nil {
+		return nil, err
+	}
+
+	// Unlink the author from its books, then delete it
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		bookIDs, err = touchLinked(tx, &model.BookAuthor{}, "author_id", author.ID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("author_id = ?", author.ID).Delete(&model.BookAuthor{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&author).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bookIDs, nil
+}
+
+func (r Repo) GetBooksByAuthor(id int64) ([]model.Book, error) {
+	var books []model.Book
+	err := r.preloadBook(r.db).
+		Where("id IN (?)", r.db.Model(&model.BookAuthor{}).Select("book_id").Where("author_id = ?", id)).
+		Find(&books).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return books, nil
+}
+
+// findOrCreateAuthor returns the author matching name, creating it if needed
+func (r Repo) findOrCreateAuthor(tx *gorm.DB, name string) (res model.Author, err error) {
+	err = tx.Where(model.Author{TenantID: r.tenantID, NormalizedName: model.NormalizeAuthorName(name)}).
+		Attrs(model.Author{Name: name}).
+		FirstOrCreate(&res).Error
+	return res, err
+}
@@ -1,44 +1,243 @@
 package repository
 
 import (
+	"errors"
+	"fmt"
 	"ninth-learn/model"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // clean architectures -> handler->service->repo
 
 // interface Book
 type BookRepo interface {
-	GetBooks() ([]model.Book, error)
+	GetBooks(filter model.BookFilter) ([]model.Book, error)
+	CountBooks(filter model.BookFilter) (int64, error)
+	// BooksModifiedAt is the latest change to any book of the tenant, or
+	// its covers, or a delete
+	BooksModifiedAt() (time.Time, error)
 	CreateBook(in model.Book) (res model.Book, err error)
 	GetBookById(id int64) (res model.Book, err error)
+	GetBookByISBN(isbn13 string) (res model.Book, err error)
+	GetBooksByIds(ids []int64) ([]model.Book, error)
 	UpdateBook(in model.Book) (res model.Book, err error)
-	DeleteBook(id int64) (err error)
+	// DeleteBook refuses a book with copies unless forced
+	DeleteBook(id int64, force bool) (err error)
 }
 
-func (r Repo) GetBooks() ([]model.Book, error) {
+func (r Repo) GetBooks(filter model.BookFilter) ([]model.Book, error) {
+	query, err := r.filterBooks(r.preloadBook(r.db), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Sort == model.BookSortRating {
+		query = query.Order("rating_average DESC, rating_count DESC, id")
+	}
+	if filter.SeriesName != "" {
+		positions := r.seriesEntries(filter.SeriesName).Select("book_id, MIN(position) AS position").Group("book_id")
+		query = query.Joins("JOIN (?) series_order ON series_order.book_id = books.id", positions).Order("series_order.position")
+	}
+	if filter.Limit > 0 {
+		// pages need a stable order
+		query = query.Order("id").Limit(filter.Limit).Offset(filter.Offset)
+	}
+
 	var books []model.Book
-	err := r.db.Find(&books).Error
+	err = query.Find(&books).Error
 	if err != nil {
 		return nil, err
 	}
 
+	if err := r.fillAvailability(books); err != nil {
+		return nil, err
+	}
+
 	return books, nil
 }
 
+// CountBooks counts the books matching the filter, ignoring its page
+func (r Repo) CountBooks(filter model.BookFilter) (int64, error) {
+	query, err := r.filterBooks(r.db.Model(&model.Book{}), filter)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err = query.Count(&count).Error
+	return count, err
+}
+
+// BooksModifiedAt dates every list of the tenant's books. a book leaving a
+// list is either deleted, which dates the tenant, or written, which dates
+// the book
+func (r Repo) BooksModifiedAt() (time.Time, error) {
+	var books struct {
+		Updated *time.Time
+		Cover   *time.Time
+	}
+	err := r.db.Model(&model.Book{}).Select("MAX(updated_at) AS updated, MAX(cover_updated_at) AS cover").Scan(&books).Error
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var deleted *time.Time
+	tenants := r.db.Model(&model.Tenant{})
+	if r.tenantID != 0 {
+		tenants = tenants.Where("id = ?", r.tenantID)
+	}
+	if err := tenants.Select("MAX(books_deleted_at)").Scan(&deleted).Error; err != nil {
+		return time.Time{}, err
+	}
+
+	var latest time.Time
+	for _, at := range []*time.Time{books.Updated, books.Cover, deleted} {
+		if at != nil && at.After(latest) {
+			latest = *at
+		}
+	}
+	return latest, nil
+}
+
+// filterBooks narrows the query down to the books matching the filter
+func (r Repo) filterBooks(query *gorm.DB, filter model.BookFilter) (*gorm.DB, error) {
+	if filter.CategoryID != 0 {
+		ids, err := r.GetCategoryDescendantIds(filter.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("id IN (?)", r.db.Model(&model.BookCategory{}).Select("book_id").Where("category_id IN ?", ids))
+	}
+
+	if len(filter.Tags) > 0 {
+		names := make([]string, 0, len(filter.Tags))
+		for _, name := range filter.Tags {
+			names = append(names, model.NormalizeTagName(name))
+		}
+
+		tagged := r.db.Model(&model.BookTag{}).
+			Select("book_id").
+			Joins("JOIN public.tags t ON t.id = book_tags.tag_id").
+			Where("t.name IN ?", names)
+		if filter.MatchAllTags {
+			tagged = tagged.Group("book_id").Having("COUNT(DISTINCT t.id) = ?", len(uniqueStrings(names)))
+		}
+		query = query.Where("id IN (?)", tagged)
+	}
+
+	if filter.Publisher != "" {
+		query = query.Where("LOWER(publisher) = LOWER(?)", filter.Publisher)
+	}
+	if filter.Language != "" {
+		query = query.Where("language = ?", filter.Language)
+	}
+	if filter.Format != "" {
+		query = query.Where("format = ?", filter.Format)
+	}
+	if filter.Query != "" {
+		query = query.Where("id IN (?)", r.textSearch(filter.Query))
+	}
+	if filter.SeriesName != "" {
+		// the series entries place books in series, series_name only puts
+		// a new book in one
+		query = query.Where("id IN (?)", r.seriesEntries(filter.SeriesName).Select("book_id"))
+	}
+	if filter.PublishedAfter != nil {
+		query = query.Where("publication_date >= ?", filter.PublishedAfter)
+	}
+	if filter.PublishedBefore != nil {
+		query = query.Where("publication_date <= ?", filter.PublishedBefore)
+	}
+
+	return query, nil
+}
+
 func (r Repo) CreateBook(in model.Book) (res model.Book, err error) {
-	result := r.db.Create(&in)
-	if result.Error != nil {
-		return res, result.Error
+	in.TenantID = r.tenantID
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(append(ratingColumns, clause.Associations)...).Create(&in).Error; err != nil {
+			return err
+		}
+
+		if err := r.replaceCategories(tx, in.ID, in.Categories); err != nil {
+			return err
+		}
+		if err := r.replaceTags(tx, in.ID, in.Tags); err != nil {
+			return err
+		}
+
+		if err := r.linkSeries(tx, in); err != nil {
+			return err
+		}
+
+		// without explicit contributors the free-text author becomes the author
+		if len(in.Authors) == 0 {
+			if err := r.linkAuthor(tx, in.ID, in.Author); err != nil {
+				return err
+			}
+		} else if err := r.replaceAuthors(tx, in.ID, in.Authors); err != nil {
+			return err
+		}
+
+		return r.recordBookEvent(tx, model.BookCreated, in.ID)
+	})
+	if err != nil {
+		return res, err
 	}
 
-	return in, nil
+	return r.GetBookById(in.ID)
 }
 
+// GetBookById follows the redirect left behind when the book was merged
+// into another
 func (r Repo) GetBookById(id int64) (res model.Book, err error) {
-	if err := r.db.Where("id = ?", id).First(&res).Error; err != nil {
+	err = r.preloadBook(r.db).Where("id = ?", id).First(&res).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		redirect := model.BookRedirect{}
+		if r.db.Where("from_id = ?", id).First(&redirect).Error == nil {
+			err = r.preloadBook(r.db).Where("id = ?", redirect.ToID).First(&res).Error
+		}
+	}
+	if err != nil {
+		return res, err
+	}
+
+	books := []model.Book{res}
+	if err := r.fillAvailability(books); err != nil {
 		return res, err
 	}
-	return res, nil
+	return books[0], nil
+}
+
+func (r Repo) GetBookByISBN(isbn13 string) (res model.Book, err error) {
+	if err := r.preloadBook(r.db).Where("isbn_13 = ?", isbn13).First(&res).Error; err != nil {
+		return res, err
+	}
+
+	books := []model.Book{res}
+	if err := r.fillAvailability(books); err != nil {
+		return res, err
+	}
+	return books[0], nil
+}
+
+func (r Repo) GetBooksByIds(ids []int64) ([]model.Book, error) {
+	var books []model.Book
+	err := r.preloadBook(r.db).Where("id IN ?", ids).Find(&books).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.fillAvailability(books); err != nil {
+		return nil, err
+	}
+
+	return books, nil
 }
 
 func (r Repo) UpdateBook(in model.Book) (res model.Book, err error) {
@@ -47,32 +246,310 @@ func (r Repo) UpdateBook(in model.Book) (res model.Book, err error) {
 	if err := r.db.Where("id = ?", in.ID).First(&book).Error; err != nil {
 		return in, err
 	}
+	authorChanged := model.NormalizeAuthorName(book.Author) != model.NormalizeAuthorName(in.Author)
+	seriesChanged := !strings.EqualFold(strings.TrimSpace(book.SeriesName), strings.TrimSpace(in.SeriesName))
+	titleChanged := book.Title != in.Title
+	oldSeries := book.SeriesName
 
 	// Update the book
 	book.Title = in.Title
+	book.Subtitle = in.Subtitle
 	book.Author = in.Author
 	book.Description = in.Description
+	book.ISBN10 = in.ISBN10
+	book.ISBN13 = in.ISBN13
+	book.Publisher = in.Publisher
+	book.PublicationDate = in.PublicationDate
+	book.Edition = in.Edition
+	book.Language = in.Language
+	book.PageCount = in.PageCount
+	book.Format = in.Format
+	book.SeriesName = in.SeriesName
+	book.SeriesPosition = in.SeriesPosition
 
-	err = r.db.Save(&book).Error
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(append(ratingColumns, clause.Associations)...).Save(&book).Error; err != nil {
+			return err
+		}
+
+		if titleChanged {
+			if err := touchNeighbours(tx, book.ID); err != nil {
+				return err
+			}
+		}
+		if in.Categories != nil {
+			if err := r.replaceCategories(tx, book.ID, in.Categories); err != nil {
+				return err
+			}
+		}
+		if in.Tags != nil {
+			if err := r.replaceTags(tx, book.ID, in.Tags); err != nil {
+				return err
+			}
+		}
+		if seriesChanged {
+			if err := r.unlinkSeries(tx, book.ID, oldSeries); err != nil {
+				return err
+			}
+			if err := r.linkSeries(tx, book); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case in.Authors != nil:
+			if err := r.replaceAuthors(tx, book.ID, in.Authors); err != nil {
+				return err
+			}
+		case authorChanged:
+			// keep editors and translators, swap the primary author
+			if err := tx.Where("book_id = ? AND role = ?", book.ID, model.AuthorRoleAuthor).Delete(&model.BookAuthor{}).Error; err != nil {
+				return err
+			}
+			if err := r.linkAuthor(tx, book.ID, book.Author); err != nil {
+				return err
+			}
+		}
+
+		return r.recordBookEvent(tx, model.BookUpdated, book.ID)
+	})
 	if err != nil {
 		return res, err
 	}
 
-	res = book
-	return res, nil
+	return r.GetBookById(book.ID)
 }
 
-func (r Repo) DeleteBook(id int64) (err error) {
+func (r Repo) DeleteBook(id int64, force bool) (err error) {
 	// Find the book to delete
 	book := model.Book{}
 	if err := r.db.Where("id = ?", id).First(&book).Error; err != nil {
 		return err
 	}
 
-	// Delete the book
-	if err := r.db.Delete(&book).Error; err != nil {
+	// Take the book off reading lists and out of its series, then delete it
+	// with its links, relations, reviews, translations, holds, loans and
+	// copies. Locking the book keeps copies from being added meanwhile, and
+	// locking the copies keeps them from being checked out.
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, book.ID); err != nil {
+			return err
+		}
+		copies := []model.Copy{}
+		if err := tx.Clauses(forUpdate).Where("book_id = ?", book.ID).Find(&copies).Error; err != nil {
+			return err
+		}
+		if len(copies) > 0 && !force {
+			return model.ErrBookHasCopies
+		}
+		if err := keepLoans(tx, "book_id = ?", book.ID); err != nil {
+			return err
+		}
+		if err := touchNeighbours(tx, book.ID); err != nil {
+			return err
+		}
+		if err := r.unlistBook(tx, book.ID); err != nil {
+			return err
+		}
+		if err := r.unseriesBook(tx, book.ID); err != nil {
+			return err
+		}
+		if err := r.unrelateBook(tx, book.ID); err != nil {
+			return err
+		}
+		if err := tx.Where("to_id = ?", book.ID).Delete(&model.BookRedirect{}).Error; err != nil {
+			return err
+		}
+		for _, link := range []interface{}{&model.BookAuthor{}, &model.BookCategory{}, &model.BookTag{}, &model.Review{}, &model.BookTranslation{}, &model.Hold{}, &model.Loan{}, &model.Copy{}} {
+			if err := tx.Where("book_id = ?", book.ID).Delete(link).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Delete(&book).Error; err != nil {
+			return err
+		}
+		if err := dateBookDelete(tx, book.TenantID); err != nil {
+			return err
+		}
+
+		return r.recordBookEvent(tx, model.BookDeleted, book.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	// blobs are removed once the row is gone, a failure only leaves orphans
+	if err := r.deleteBookCover(book); err != nil {
+		return fmt.Errorf("%w: %v", model.ErrCoverLeft, err)
+	}
+	return nil
+}
+
+// linkAuthor credits the named author, creating the author row if needed
+func (r Repo) linkAuthor(tx *gorm.DB, bookID int64, name string) error {
+	if model.NormalizeAuthorName(name) == "" {
+		return nil
+	}
+
+	author, err := r.findOrCreateAuthor(tx, name)
+	if err != nil {
+		return err
+	}
+
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.BookAuthor{
+		BookID:   bookID,
+		AuthorID: author.ID,
+		Role:     model.AuthorRoleAuthor,
+	}).Error
+}
+
+// replaceAuthors swaps every contributor link of a book for the given ones
+func (r Repo) replaceAuthors(tx *gorm.DB, bookID int64, authors []model.BookAuthor) error {
+	if err := tx.Where("book_id = ?", bookID).Delete(&model.BookAuthor{}).Error; err != nil {
+		return err
+	}
+	if len(authors) == 0 {
+		return nil
+	}
+
+	// only authors of the current tenant may be linked
+	ids := make([]int64, 0, len(authors))
+	for _, a := range authors {
+		ids = append(ids, a.AuthorID)
+	}
+	var count int64
+	if err := tx.Model(&model.Author{}).Where("id IN ?", ids).Count(&count).Error; err != nil {
+		return err
+	}
+	if count != int64(len(uniqueIDs(ids))) {
+		return gorm.ErrRecordNotFound
+	}
+
+	links := make([]model.BookAuthor, 0, len(authors))
+	for _, a := range authors {
+		links = append(links, model.BookAuthor{BookID: bookID, AuthorID: a.AuthorID, Role: a.Role})
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+}
+
+// replaceCategories swaps the categories of a book for the given ones
+func (r Repo) replaceCategories(tx *gorm.DB, bookID int64, categories []model.Category) error {
+	if err := tx.Where("book_id = ?", bookID).Delete(&model.BookCategory{}).Error; err != nil {
 		return err
 	}
+	if len(categories) == 0 {
+		return nil
+	}
 
+	// only categories of the current tenant may be assigned
+	ids := make([]int64, 0, len(categories))
+	for _, c := range categories {
+		ids = append(ids, c.ID)
+	}
+	ids = uniqueIDs(ids)
+	var count int64
+	if err := tx.Model(&model.Category{}).Where("id IN ?", ids).Count(&count).Error; err != nil {
+		return err
+	}
+	if count != int64(len(ids)) {
+		return gorm.ErrRecordNotFound
+	}
+
+	links := make([]model.BookCategory, 0, len(ids))
+	for _, id := range ids {
+		links = append(links, model.BookCategory{BookID: bookID, CategoryID: id})
+	}
+	return tx.Create(&links).Error
+}
+
+// replaceTags swaps the tags of a book for the given ones, unknown tag
+// names are created on the fly
+func (r Repo) replaceTags(tx *gorm.DB, bookID int64, tags []model.Tag) error {
+	if err := tx.Where("book_id = ?", bookID).Delete(&model.BookTag{}).Error; err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	tags, err := r.findOrCreateTags(tx, tags)
+	if err != nil {
+		return err
+	}
+
+	links := make([]model.BookTag, 0, len(tags))
+	for _, t := range tags {
+		links = append(links, model.BookTag{BookID: bookID, TagID: t.ID})
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+}
+
+// preloadBook loads the relations returned with every book
+func (r Repo) preloadBook(db *gorm.DB) *gorm.DB {
+	return db.Preload("Authors.Author").Preload("Categories").Preload("Tags")
+}
+
+func uniqueStrings(values []string) []string {
+	seen := map[string]bool{}
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func uniqueIDs(ids []int64) []int64 {
+	seen := map[int64]bool{}
+	out := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// touchBooks moves the change time of books whose reads change without a
+// write to their row, copy counts, ratings, translations, relations and
+// series standings, so clients revalidating by Last-Modified see it
+func touchBooks(tx *gorm.DB, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return tx.Model(&model.Book{}).Where("id IN ?", ids).UpdateColumn("updated_at", time.Now()).Error
+}
+
+// dateBookDelete dates the lists of the tenant a deleted book left
+func dateBookDelete(tx *gorm.DB, tenantID int64) error {
+	return tx.Model(&model.Tenant{}).Where("id = ?", tenantID).UpdateColumn("books_deleted_at", time.Now()).Error
+}
+
+// touchLinked touches the books linked to an author, category or tag, whose
+// names show in the books, and returns their ids
+func touchLinked(tx *gorm.DB, link interface{}, column string, id int64) (bookIDs []int64, err error) {
+	if err := tx.Model(link).Where(column+" = ?", id).Pluck("book_id", &bookIDs).Error; err != nil {
+		return nil, err
+	}
+	return bookIDs, touchBooks(tx, bookIDs...)
+}
+
+// touchNeighbours touches the books listing the book among their related
+// works or series neighbours
+func touchNeighbours(tx *gorm.DB, bookID int64) error {
+	related := tx.Model(&model.BookRelation{}).Select("related_id").Where("book_id = ?", bookID)
+	relating := tx.Model(&model.BookRelation{}).Select("book_id").Where("related_id = ?", bookID)
+	inSeries := tx.Model(&model.SeriesEntry{}).Select("book_id").
+		Where("series_id IN (?)", tx.Model(&model.SeriesEntry{}).Select("series_id").Where("book_id = ?", bookID))
+
+	for _, ids := range []*gorm.DB{related, relating, inSeries} {
+		err := tx.Model(&model.Book{}).Where("id IN (?) AND id <> ?", ids, bookID).UpdateColumn("updated_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
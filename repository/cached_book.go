@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"ninth-learn/cache"
+	"ninth-learn/model"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+)
+
+// BookCache keeps the books looked up by id, and for a short while the ids
+// a tenant found nothing under. copy counts and hold queues are read fresh
+// on every hit, renamed authors, categories and tags drop the books showing
+// them
+type BookCache struct {
+	store       cache.Store
+	ttl         time.Duration
+	negativeTTL time.Duration
+	// loads collapses concurrent misses of a book into one query
+	loads singleflight.Group
+	// invalidations counts the writes, a load overtaken by one does not
+	// cache what it read
+	invalidations atomic.Int64
+	Stats         cache.Stats
+}
+
+// cachedBook is a cache entry, gob keeps the fields hidden from JSON
+type cachedBook struct {
+	Missing bool
+	Book    model.Book
+}
+
+func NewBookCache(store cache.Store, ttl, negativeTTL time.Duration) *BookCache {
+	return &BookCache{store: store, ttl: ttl, negativeTTL: negativeTTL}
+}
+
+// Invalidate drops what the tenant, and the unscoped root, have cached of
+// the book
+func (c *BookCache) Invalidate(tenantID, id int64) {
+	keys := []string{bookKey(0, id)}
+	if tenantID != 0 {
+		keys = append(keys, bookKey(tenantID, id))
+	}
+	c.invalidations.Add(1)
+	for _, key := range keys {
+		// a load under way may have read the book before the change
+		c.loads.Forget(key)
+	}
+	if err := c.store.Delete(keys...); err != nil {
+		c.Stats.Errors.Add(1)
+	}
+}
+
+// Purge drops every book held in this process. a shared store is left
+// alone, the instances writing to it invalidate it themselves
+func (c *BookCache) Purge() {
+	if p, ok := c.store.(cache.Purger); ok {
+		p.Purge()
+	}
+}
+
+func bookKey(tenantID, id int64) string {
+	return "book:" + strconv.FormatInt(tenantID, 10) + ":" + strconv.FormatInt(id, 10)
+}
+
+// CachedRepo reads books by id through a BookCache and invalidates them on
+// every write to a book it makes. everything else goes straight to the repo
+type CachedRepo struct {
+	RepoInterface
+	books    *BookCache
+	tenantID int64
+}
+
+func NewCachedRepo(repo RepoInterface, books *BookCache) *CachedRepo {
+	return &CachedRepo{RepoInterface: repo, books: books}
+}
+
+func (r CachedRepo) WithTenant(tenantID int64) RepoInterface {
+	return &CachedRepo{
+		RepoInterface: r.RepoInterface.WithTenant(tenantID),
+		books:         r.books,
+		tenantID:      tenantID,
+	}
+}
+
+func (r CachedRepo) GetBookById(id int64) (res model.Book, err error) {
+	key := bookKey(r.tenantID, id)
+
+	data, ok, err := r.books.store.Get(key)
+	if err != nil {
+		r.books.Stats.Errors.Add(1)
+	}
+	if ok {
+		entry, err := decodeBook(data)
+		if err == nil {
+			r.books.Stats.Hits.Add(1)
+			if entry.Missing {
+				r.books.Stats.NegativeHits.Add(1)
+				return res, gorm.ErrRecordNotFound
+			}
+			books := []model.Book{entry.Book}
+			if err := r.RepoInterface.FillAvailability(books); err != nil {
+				return res, err
+			}
+			return books[0], nil
+		}
+		r.books.Stats.Errors.Add(1)
+	}
+
+	r.books.Stats.Misses.Add(1)
+	loaded, err, _ := r.books.loads.Do(key, func() (interface{}, error) {
+		return r.loadBook(key, id)
+	})
+	if err != nil {
+		return res, err
+	}
+	// every caller decodes a copy of its own to fill in
+	entry, err := decodeBook(loaded.([]byte))
+	if err != nil {
+		return res, err
+	}
+	if entry.Missing {
+		return res, gorm.ErrRecordNotFound
+	}
+	return entry.Book, nil
+}
+
+// loadBook reads the book and caches it, or that it is missing
+func (r CachedRepo) loadBook(key string, id int64) ([]byte, error) {
+	entry := cachedBook{}
+	ttl := r.books.ttl
+	invalidations := r.books.invalidations.Load()
+
+	book, err := r.RepoInterface.GetBookById(id)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		entry.Missing = true
+		ttl = r.books.negativeTTL
+	case err != nil:
+		return nil, err
+	default:
+		entry.Book = book
+	}
+
+	data, err := encodeBook(entry)
+	if err != nil {
+		return nil, err
+	}
+	// merged books are found under the id they had, only the book itself
+	// is invalidated when it changes
+	cacheable := entry.Missing || book.ID == id
+	if cacheable && r.books.invalidations.Load() == invalidations {
+		if err := r.books.store.Set(key, data, ttl); err != nil {
+			r.books.Stats.Errors.Add(1)
+		}
+	}
+	return data, nil
+}
+
+func encodeBook(entry cachedBook) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(entry)
+	return buf.Bytes(), err
+}
+
+func decodeBook(data []byte) (entry cachedBook, err error) {
+	err = gob.NewDecoder(bytes.NewReader(data)).Decode(&entry)
+	return entry, err
+}
+
+// tenantOf is the tenant whose cache a write to a book of the given tenant
+// invalidates. a tenant's repo only reaches its own books, the root repo
+// takes the tenant from what it wrote. deletes by id leave it nothing to
+// take it from, the root repo only writes books of tenant 0 that way
+func (r CachedRepo) tenantOf(tenantID int64) int64 {
+	if r.tenantID != 0 {
+		return r.tenantID
+	}
+	return tenantID
+}
+
+func (r CachedRepo) CreateBook(in model.Book) (res model.Book, err error) {
+	res, err = r.RepoInterface.CreateBook(in)
+	if err == nil {
+		// the tenant may have looked for the id before it was taken
+		r.books.Invalidate(res.TenantID, res.ID)
+	}
+	return res, err
+}
+
+func (r CachedRepo) UpdateBook(in model.Book) (res model.Book, err error) {
+	res, err = r.RepoInterface.UpdateBook(in)
+	r.books.Invalidate(r.tenantOf(res.TenantID), in.ID)
+	return res, err
+}
+
+func (r CachedRepo) DeleteBook(id int64, force bool) (err error) {
+	err = r.RepoInterface.DeleteBook(id, force)
+	r.books.Invalidate(r.tenantOf(0), id)
+	return err
+}
+
+func (r CachedRepo) MergeBooks(sourceID, targetID int64) (res model.Book, err error) {
+	res, err = r.RepoInterface.MergeBooks(sourceID, targetID)
+	tenantID := r.tenantOf(res.TenantID)
+	r.books.Invalidate(tenantID, sourceID)
+	r.books.Invalidate(tenantID, targetID)
+	return res, err
+}
+
+func (r CachedRepo) SaveBookCover(id int64, etag string, images []model.CoverImage) (res model.Book, err error) {
+	res, err = r.RepoInterface.SaveBookCover(id, etag, images)
+	r.books.Invalidate(r.tenantOf(res.TenantID), id)
+	return res, err
+}
+
+// authors, categories and tags show in their books
+
+func (r CachedRepo) UpdateAuthor(in model.Author) (res model.Author, bookIDs []int64, err error) {
+	res, bookIDs, err = r.RepoInterface.UpdateAuthor(in)
+	r.invalidateBooks(r.tenantOf(res.TenantID), bookIDs)
+	return res, bookIDs, err
+}
+
+func (r CachedRepo) DeleteAuthor(id int64) (bookIDs []int64, err error) {
+	bookIDs, err = r.RepoInterface.DeleteAuthor(id)
+	r.invalidateBooks(r.tenantOf(0), bookIDs)
+	return bookIDs, err
+}
+
+func (r CachedRepo) UpdateCategory(in model.Category) (res model.Category, bookIDs []int64, err error) {
+	res, bookIDs, err = r.RepoInterface.UpdateCategory(in)
+	r.invalidateBooks(r.tenantOf(res.TenantID), bookIDs)
+	return res, bookIDs, err
+}
+
+func (r CachedRepo) DeleteCategory(id int64) (bookIDs []int64, err error) {
+	bookIDs, err = r.RepoInterface.DeleteCategory(id)
+	r.invalidateBooks(r.tenantOf(0), bookIDs)
+	return bookIDs, err
+}
+
+func (r CachedRepo) UpdateTag(in model.Tag) (res model.Tag, bookIDs []int64, err error) {
+	res, bookIDs, err = r.RepoInterface.UpdateTag(in)
+	r.invalidateBooks(r.tenantOf(res.TenantID), bookIDs)
+	return res, bookIDs, err
+}
+
+func (r CachedRepo) DeleteTag(id int64) (bookIDs []int64, err error) {
+	bookIDs, err = r.RepoInterface.DeleteTag(id)
+	r.invalidateBooks(r.tenantOf(0), bookIDs)
+	return bookIDs, err
+}
+
+func (r CachedRepo) invalidateBooks(tenantID int64, ids []int64) {
+	for _, id := range ids {
+		r.books.Invalidate(tenantID, id)
+	}
+}
+
+// reviews change the rating of their book
+
+func (r CachedRepo) CreateReview(in model.Review) (res model.Review, err error) {
+	res, err = r.RepoInterface.CreateReview(in)
+	r.books.Invalidate(r.tenantOf(res.TenantID), in.BookID)
+	return res, err
+}
+
+func (r CachedRepo) UpdateReview(in model.Review) (res model.Review, err error) {
+	res, err = r.RepoInterface.UpdateReview(in)
+	r.books.Invalidate(r.tenantOf(res.TenantID), in.BookID)
+	return res, err
+}
+
+func (r CachedRepo) SetReviewStatus(bookID, id int64, status string) (res model.Review, err error) {
+	res, err = r.RepoInterface.SetReviewStatus(bookID, id, status)
+	r.books.Invalidate(r.tenantOf(res.TenantID), bookID)
+	return res, err
+}
+
+func (r CachedRepo) DeleteReview(bookID, id int64) (err error) {
+	err = r.RepoInterface.DeleteReview(bookID, id)
+	r.books.Invalidate(r.tenantOf(0), bookID)
+	return err
+}
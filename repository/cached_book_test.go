@@ -0,0 +1,221 @@
+package repository_test
+
+import (
+	"errors"
+	"ninth-learn/cache"
+	"ninth-learn/model"
+	"ninth-learn/repository"
+	"ninth-learn/repository/mocks"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// mockRepo stitches the per-entity mocks into a repository.RepoInterface,
+// tests only set the mocks they expect calls on
+type mockRepo struct {
+	*mocks.MockBookRepo
+	*mocks.MockTenantRepo
+	*mocks.MockAuthorRepo
+	*mocks.MockCategoryRepo
+	*mocks.MockTagRepo
+	*mocks.MockCoverRepo
+	*mocks.MockCopyRepo
+	*mocks.MockMemberRepo
+	*mocks.MockLoanRepo
+	*mocks.MockHoldRepo
+	*mocks.MockFineRepo
+	*mocks.MockReviewRepo
+	*mocks.MockReadingListRepo
+	*mocks.MockDuplicateRepo
+	*mocks.MockTranslationRepo
+	*mocks.MockSeriesRepo
+	*mocks.MockRelationRepo
+	*mocks.MockWebhookRepo
+	*mocks.MockOutboxRepo
+}
+
+func (m mockRepo) WithTenant(tenantID int64) repository.RepoInterface {
+	return m
+}
+
+func cachedRepo(t *testing.T) (repository.RepoInterface, *repository.BookCache, *mocks.MockBookRepo, *mocks.MockCopyRepo) {
+	mockCtrl := gomock.NewController(t)
+	bookRepo := mocks.NewMockBookRepo(mockCtrl)
+	copyRepo := mocks.NewMockCopyRepo(mockCtrl)
+	books := repository.NewBookCache(cache.NewLRU(100), time.Minute, time.Minute)
+	repo := repository.NewCachedRepo(mockRepo{MockBookRepo: bookRepo, MockCopyRepo: copyRepo}, books)
+	return repo.WithTenant(1), books, bookRepo, copyRepo
+}
+
+func Test_CachedRepo_GetBookById(t *testing.T) {
+	repo, books, bookRepo, copyRepo := cachedRepo(t)
+
+	book := model.Book{ID: 10, TenantID: 1, Title: "Dune", RatingSum: 9, TotalCopies: 2}
+	bookRepo.EXPECT().GetBookById(int64(10)).Return(book, nil).Times(1)
+	copyRepo.EXPECT().FillAvailability(gomock.Any()).DoAndReturn(func(books []model.Book) error {
+		books[0].TotalCopies = 3
+		return nil
+	}).Times(1)
+
+	res, err := repo.GetBookById(10)
+	assert.NoError(t, err)
+	assert.Equal(t, book, res)
+
+	// a hit keeps the fields hidden from JSON and reads availability again
+	res, err = repo.GetBookById(10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.TenantID)
+	assert.Equal(t, int64(9), res.RatingSum)
+	assert.Equal(t, int64(3), res.TotalCopies)
+
+	assert.Equal(t, cache.StatsSnapshot{Hits: 1, Misses: 1, HitRatio: 0.5}, books.Stats.Snapshot())
+}
+
+func Test_CachedRepo_Negative(t *testing.T) {
+	repo, books, bookRepo, _ := cachedRepo(t)
+
+	bookRepo.EXPECT().GetBookById(int64(10)).Return(model.Book{}, gorm.ErrRecordNotFound).Times(1)
+	for i := 0; i < 2; i++ {
+		_, err := repo.GetBookById(10)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	}
+	assert.Equal(t, int64(1), books.Stats.Snapshot().NegativeHits)
+
+	// errors other than not found are not cached
+	bookRepo.EXPECT().GetBookById(int64(11)).Return(model.Book{}, errors.New("connection refused")).Times(2)
+	for i := 0; i < 2; i++ {
+		_, err := repo.GetBookById(11)
+		assert.Error(t, err)
+	}
+}
+
+func Test_CachedRepo_Invalidate(t *testing.T) {
+	repo, books, bookRepo, copyRepo := cachedRepo(t)
+	copyRepo.EXPECT().FillAvailability(gomock.Any()).Return(nil).AnyTimes()
+
+	book := model.Book{ID: 10, TenantID: 1, Title: "Dune"}
+	updated := model.Book{ID: 10, TenantID: 1, Title: "Dune Messiah"}
+	gomock.InOrder(
+		bookRepo.EXPECT().GetBookById(int64(10)).Return(book, nil),
+		bookRepo.EXPECT().UpdateBook(updated).Return(updated, nil),
+		bookRepo.EXPECT().GetBookById(int64(10)).Return(updated, nil),
+		bookRepo.EXPECT().DeleteBook(int64(10), false).Return(nil),
+		bookRepo.EXPECT().GetBookById(int64(10)).Return(model.Book{}, gorm.ErrRecordNotFound),
+		bookRepo.EXPECT().GetBookById(int64(10)).Return(book, nil),
+	)
+
+	res, _ := repo.GetBookById(10)
+	assert.Equal(t, "Dune", res.Title)
+
+	_, err := repo.UpdateBook(updated)
+	assert.NoError(t, err)
+	res, _ = repo.GetBookById(10)
+	assert.Equal(t, "Dune Messiah", res.Title)
+
+	assert.NoError(t, repo.DeleteBook(10, false))
+	_, err = repo.GetBookById(10)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// changes made by other instances
+	books.Invalidate(1, 10)
+	res, _ = repo.GetBookById(10)
+	assert.Equal(t, "Dune", res.Title)
+}
+
+func Test_CachedRepo_RootWrite(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	bookRepo := mocks.NewMockBookRepo(mockCtrl)
+	copyRepo := mocks.NewMockCopyRepo(mockCtrl)
+	copyRepo.EXPECT().FillAvailability(gomock.Any()).Return(nil).AnyTimes()
+	root := repository.NewCachedRepo(mockRepo{MockBookRepo: bookRepo, MockCopyRepo: copyRepo}, repository.NewBookCache(cache.NewLRU(100), time.Minute, time.Minute))
+	repo := root.WithTenant(1)
+
+	book := model.Book{ID: 10, TenantID: 1, Title: "Dune"}
+	updated := model.Book{ID: 10, TenantID: 1, Title: "Dune Messiah"}
+	// the root repo takes the tenant from the book it wrote, it reads nothing
+	// before the write
+	gomock.InOrder(
+		bookRepo.EXPECT().GetBookById(int64(10)).Return(book, nil),
+		bookRepo.EXPECT().UpdateBook(model.Book{ID: 10, Title: "Dune Messiah"}).Return(updated, nil),
+		bookRepo.EXPECT().GetBookById(int64(10)).Return(updated, nil),
+	)
+
+	res, _ := repo.GetBookById(10)
+	assert.Equal(t, "Dune", res.Title)
+
+	_, err := root.UpdateBook(model.Book{ID: 10, Title: "Dune Messiah"})
+	assert.NoError(t, err)
+	res, _ = repo.GetBookById(10)
+	assert.Equal(t, "Dune Messiah", res.Title)
+}
+
+func Test_CachedRepo_Links(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	bookRepo := mocks.NewMockBookRepo(mockCtrl)
+	copyRepo := mocks.NewMockCopyRepo(mockCtrl)
+	authorRepo := mocks.NewMockAuthorRepo(mockCtrl)
+	copyRepo.EXPECT().FillAvailability(gomock.Any()).Return(nil).AnyTimes()
+	books := repository.NewBookCache(cache.NewLRU(100), time.Minute, time.Minute)
+	repo := repository.NewCachedRepo(mockRepo{MockBookRepo: bookRepo, MockCopyRepo: copyRepo, MockAuthorRepo: authorRepo}, books).WithTenant(1)
+
+	book := model.Book{ID: 10, TenantID: 1, Title: "Dune", Authors: []model.BookAuthor{{AuthorID: 2, Author: &model.Author{ID: 2, Name: "Frank Herbert"}}}}
+	renamed := model.Book{ID: 10, TenantID: 1, Title: "Dune", Authors: []model.BookAuthor{{AuthorID: 2, Author: &model.Author{ID: 2, Name: "Franklin Herbert"}}}}
+	gomock.InOrder(
+		bookRepo.EXPECT().GetBookById(int64(10)).Return(book, nil),
+		authorRepo.EXPECT().UpdateAuthor(gomock.Any()).Return(model.Author{ID: 2, TenantID: 1}, []int64{10}, nil),
+		bookRepo.EXPECT().GetBookById(int64(10)).Return(renamed, nil),
+		authorRepo.EXPECT().DeleteAuthor(int64(2)).Return([]int64{10}, nil),
+		bookRepo.EXPECT().GetBookById(int64(10)).Return(model.Book{ID: 10, TenantID: 1, Title: "Dune"}, nil),
+	)
+
+	res, _ := repo.GetBookById(10)
+	assert.Equal(t, "Frank Herbert", res.Authors[0].Author.Name)
+
+	// the books showing the author are read again
+	_, _, err := repo.UpdateAuthor(model.Author{ID: 2, Name: "Franklin Herbert"})
+	assert.NoError(t, err)
+	res, _ = repo.GetBookById(10)
+	assert.Equal(t, "Franklin Herbert", res.Authors[0].Author.Name)
+
+	_, err = repo.DeleteAuthor(2)
+	assert.NoError(t, err)
+	res, _ = repo.GetBookById(10)
+	assert.Empty(t, res.Authors)
+}
+
+func Test_CachedRepo_Singleflight(t *testing.T) {
+	repo, books, bookRepo, _ := cachedRepo(t)
+
+	release := make(chan struct{})
+	bookRepo.EXPECT().GetBookById(int64(10)).DoAndReturn(func(id int64) (model.Book, error) {
+		<-release
+		return model.Book{ID: 10, TenantID: 1, Tags: []model.Tag{{Name: "classic"}}}, nil
+	}).Times(1)
+
+	const callers = 8
+	results := make([]model.Book, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = repo.GetBookById(10)
+		}(i)
+	}
+	assert.Eventually(t, func() bool { return books.Stats.Snapshot().Misses == callers }, time.Second, time.Millisecond)
+	// let the last of them join the load
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// every caller has a book of its own
+	results[0].Tags[0].Name = "changed"
+	for _, res := range results[1:] {
+		assert.Equal(t, "classic", res.Tags[0].Name)
+	}
+}
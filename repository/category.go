@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"ninth-learn/model"
+
+	"gorm.io/gorm"
+)
+
+// interface Category
+type CategoryRepo interface {
+	GetCategories() ([]model.Category, error)
+	CreateCategory(in model.Category) (res model.Category, err error)
+	GetCategoryById(id int64) (res model.Category, err error)
+	GetCategoryDescendantIds(id int64) ([]int64, error)
+	// UpdateCategory and DeleteCategory return the books that showed the category
+	UpdateCategory(in model.Category) (res model.Category, bookIDs []int64, err error)
+	DeleteCategory(id int64) (bookIDs []int64, err error)
+	CountCategoryChildren(id int64) (int64, error)
+}
+
+func (r Repo) GetCategories() ([]model.Category, error) {
+	var categories []model.Category
+	err := r.db.Order("parent_id NULLS FIRST, name").Find(&categories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r Repo) CreateCategory(in model.Category) (res model.Category, err error) {
+	in.TenantID = r.tenantID
+
+	result := r.db.Create(&in)
+	if result.Error != nil {
+		return res, result.Error
+	}
+
+	return in, nil
+}
+
+func (r Repo) GetCategoryById(id int64) (res model.Category, err error) {
+	if err := r.db.Where("id = ?", id).First(&res).Error; err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// GetCategoryDescendantIds returns the category and every category below it
+func (r Repo) GetCategoryDescendantIds(id int64) ([]int64, error) {
+	var ids []int64
+	err := r.db.Raw(`
+		WITH RECURSIVE tree AS (
+			SELECT id FROM public.categories WHERE id = ? AND tenant_id = ?
+			UNION
+			SELECT c.id FROM public.categories c JOIN tree t ON c.parent_id = t.id
+		)
+		SELECT id FROM tree`, id, r.tenantID).Scan(&ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r Repo) UpdateCategory(in model.Category) (res model.Category, bookIDs []int64, err error) {
+	// Find the category to update
+	category := model.Category{}
+	if err := r.db.Where("id = ?", in.ID).First(&category).Error; err != nil {
+		return in, nil, err
+	}
+
+	// Update the category
+	category.Name = in.Name
+	category.ParentID = in.ParentID
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&category).Error; err != nil {
+			return err
+		}
+		bookIDs, err = touchLinked(tx, &model.BookCategory{}, "category_id", category.ID)
+		return err
+	})
+	if err != nil {
+		return res, nil, err
+	}
+
+	res = category
+	return res, bookIDs, nil
+}
+
+func (r Repo) DeleteCategory(id int64) (bookIDs []int64, err error) {
+	// Find the category to delete
+	category := model.Category{}
+	if err := r.db.Where("id = ?", id).First(&category).Error; err != nil {
+		return nil, err
+	}
+
+	// Unassign the category from its books, then delete it
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		bookIDs, err = touchLinked(tx, &model.BookCategory{}, "category_id", category.ID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("category_id = ?", category.ID).Delete(&model.BookCategory{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&category).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bookIDs, nil
+}
+
+func (r Repo) CountCategoryChildren(id int64) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Category{}).Where("parent_id = ?", id).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"ninth-learn/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// interface Copy
+type CopyRepo interface {
+	GetCopies(bookID int64) ([]model.Copy, error)
+	CreateCopy(in model.Copy) (res model.Copy, err error)
+	GetCopyById(bookID, id int64) (res model.Copy, err error)
+	GetCopyByBarcode(barcode string) (res model.Copy, err error)
+	UpdateCopy(in model.Copy) (res model.Copy, err error)
+	DeleteCopy(bookID, id int64) (err error)
+	// FillAvailability sets the copy counts, hold queue length and update
+	// times of books read elsewhere
+	FillAvailability(books []model.Book) error
+}
+
+func (r Repo) GetCopies(bookID int64) ([]model.Copy, error) {
+	var copies []model.Copy
+	err := r.db.Where("book_id = ?", bookID).Order("barcode").Find(&copies).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return copies, nil
+}
+
+func (r Repo) CreateCopy(in model.Copy) (res model.Copy, err error) {
+	in.TenantID = r.tenantID
+
+	// the book is locked against a delete counting its copies meanwhile
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, in.BookID); err != nil {
+			return err
+		}
+		if err := tx.Create(&in).Error; err != nil {
+			return err
+		}
+		return touchBooks(tx, in.BookID)
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return in, nil
+}
+
+func (r Repo) GetCopyById(bookID, id int64) (res model.Copy, err error) {
+	if err := r.db.Where("book_id = ? AND id = ?", bookID, id).First(&res).Error; err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// GetCopyByBarcode returns the copy along with its book
+func (r Repo) GetCopyByBarcode(barcode string) (res model.Copy, err error) {
+	if err := r.db.Preload("Book").Where("barcode = ?", barcode).First(&res).Error; err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+func (r Repo) UpdateCopy(in model.Copy) (res model.Copy, err error) {
+	// Find the copy to update
+	cp := model.Copy{}
+	if err := r.db.Where("book_id = ? AND id = ?", in.BookID, in.ID).First(&cp).Error; err != nil {
+		return in, err
+	}
+
+	// Update the copy
+	cp.Barcode = in.Barcode
+	cp.ShelfLocation = in.ShelfLocation
+	cp.Condition = in.Condition
+	// lent and reserved copies change status through loans and holds only
+	if cp.Status != model.CopyStatusOnLoan && cp.Status != model.CopyStatusOnHold {
+		cp.Status = in.Status
+	}
+	cp.AcquiredAt = in.AcquiredAt
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Book").Save(&cp).Error; err != nil {
+			return err
+		}
+		return touchBooks(tx, cp.BookID)
+	})
+	if err != nil {
+		return res, err
+	}
+
+	res = cp
+	return res, nil
+}
+
+func (r Repo) DeleteCopy(bookID, id int64) (err error) {
+	// Find the copy to delete
+	cp := model.Copy{}
+	if err := r.db.Where("book_id = ? AND id = ?", bookID, id).First(&cp).Error; err != nil {
+		return err
+	}
+
+	// Drop the loan history of the copy, then the copy
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(forUpdate).Where("id = ?", cp.ID).First(&model.Copy{}).Error; err != nil {
+			return err
+		}
+		if err := keepLoans(tx, "copy_id = ?", cp.ID); err != nil {
+			return err
+		}
+		if err := touchBooks(tx, cp.BookID); err != nil {
+			return err
+		}
+		if err := tx.Where("copy_id = ?", cp.ID).Delete(&model.Loan{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Hold{}).Where("copy_id = ?", cp.ID).Update("copy_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&cp).Error
+	})
+}
+
+func (r Repo) FillAvailability(books []model.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(books))
+	for _, b := range books {
+		ids = append(ids, b.ID)
+	}
+
+	// the writes that change availability also date the book, a copy read
+	// elsewhere carries the times it was read with
+	var times []struct {
+		ID             int64
+		UpdatedAt      time.Time
+		CoverUpdatedAt *time.Time
+	}
+	err := r.db.Model(&model.Book{}).Select("id, updated_at, cover_updated_at").Where("id IN ?", ids).Scan(&times).Error
+	if err != nil {
+		return err
+	}
+	for _, t := range times {
+		for i := range books {
+			if books[i].ID == t.ID {
+				books[i].UpdatedAt = t.UpdatedAt
+				books[i].CoverUpdatedAt = t.CoverUpdatedAt
+			}
+		}
+	}
+
+	return r.fillAvailability(books)
+}
+
+// fillAvailability sets the copy counts and hold queue length of books
+func (r Repo) fillAvailability(books []model.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(books))
+	for _, b := range books {
+		ids = append(ids, b.ID)
+	}
+
+	var counts []model.CopyCount
+	err := r.db.Model(&model.Copy{}).
+		Select("book_id, COUNT(*) AS total, COUNT(*) FILTER (WHERE status = ?) AS available", model.CopyStatusAvailable).
+		Where("book_id IN ?", ids).
+		Group("book_id").
+		Scan(&counts).Error
+	if err != nil {
+		return err
+	}
+
+	byBook := make(map[int64]model.CopyCount, len(counts))
+	for _, c := range counts {
+		byBook[c.BookID] = c
+	}
+	var queues []struct {
+		BookID int64
+		Count  int64
+	}
+	err = r.db.Model(&model.Hold{}).
+		Select("book_id, COUNT(*) AS count").
+		Where("book_id IN ? AND status = ?", ids, model.HoldStatusWaiting).
+		Group("book_id").
+		Scan(&queues).Error
+	if err != nil {
+		return err
+	}
+
+	queueByBook := make(map[int64]int64, len(queues))
+	for _, q := range queues {
+		queueByBook[q.BookID] = q.Count
+	}
+
+	for i := range books {
+		books[i].TotalCopies = byBook[books[i].ID].Total
+		books[i].AvailableCopies = byBook[books[i].ID].Available
+		books[i].HoldQueueLength = queueByBook[books[i].ID]
+	}
+	return nil
+}
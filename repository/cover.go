@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"ninth-learn/model"
+	"ninth-learn/storage"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// interface Cover
+type CoverRepo interface {
+	SaveBookCover(id int64, etag string, images []model.CoverImage) (res model.Book, err error)
+	GetBookCover(id int64, size string) (res model.CoverImage, err error)
+}
+
+var ErrNoBlobStore = errors.New("no blob store configured")
+
+func (r Repo) SaveBookCover(id int64, etag string, images []model.CoverImage) (res model.Book, err error) {
+	if r.blobs == nil {
+		return res, ErrNoBlobStore
+	}
+
+	// Find the book to attach the cover to
+	book := model.Book{}
+	if err := r.db.Where("id = ?", id).First(&book).Error; err != nil {
+		return res, err
+	}
+
+	for _, img := range images {
+		err := r.blobs.Put(coverKey(book, img.Size), storage.Blob{Data: img.Data, ContentType: img.ContentType})
+		if err != nil {
+			return res, err
+		}
+	}
+
+	now := time.Now()
+	err = r.db.Model(&book).Updates(map[string]interface{}{
+		"cover_etag":       etag,
+		"cover_updated_at": now,
+	}).Error
+	if err != nil {
+		return res, err
+	}
+
+	return r.GetBookById(book.ID)
+}
+
+func (r Repo) GetBookCover(id int64, size string) (res model.CoverImage, err error) {
+	if r.blobs == nil {
+		return res, ErrNoBlobStore
+	}
+
+	book := model.Book{}
+	if err := r.db.Where("id = ?", id).First(&book).Error; err != nil {
+		return res, err
+	}
+	if book.CoverUpdatedAt == nil {
+		return res, gorm.ErrRecordNotFound
+	}
+
+	blob, err := r.blobs.Get(coverKey(book, size))
+	if errors.Is(err, storage.ErrNotFound) {
+		return res, gorm.ErrRecordNotFound
+	}
+	if err != nil {
+		return res, err
+	}
+
+	return model.CoverImage{
+		Size:        size,
+		ContentType: blob.ContentType,
+		Data:        blob.Data,
+		ETag:        book.CoverETag + "-" + size,
+		UpdatedAt:   *book.CoverUpdatedAt,
+	}, nil
+}
+
+// deleteBookCover removes every rendition of a book cover
+func (r Repo) deleteBookCover(book model.Book) error {
+	if r.blobs == nil || book.CoverUpdatedAt == nil {
+		return nil
+	}
+
+	for _, size := range model.CoverSizes {
+		if err := r.blobs.Delete(coverKey(book, size)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func coverKey(book model.Book, size string) string {
+	return fmt.Sprintf("covers/%d/%d/%s", book.TenantID, book.ID, size)
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"fmt"
+	"ninth-learn/model"
+
+	"gorm.io/gorm"
+)
+
+// interface Duplicate
+type DuplicateRepo interface {
+	GetBookSummaries() ([]model.BookSummary, error)
+	GetDuplicateCandidates(book model.BookSummary) ([]model.BookSummary, error)
+	MergeBooks(sourceID, targetID int64) (res model.Book, err error)
+}
+
+func (r Repo) GetBookSummaries() ([]model.BookSummary, error) {
+	var books []model.BookSummary
+	err := r.db.Model(&model.Book{}).
+		Select("id, title, author, isbn_10, isbn_13").
+		Order("id").
+		Scan(&books).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return books, nil
+}
+
+// GetDuplicateCandidates narrows the books a new one may duplicate down to
+// those sharing an ISBN or whose title is similar by pg_trgm, the cut of
+// pg_trgm.similarity_threshold (0.3 by default) lies well below the one
+// books are matched on, so titles normalized apart are still found
+func (r Repo) GetDuplicateCandidates(book model.BookSummary) ([]model.BookSummary, error) {
+	isbn13s, isbn10s := []string{}, []string{}
+	for _, isbn := range []string{book.ISBN10, book.ISBN13} {
+		isbn13, err := model.CanonicalISBN(isbn)
+		if err != nil {
+			continue
+		}
+		isbn13s = append(isbn13s, isbn13)
+		if isbn10, ok := model.ISBN13To10(isbn13); ok {
+			isbn10s = append(isbn10s, isbn10)
+		}
+	}
+
+	match := r.db.Where("title % ?", book.Title)
+	if len(isbn13s) > 0 {
+		match = match.Or("isbn_13 IN ?", isbn13s)
+	}
+	if len(isbn10s) > 0 {
+		match = match.Or("isbn_10 IN ?", isbn10s)
+	}
+
+	var books []model.BookSummary
+	err := r.db.Model(&model.Book{}).
+		Select("id, title, author, isbn_10, isbn_13").
+		Where("id <> ?", book.ID).
+		Where(match).
+		Order("id").
+		Scan(&books).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return books, nil
+}
+
+// MergeBooks moves everything attached to the source book over to the
+// target, deletes the source and leaves a redirect from its ID
+func (r Repo) MergeBooks(sourceID, targetID int64) (res model.Book, err error) {
+	source := model.Book{}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var books []model.Book
+		if err := tx.Clauses(forUpdate).Where("id IN ?", []int64{sourceID, targetID}).Order("id").Find(&books).Error; err != nil {
+			return err
+		}
+		if len(books) != 2 {
+			return gorm.ErrRecordNotFound
+		}
+		for _, b := range books {
+			if b.ID == sourceID {
+				source = b
+			}
+		}
+
+		for _, link := range []string{"public.book_authors (book_id, author_id, role) SELECT ?, author_id, role FROM public.book_authors",
+			"public.book_categories (book_id, category_id) SELECT ?, category_id FROM public.book_categories",
+			"public.book_tags (book_id, tag_id) SELECT ?, tag_id FROM public.book_tags"} {
+			if err := tx.Exec("INSERT INTO "+link+" WHERE book_id = ? ON CONFLICT DO NOTHING", targetID, sourceID).Error; err != nil {
+				return err
+			}
+		}
+		for _, link := range []interface{}{&model.BookAuthor{}, &model.BookCategory{}, &model.BookTag{}} {
+			if err := tx.Where("book_id = ?", sourceID).Delete(link).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, moved := range []interface{}{&model.Copy{}, &model.Loan{}} {
+			if err := tx.Model(moved).Where("book_id = ?", sourceID).Update("book_id", targetID).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := r.mergeHolds(tx, sourceID, targetID); err != nil {
+			return err
+		}
+		if err := r.mergeReviews(tx, sourceID, targetID); err != nil {
+			return err
+		}
+		if err := r.mergeListEntries(tx, sourceID, targetID); err != nil {
+			return err
+		}
+		if err := r.mergeTranslations(tx, sourceID, targetID); err != nil {
+			return err
+		}
+		if err := r.mergeSeriesEntries(tx, sourceID, targetID); err != nil {
+			return err
+		}
+		if err := r.mergeRelations(tx, sourceID, targetID); err != nil {
+			return err
+		}
+		// the books that showed the source now show the target
+		if err := touchBooks(tx, targetID); err != nil {
+			return err
+		}
+		if err := touchNeighbours(tx, targetID); err != nil {
+			return err
+		}
+
+		// earlier redirects to the source now lead straight to the target
+		if err := tx.Model(&model.BookRedirect{}).Where("to_id = ?", sourceID).Update("to_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&model.BookRedirect{FromID: sourceID, TenantID: r.tenantID, ToID: targetID}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&source).Error; err != nil {
+			return err
+		}
+		if err := dateBookDelete(tx, source.TenantID); err != nil {
+			return err
+		}
+
+		if err := r.recordBookEvent(tx, model.BookDeleted, sourceID); err != nil {
+			return err
+		}
+		return r.recordBookEvent(tx, model.BookUpdated, targetID)
+	})
+	if err != nil {
+		return res, err
+	}
+
+	res, err = r.GetBookById(targetID)
+	if err != nil {
+		return res, err
+	}
+	if err := r.deleteBookCover(source); err != nil {
+		return res, fmt.Errorf("%w: %v", model.ErrCoverLeft, err)
+	}
+	return res, nil
+}
+
+// mergeHolds moves the hold queue of the source onto the target. a member
+// queued for both keeps one hold, a ready one wins over a waiting one.
+func (r Repo) mergeHolds(tx *gorm.DB, sourceID, targetID int64) error {
+	active := []string{model.HoldStatusWaiting, model.HoldStatusReady}
+
+	err := tx.Model(&model.Hold{}).
+		Where("book_id = ? AND status = ?", sourceID, model.HoldStatusWaiting).
+		Where("member_id IN (?)", tx.Model(&model.Hold{}).Select("member_id").Where("book_id = ? AND status IN ?", targetID, active)).
+		Update("status", model.HoldStatusCancelled).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Model(&model.Hold{}).
+		Where("book_id = ? AND status = ?", targetID, model.HoldStatusWaiting).
+		Where("member_id IN (?)", tx.Model(&model.Hold{}).Select("member_id").Where("book_id = ? AND status = ?", sourceID, model.HoldStatusReady)).
+		Update("status", model.HoldStatusCancelled).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&model.Hold{}).Where("book_id = ?", sourceID).Update("book_id", targetID).Error
+}
+
+// mergeReviews keeps the target's review of readers who reviewed both books
+func (r Repo) mergeReviews(tx *gorm.DB, sourceID, targetID int64) error {
+	err := tx.Where("book_id = ?", sourceID).
+		Where("reviewer IN (?)", tx.Model(&model.Review{}).Select("reviewer").Where("book_id = ?", targetID)).
+		Delete(&model.Review{}).Error
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Model(&model.Review{}).Where("book_id = ?", sourceID).Update("book_id", targetID).Error; err != nil {
+		return err
+	}
+	return r.recomputeRating(tx, targetID)
+}
+
+// mergeListEntries moves the source onto the target in reading lists, lists
+// that already hold the target drop the source
+func (r Repo) mergeListEntries(tx *gorm.DB, sourceID, targetID int64) error {
+	var both []model.ReadingListEntry
+	err := tx.Where("book_id = ?", sourceID).
+		Where("list_id IN (?)", tx.Model(&model.ReadingListEntry{}).Select("list_id").Where("book_id = ?", targetID)).
+		Find(&both).Error
+	if err != nil {
+		return err
+	}
+	if err := r.removeEntries(tx, both); err != nil {
+		return err
+	}
+
+	return tx.Model(&model.ReadingListEntry{}).Where("book_id = ?", sourceID).Update("book_id", targetID).Error
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"ninth-learn/model"
+
+	"gorm.io/gorm"
+)
+
+// interface Fine
+type FineRepo interface {
+	GetFineSummary(memberID int64) (res model.FineSummary, err error)
+	CreateFineTransaction(in model.FineTransaction) (res model.FineTransaction, err error)
+}
+
+// GetFineSummary totals the fines accrued by the member's loans against the
+// payments and waivers recorded for them
+func (r Repo) GetFineSummary(memberID int64) (res model.FineSummary, err error) {
+	return fineSummary(r.db, memberID)
+}
+
+func fineSummary(db *gorm.DB, memberID int64) (res model.FineSummary, err error) {
+	res.MemberID = memberID
+
+	err = db.Model(&model.Loan{}).
+		Select("COALESCE(SUM(fine_cents), 0)").
+		Where("member_id = ?", memberID).
+		Scan(&res.AccruedCents).Error
+	if err != nil {
+		return res, err
+	}
+
+	err = db.Where("member_id = ?", memberID).Order("created_at DESC").Find(&res.Transactions).Error
+	if err != nil {
+		return res, err
+	}
+
+	for _, t := range res.Transactions {
+		switch t.Kind {
+		case model.FineKindPayment:
+			res.PaidCents += t.AmountCents
+		case model.FineKindWaiver:
+			res.WaivedCents += t.AmountCents
+		}
+	}
+	res.BalanceCents = res.AccruedCents - res.PaidCents - res.WaivedCents
+
+	return res, nil
+}
+
+// CreateFineTransaction records a payment or waiver of at most the
+// outstanding balance. The member row stays locked until commit, so
+// concurrent payments cannot both settle the same balance.
+func (r Repo) CreateFineTransaction(in model.FineTransaction) (res model.FineTransaction, err error) {
+	in.TenantID = r.tenantID
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(forUpdate).Where("id = ?", in.MemberID).First(&model.Member{}).Error; err != nil {
+			return err
+		}
+
+		fines, err := fineSummary(tx, in.MemberID)
+		if err != nil {
+			return err
+		}
+		if in.AmountCents > fines.BalanceCents {
+			return model.ErrFineExceedsBalance
+		}
+
+		return tx.Create(&in).Error
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return in, nil
+}
@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"errors"
+	"ninth-learn/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// interface Hold
+type HoldRepo interface {
+	GetHolds(filter model.HoldFilter) ([]model.Hold, error)
+	GetHoldById(id int64) (res model.Hold, err error)
+	GetHoldPosition(bookID, memberID int64) (int64, error)
+	CountActiveHolds(memberID int64) (int64, error)
+	PlaceHold(bookID, memberID int64) (res model.Hold, err error)
+	CancelHold(id int64, pickupUntil time.Time) (res model.Hold, err error)
+	ExpireHolds(bookID int64, pickupUntil time.Time) (expired int64, err error)
+}
+
+func (r Repo) GetHolds(filter model.HoldFilter) ([]model.Hold, error) {
+	query := r.db.Preload("Member")
+
+	if filter.BookID != 0 {
+		query = query.Where("book_id = ?", filter.BookID)
+	}
+	if filter.MemberID != 0 {
+		query = query.Where("member_id = ?", filter.MemberID)
+	}
+	if filter.ActiveOnly {
+		query = query.Where("status IN ?", []string{model.HoldStatusWaiting, model.HoldStatusReady})
+	}
+
+	var holds []model.Hold
+	err := query.Order("placed_at, id").Find(&holds).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range holds {
+		if holds[i].Status != model.HoldStatusWaiting {
+			continue
+		}
+		if holds[i].Position, err = r.queuePosition(holds[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return holds, nil
+}
+
+func (r Repo) GetHoldById(id int64) (res model.Hold, err error) {
+	if err := r.db.Preload("Member").Where("id = ?", id).First(&res).Error; err != nil {
+		return res, err
+	}
+
+	if res.Status == model.HoldStatusWaiting {
+		if res.Position, err = r.queuePosition(res); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// GetHoldPosition returns the place of the member's waiting hold on the
+// book, 0 when the member is not queueing for it
+func (r Repo) GetHoldPosition(bookID, memberID int64) (int64, error) {
+	hold := model.Hold{}
+	err := r.db.Where("book_id = ? AND member_id = ? AND status = ?", bookID, memberID, model.HoldStatusWaiting).First(&hold).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return r.queuePosition(hold)
+}
+
+func (r Repo) CountActiveHolds(memberID int64) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Hold{}).
+		Where("member_id = ? AND status IN ?", memberID, []string{model.HoldStatusWaiting, model.HoldStatusReady}).
+		Count(&count).Error
+	return count, err
+}
+
+// PlaceHold queues the member for the book. Holds are only taken while every
+// copy is out, the book row stays locked so a concurrent return cannot slip
+// a copy back on the shelf unnoticed.
+func (r Repo) PlaceHold(bookID, memberID int64) (res model.Hold, err error) {
+	hold := model.Hold{}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, bookID); err != nil {
+			return err
+		}
+
+		member := model.Member{}
+		if err := tx.Where("id = ?", memberID).First(&member).Error; err != nil {
+			return err
+		}
+		if !member.IsActive() {
+			return model.ErrMemberInactive
+		}
+
+		var count int64
+		err := tx.Model(&model.Hold{}).
+			Where("book_id = ? AND member_id = ? AND status IN ?", bookID, memberID, []string{model.HoldStatusWaiting, model.HoldStatusReady}).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return model.ErrHoldExists
+		}
+
+		err = tx.Model(&model.Loan{}).
+			Where("book_id = ? AND member_id = ? AND returned_at IS NULL", bookID, memberID).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return model.ErrAlreadyBorrowed
+		}
+
+		copies := model.CopyCount{}
+		err = tx.Model(&model.Copy{}).
+			Select("COUNT(*) AS total, COUNT(*) FILTER (WHERE status = ?) AS available", model.CopyStatusAvailable).
+			Where("book_id = ?", bookID).
+			Scan(&copies).Error
+		if err != nil {
+			return err
+		}
+		if copies.Total == 0 {
+			return model.ErrBookHasNoCopies
+		}
+		if copies.Available > 0 {
+			return model.ErrCopyAvailableToLoan
+		}
+
+		hold = model.Hold{
+			TenantID: r.tenantID,
+			BookID:   bookID,
+			MemberID: memberID,
+			Status:   model.HoldStatusWaiting,
+			PlacedAt: time.Now(),
+		}
+		if err := tx.Create(&hold).Error; err != nil {
+			return err
+		}
+		return touchBooks(tx, bookID)
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return r.GetHoldById(hold.ID)
+}
+
+// CancelHold withdraws an active hold, a copy already set aside for it
+// passes to the next member in the queue
+func (r Repo) CancelHold(id int64, pickupUntil time.Time) (res model.Hold, err error) {
+	hold := model.Hold{}
+	if err := r.db.Where("id = ?", id).First(&hold).Error; err != nil {
+		return res, err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, hold.BookID); err != nil {
+			return err
+		}
+		if err := tx.Where("id = ?", id).First(&hold).Error; err != nil {
+			return err
+		}
+		if !hold.IsActive() {
+			return model.ErrHoldNotActive
+		}
+
+		if err := tx.Model(&hold).Update("status", model.HoldStatusCancelled).Error; err != nil {
+			return err
+		}
+		if err := touchBooks(tx, hold.BookID); err != nil {
+			return err
+		}
+		if hold.CopyID != nil {
+			return r.allocateCopy(tx, hold.BookID, *hold.CopyID, pickupUntil)
+		}
+		return nil
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return r.GetHoldById(id)
+}
+
+// ExpireHolds expires the ready holds not picked up in time and hands their
+// copies to the next member in line, bookID 0 covers every book
+func (r Repo) ExpireHolds(bookID int64, pickupUntil time.Time) (expired int64, err error) {
+	query := r.db.Model(&model.Hold{}).Where("status = ? AND expires_at < ?", model.HoldStatusReady, time.Now())
+	if bookID != 0 {
+		query = query.Where("book_id = ?", bookID)
+	}
+
+	var holds []model.Hold
+	if err := query.Find(&holds).Error; err != nil {
+		return 0, err
+	}
+
+	for _, hold := range holds {
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			if err := lockBook(tx, hold.BookID); err != nil {
+				return err
+			}
+
+			// the hold may have been picked up or cancelled meanwhile
+			result := tx.Model(&model.Hold{}).
+				Where("id = ? AND status = ?", hold.ID, model.HoldStatusReady).
+				Update("status", model.HoldStatusExpired)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+
+			expired++
+			if err := touchBooks(tx, hold.BookID); err != nil {
+				return err
+			}
+			if hold.CopyID != nil {
+				return r.allocateCopy(tx, hold.BookID, *hold.CopyID, pickupUntil)
+			}
+			return nil
+		})
+		if err != nil {
+			return expired, err
+		}
+	}
+
+	return expired, nil
+}
+
+// allocateCopy sets a copy aside for the next waiting hold on the book, or
+// puts it back on the shelf when nobody is queueing. The caller must hold
+// the book lock.
+func (r Repo) allocateCopy(tx *gorm.DB, bookID, copyID int64, pickupUntil time.Time) error {
+	next := model.Hold{}
+	err := tx.Clauses(forUpdate).
+		Where("book_id = ? AND status = ?", bookID, model.HoldStatusWaiting).
+		Order("placed_at, id").
+		First(&next).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return tx.Model(&model.Copy{}).Where("id = ?", copyID).Update("status", model.CopyStatusAvailable).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err = tx.Model(&next).Updates(map[string]interface{}{
+		"status":     model.HoldStatusReady,
+		"copy_id":    copyID,
+		"ready_at":   now,
+		"expires_at": pickupUntil,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&model.Copy{}).Where("id = ?", copyID).Update("status", model.CopyStatusOnHold).Error
+}
+
+// queuePosition counts the waiting holds placed before the given one
+func (r Repo) queuePosition(hold model.Hold) (int64, error) {
+	var ahead int64
+	err := r.db.Model(&model.Hold{}).
+		Where("book_id = ? AND status = ?", hold.BookID, model.HoldStatusWaiting).
+		Where("(placed_at, id) < (?, ?)", hold.PlacedAt, hold.ID).
+		Count(&ahead).Error
+	return ahead + 1, err
+}
+
+// lockBook serialises the hold queue and copy allocation of a book
+func lockBook(tx *gorm.DB, bookID int64) error {
+	return tx.Clauses(forUpdate).Select("id").Where("id = ?", bookID).First(&model.Book{}).Error
+}
@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"errors"
+	"ninth-learn/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// interface Loan
+type LoanRepo interface {
+	GetLoans(filter model.LoanFilter) ([]model.Loan, error)
+	GetLoanById(id int64) (res model.Loan, err error)
+	CountActiveLoans(memberID int64) (int64, error)
+	CheckoutCopy(bookID, copyID, memberID int64, dueAt time.Time, maxLoans int) (res model.Loan, err error)
+	ReturnLoan(id int64, pickupUntil time.Time) (res model.Loan, err error)
+	RenewLoan(id int64, dueAt time.Time, maxRenewals int, fineCents int64) (res model.Loan, err error)
+	UpdateLoanFine(loan model.Loan, fineCents int64) (updated bool, err error)
+}
+
+// forUpdate locks the selected rows until the transaction ends
+var forUpdate = clause.Locking{Strength: "UPDATE"}
+
+func (r Repo) GetLoans(filter model.LoanFilter) ([]model.Loan, error) {
+	query := r.db.Preload("Copy").Preload("Member")
+
+	if filter.MemberID != 0 {
+		query = query.Where("member_id = ?", filter.MemberID)
+	}
+	if filter.BookID != 0 {
+		query = query.Where("book_id = ?", filter.BookID)
+	}
+	if filter.ActiveOnly {
+		query = query.Where("returned_at IS NULL")
+	}
+	if filter.DueBefore != nil {
+		query = query.Where("due_at < ?", filter.DueBefore)
+	}
+
+	var loans []model.Loan
+	err := query.Order("borrowed_at DESC").Find(&loans).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return loans, nil
+}
+
+func (r Repo) GetLoanById(id int64) (res model.Loan, err error) {
+	if err := r.db.Preload("Copy").Preload("Member").Where("id = ?", id).First(&res).Error; err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+func (r Repo) CountActiveLoans(memberID int64) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Loan{}).Where("member_id = ? AND returned_at IS NULL", memberID).Count(&count).Error
+	return count, err
+}
+
+// CheckoutCopy lends a copy of the book to the member. A member with a ready
+// hold gets the copy set aside for them, otherwise the given copy or, without
+// a copy id, the first available one is taken. The member and copy rows stay
+// locked until commit, so concurrent checkouts can neither take the same copy
+// nor push a member past the loan limit.
+func (r Repo) CheckoutCopy(bookID, copyID, memberID int64, dueAt time.Time, maxLoans int) (res model.Loan, err error) {
+	loan := model.Loan{}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		member := model.Member{}
+		if err := tx.Clauses(forUpdate).Where("id = ?", memberID).First(&member).Error; err != nil {
+			return err
+		}
+		if !member.IsActive() {
+			return model.ErrMemberInactive
+		}
+		// the book is locked before its copies, the way returns and holds
+		// lock them
+		if err := lockBook(tx, bookID); err != nil {
+			return err
+		}
+
+		var open int64
+		if err := tx.Model(&model.Loan{}).Where("member_id = ? AND returned_at IS NULL", memberID).Count(&open).Error; err != nil {
+			return err
+		}
+		if open >= int64(maxLoans) {
+			return model.ErrLoanLimitReached
+		}
+
+		// a member collecting a hold takes the copy set aside for them
+		hold := model.Hold{}
+		err := tx.Clauses(forUpdate).
+			Where("book_id = ? AND member_id = ? AND status = ?", bookID, memberID, model.HoldStatusReady).
+			First(&hold).Error
+		switch {
+		case err == nil:
+			if err := tx.Model(&hold).Update("status", model.HoldStatusFulfilled).Error; err != nil {
+				return err
+			}
+			// a hold whose copy was deleted takes any copy on the shelf
+			if hold.CopyID != nil {
+				copyID = *hold.CopyID
+			}
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		cp := model.Copy{}
+		if hold.CopyID != nil {
+			if err := tx.Clauses(forUpdate).Where("id = ?", copyID).First(&cp).Error; err != nil {
+				return err
+			}
+		} else if copyID != 0 {
+			if err := tx.Clauses(forUpdate).Where("book_id = ? AND id = ?", bookID, copyID).First(&cp).Error; err != nil {
+				return err
+			}
+			if cp.Status != model.CopyStatusAvailable {
+				return model.ErrCopyUnavailable
+			}
+		} else {
+			// skip copies locked by other checkouts instead of waiting on them
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+				Where("book_id = ? AND status = ?", bookID, model.CopyStatusAvailable).
+				Order("id").
+				First(&cp).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.ErrNoCopyAvailable
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&cp).Update("status", model.CopyStatusOnLoan).Error; err != nil {
+			return err
+		}
+
+		loan = model.Loan{
+			TenantID:   r.tenantID,
+			CopyID:     cp.ID,
+			BookID:     bookID,
+			MemberID:   member.ID,
+			BorrowedAt: time.Now(),
+			DueAt:      dueAt,
+		}
+		if err := tx.Create(&loan).Error; err != nil {
+			return err
+		}
+		return touchBooks(tx, bookID)
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return r.GetLoanById(loan.ID)
+}
+
+// ReturnLoan closes the loan and hands the copy to the next hold on the
+// book, or puts it back on the shelf
+func (r Repo) ReturnLoan(id int64, pickupUntil time.Time) (res model.Loan, err error) {
+	loan := model.Loan{}
+	if err := r.db.Where("id = ?", id).First(&loan).Error; err != nil {
+		return res, err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, loan.BookID); err != nil {
+			return err
+		}
+		if err := tx.Clauses(forUpdate).Where("id = ?", id).First(&loan).Error; err != nil {
+			return err
+		}
+		if !loan.IsActive() {
+			return model.ErrLoanReturned
+		}
+
+		if err := tx.Model(&loan).Update("returned_at", time.Now()).Error; err != nil {
+			return err
+		}
+		if err := touchBooks(tx, loan.BookID); err != nil {
+			return err
+		}
+
+		// leave copies alone that were marked lost or sent to repair meanwhile
+		cp := model.Copy{}
+		if err := tx.Clauses(forUpdate).Where("id = ?", loan.CopyID).First(&cp).Error; err != nil {
+			return err
+		}
+		if cp.Status != model.CopyStatusOnLoan {
+			return nil
+		}
+		return r.allocateCopy(tx, loan.BookID, cp.ID, pickupUntil)
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return r.GetLoanById(id)
+}
+
+// RenewLoan moves the due date of an open loan, up to maxRenewals times.
+// The fine charged up to the renewal is carried over to the new period.
+func (r Repo) RenewLoan(id int64, dueAt time.Time, maxRenewals int, fineCents int64) (res model.Loan, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		loan := model.Loan{}
+		if err := tx.Clauses(forUpdate).Where("id = ?", id).First(&loan).Error; err != nil {
+			return err
+		}
+		if !loan.IsActive() {
+			return model.ErrLoanReturned
+		}
+		if loan.Renewals >= maxRenewals {
+			return model.ErrRenewalLimitReached
+		}
+
+		// fines charged so far stay, the loan is no longer late
+		if fineCents < loan.FineCents {
+			fineCents = loan.FineCents
+		}
+		return tx.Model(&loan).Updates(map[string]interface{}{
+			"due_at":             dueAt,
+			"renewals":           loan.Renewals + 1,
+			"overdue":            false,
+			"fine_cents":         fineCents,
+			"carried_fine_cents": fineCents,
+		}).Error
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return r.GetLoanById(id)
+}
+
+// keepLoans refuses to drop the loans matching the query while any is still
+// out or was fined, the fines ledger of their members is made of them
+func keepLoans(tx *gorm.DB, query string, args ...interface{}) error {
+	var active, fined int64
+	if err := tx.Model(&model.Loan{}).Where(query, args...).Where("returned_at IS NULL").Count(&active).Error; err != nil {
+		return err
+	}
+	if active > 0 {
+		return model.ErrLoansActive
+	}
+	if err := tx.Model(&model.Loan{}).Where(query, args...).Where("fine_cents > 0").Count(&fined).Error; err != nil {
+		return err
+	}
+	if fined > 0 {
+		return model.ErrLoansFined
+	}
+	return nil
+}
+
+// UpdateLoanFine marks the loan overdue with the fine worked out from the
+// loan as it was read. a loan renewed or returned since is left alone, its
+// fine no longer holds, updated is false then
+func (r Repo) UpdateLoanFine(loan model.Loan, fineCents int64) (updated bool, err error) {
+	query := r.db.Model(&model.Loan{}).Where("id = ? AND due_at = ?", loan.ID, loan.DueAt)
+	if loan.ReturnedAt == nil {
+		query = query.Where("returned_at IS NULL")
+	} else {
+		query = query.Where("returned_at = ?", *loan.ReturnedAt)
+	}
+
+	res := query.Updates(map[string]interface{}{
+		"overdue":    true,
+		"fine_cents": fineCents,
+	})
+	return res.RowsAffected > 0, res.Error
+}
@@ -0,0 +1,102 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"ninth-learn/model"
+	"ninth-learn/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// statementRecorder stands in for the database, it keeps the statements it
+// is sent and reports affected rows for them
+type statementRecorder struct {
+	affected   int64
+	statements []string
+	args       [][]interface{}
+}
+
+func (r *statementRecorder) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	r.statements = append(r.statements, query)
+	r.args = append(r.args, args)
+	return driver.RowsAffected(r.affected), nil
+}
+
+func (r *statementRecorder) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (r *statementRecorder) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New("not supported")
+}
+
+func (r *statementRecorder) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+
+func recordedRepo(t *testing.T, affected int64) (repository.RepoInterface, *statementRecorder) {
+	recorder := &statementRecorder{affected: affected}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: recorder}), &gorm.Config{SkipDefaultTransaction: true, DisableAutomaticPing: true})
+	assert.NoError(t, err)
+	return repository.NewRepo(db, nil).WithTenant(1), recorder
+}
+
+func Test_Repo_UpdateLoanFine(t *testing.T) {
+	due := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	returned := due.AddDate(0, 0, 3)
+
+	tests := []struct {
+		name        string
+		loan        model.Loan
+		affected    int64
+		wantUpdated bool
+		wantWhere   string
+		wantArgs    []interface{}
+	}{
+		{
+			name:        "open loan",
+			loan:        model.Loan{ID: 7, DueAt: due},
+			affected:    1,
+			wantUpdated: true,
+			wantWhere:   `SET "fine_cents"=$1,"overdue"=$2,"updated_at"=$3 WHERE (id = $4 AND due_at = $5) AND returned_at IS NULL AND "loans"."tenant_id" = $6`,
+			wantArgs:    []interface{}{int64(7), due, int64(1)},
+		},
+		{
+			// RenewLoan moved the due date on, or the loan came back
+			name:     "renewed or returned meanwhile",
+			loan:     model.Loan{ID: 7, DueAt: due},
+			affected: 0,
+		},
+		{
+			name:        "returned loan",
+			loan:        model.Loan{ID: 7, DueAt: due, ReturnedAt: &returned},
+			affected:    1,
+			wantUpdated: true,
+			wantWhere:   `SET "fine_cents"=$1,"overdue"=$2,"updated_at"=$3 WHERE (id = $4 AND due_at = $5) AND returned_at = $6 AND "loans"."tenant_id" = $7`,
+			wantArgs:    []interface{}{int64(7), due, returned, int64(1)},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, recorder := recordedRepo(t, tc.affected)
+
+			updated, err := repo.UpdateLoanFine(tc.loan, 75)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantUpdated, updated)
+			assert.Len(t, recorder.statements, 1)
+			if tc.wantWhere != "" {
+				assert.Contains(t, recorder.statements[0], tc.wantWhere)
+				assert.Equal(t, []interface{}{int64(75), true}, recorder.args[0][:2])
+				// the loan as it was read, past the updated_at set
+				assert.Equal(t, tc.wantArgs, recorder.args[0][3:])
+			}
+		})
+	}
+}
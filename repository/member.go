@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"ninth-learn/model"
+
+	"gorm.io/gorm"
+)
+
+// interface Member
+type MemberRepo interface {
+	GetMembers() ([]model.Member, error)
+	CreateMember(in model.Member) (res model.Member, err error)
+	GetMemberById(id int64) (res model.Member, err error)
+	UpdateMember(in model.Member) (res model.Member, err error)
+	DeleteMember(id int64) (err error)
+}
+
+func (r Repo) GetMembers() ([]model.Member, error) {
+	var members []model.Member
+	err := r.db.Order("name").Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (r Repo) CreateMember(in model.Member) (res model.Member, err error) {
+	in.TenantID = r.tenantID
+
+	result := r.db.Create(&in)
+	if result.Error != nil {
+		return res, result.Error
+	}
+
+	return in, nil
+}
+
+func (r Repo) GetMemberById(id int64) (res model.Member, err error) {
+	if err := r.db.Where("id = ?", id).First(&res).Error; err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+func (r Repo) UpdateMember(in model.Member) (res model.Member, err error) {
+	// Find the member to update
+	member := model.Member{}
+	if err := r.db.Where("id = ?", in.ID).First(&member).Error; err != nil {
+		return in, err
+	}
+
+	// Update the member
+	member.Name = in.Name
+	member.Email = in.Email
+	member.Phone = in.Phone
+	member.Status = in.Status
+
+	err = r.db.Save(&member).Error
+	if err != nil {
+		return res, err
+	}
+
+	res = member
+	return res, nil
+}
+
+func (r Repo) DeleteMember(id int64) (err error) {
+	// Find the member to delete
+	member := model.Member{}
+	if err := r.db.Where("id = ?", id).First(&member).Error; err != nil {
+		return err
+	}
+
+	// Drop the loan, hold and fine history of the member, then the member.
+	// The member row stays locked so no checkout or fine comes in meanwhile.
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(forUpdate).Where("id = ?", member.ID).First(&model.Member{}).Error; err != nil {
+			return err
+		}
+		var active int64
+		if err := tx.Model(&model.Loan{}).Where("member_id = ? AND returned_at IS NULL", member.ID).Count(&active).Error; err != nil {
+			return err
+		}
+		if active > 0 {
+			return model.ErrLoansActive
+		}
+		fines, err := fineSummary(tx, member.ID)
+		if err != nil {
+			return err
+		}
+		if fines.BalanceCents > 0 {
+			return model.ErrFinesOwed
+		}
+
+		for _, history := range []interface{}{&model.Loan{}, &model.Hold{}, &model.FineTransaction{}} {
+			if err := tx.Where("member_id = ?", member.ID).Delete(history).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&member).Error
+	})
+}
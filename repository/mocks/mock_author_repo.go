@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ninth-learn/repository (interfaces: AuthorRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "ninth-learn/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuthorRepo is a mock of AuthorRepo interface.
+type MockAuthorRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorRepoMockRecorder
+}
+
+// MockAuthorRepoMockRecorder is the mock recorder for MockAuthorRepo.
+type MockAuthorRepoMockRecorder struct {
+	mock *MockAuthorRepo
+}
+
+// NewMockAuthorRepo creates a new mock instance.
+func NewMockAuthorRepo(ctrl *gomock.Controller) *MockAuthorRepo {
+	mock := &MockAuthorRepo{ctrl: ctrl}
+	mock.recorder = &MockAuthorRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorRepo) EXPECT() *MockAuthorRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateAuthor mocks base method.
+func (m *MockAuthorRepo) CreateAuthor(arg0 model.Author) (model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuthor", arg0)
+	ret0, _ := ret[0].(model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuthor indicates an expected call of CreateAuthor.
+func (mr *MockAuthorRepoMockRecorder) CreateAuthor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuthor", reflect.TypeOf((*MockAuthorRepo)(nil).CreateAuthor), arg0)
+}
+
+// DeleteAuthor mocks base method.
+func (m *MockAuthorRepo) DeleteAuthor(arg0 int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAuthor", arg0)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAuthor indicates an expected call of DeleteAuthor.
+func (mr *MockAuthorRepoMockRecorder) DeleteAuthor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuthor", reflect.TypeOf((*MockAuthorRepo)(nil).DeleteAuthor), arg0)
+}
+
+// GetAuthorById mocks base method.
+func (m *MockAuthorRepo) GetAuthorById(arg0 int64) (model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorById", arg0)
+	ret0, _ := ret[0].(model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorById indicates an expected call of GetAuthorById.
+func (mr *MockAuthorRepoMockRecorder) GetAuthorById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorById", reflect.TypeOf((*MockAuthorRepo)(nil).GetAuthorById), arg0)
+}
+
+// GetAuthorByName mocks base method.
+func (m *MockAuthorRepo) GetAuthorByName(arg0 string) (model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorByName", arg0)
+	ret0, _ := ret[0].(model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorByName indicates an expected call of GetAuthorByName.
+func (mr *MockAuthorRepoMockRecorder) GetAuthorByName(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorByName", reflect.TypeOf((*MockAuthorRepo)(nil).GetAuthorByName), arg0)
+}
+
+// GetAuthors mocks base method.
+func (m *MockAuthorRepo) GetAuthors() ([]model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthors")
+	ret0, _ := ret[0].([]model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthors indicates an expected call of GetAuthors.
+func (mr *MockAuthorRepoMockRecorder) GetAuthors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthors", reflect.TypeOf((*MockAuthorRepo)(nil).GetAuthors))
+}
+
+// GetBooksByAuthor mocks base method.
+func (m *MockAuthorRepo) GetBooksByAuthor(arg0 int64) ([]model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBooksByAuthor", arg0)
+	ret0, _ := ret[0].([]model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBooksByAuthor indicates an expected call of GetBooksByAuthor.
+func (mr *MockAuthorRepoMockRecorder) GetBooksByAuthor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBooksByAuthor", reflect.TypeOf((*MockAuthorRepo)(nil).GetBooksByAuthor), arg0)
+}
+
+// UpdateAuthor mocks base method.
+func (m *MockAuthorRepo) UpdateAuthor(arg0 model.Author) (model.Author, []int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAuthor", arg0)
+	ret0, _ := ret[0].(model.Author)
+	ret1, _ := ret[1].([]int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateAuthor indicates an expected call of UpdateAuthor.
+func (mr *MockAuthorRepoMockRecorder) UpdateAuthor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthor", reflect.TypeOf((*MockAuthorRepo)(nil).UpdateAuthor), arg0)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	model "ninth-learn/model"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -34,6 +35,36 @@ func (m *MockBookRepo) EXPECT() *MockBookRepoMockRecorder {
 	return m.recorder
 }
 
+// BooksModifiedAt mocks base method.
+func (m *MockBookRepo) BooksModifiedAt() (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BooksModifiedAt")
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BooksModifiedAt indicates an expected call of BooksModifiedAt.
+func (mr *MockBookRepoMockRecorder) BooksModifiedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BooksModifiedAt", reflect.TypeOf((*MockBookRepo)(nil).BooksModifiedAt))
+}
+
+// CountBooks mocks base method.
+func (m *MockBookRepo) CountBooks(arg0 model.BookFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBooks", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBooks indicates an expected call of CountBooks.
+func (mr *MockBookRepoMockRecorder) CountBooks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBooks", reflect.TypeOf((*MockBookRepo)(nil).CountBooks), arg0)
+}
+
 // CreateBook mocks base method.
 func (m *MockBookRepo) CreateBook(arg0 model.Book) (model.Book, error) {
 	m.ctrl.T.Helper()
@@ -50,17 +81,32 @@ func (mr *MockBookRepoMockRecorder) CreateBook(arg0 interface{}) *gomock.Call {
 }
 
 // DeleteBook mocks base method.
-func (m *MockBookRepo) DeleteBook(arg0 int64) error {
+func (m *MockBookRepo) DeleteBook(arg0 int64, arg1 bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteBook", arg0)
+	ret := m.ctrl.Call(m, "DeleteBook", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteBook indicates an expected call of DeleteBook.
-func (mr *MockBookRepoMockRecorder) DeleteBook(arg0 interface{}) *gomock.Call {
+func (mr *MockBookRepoMockRecorder) DeleteBook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBook", reflect.TypeOf((*MockBookRepo)(nil).DeleteBook), arg0, arg1)
+}
+
+// GetBookByISBN mocks base method.
+func (m *MockBookRepo) GetBookByISBN(arg0 string) (model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookByISBN", arg0)
+	ret0, _ := ret[0].(model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookByISBN indicates an expected call of GetBookByISBN.
+func (mr *MockBookRepoMockRecorder) GetBookByISBN(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBook", reflect.TypeOf((*MockBookRepo)(nil).DeleteBook), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookByISBN", reflect.TypeOf((*MockBookRepo)(nil).GetBookByISBN), arg0)
 }
 
 // GetBookById mocks base method.
@@ -79,18 +125,33 @@ func (mr *MockBookRepoMockRecorder) GetBookById(arg0 interface{}) *gomock.Call {
 }
 
 // GetBooks mocks base method.
-func (m *MockBookRepo) GetBooks() ([]model.Book, error) {
+func (m *MockBookRepo) GetBooks(arg0 model.BookFilter) ([]model.Book, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBooks")
+	ret := m.ctrl.Call(m, "GetBooks", arg0)
 	ret0, _ := ret[0].([]model.Book)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetBooks indicates an expected call of GetBooks.
-func (mr *MockBookRepoMockRecorder) GetBooks() *gomock.Call {
+func (mr *MockBookRepoMockRecorder) GetBooks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBooks", reflect.TypeOf((*MockBookRepo)(nil).GetBooks), arg0)
+}
+
+// GetBooksByIds mocks base method.
+func (m *MockBookRepo) GetBooksByIds(arg0 []int64) ([]model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBooksByIds", arg0)
+	ret0, _ := ret[0].([]model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBooksByIds indicates an expected call of GetBooksByIds.
+func (mr *MockBookRepoMockRecorder) GetBooksByIds(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBooks", reflect.TypeOf((*MockBookRepo)(nil).GetBooks))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBooksByIds", reflect.TypeOf((*MockBookRepo)(nil).GetBooksByIds), arg0)
 }
 
 // UpdateBook mocks base method.
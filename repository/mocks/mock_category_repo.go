@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ninth-learn/repository (interfaces: CategoryRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "ninth-learn/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCategoryRepo is a mock of CategoryRepo interface.
+type MockCategoryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryRepoMockRecorder
+}
+
+// MockCategoryRepoMockRecorder is the mock recorder for MockCategoryRepo.
+type MockCategoryRepoMockRecorder struct {
+	mock *MockCategoryRepo
+}
+
+// NewMockCategoryRepo creates a new mock instance.
+func NewMockCategoryRepo(ctrl *gomock.Controller) *MockCategoryRepo {
+	mock := &MockCategoryRepo{ctrl: ctrl}
+	mock.recorder = &MockCategoryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryRepo) EXPECT() *MockCategoryRepoMockRecorder {
+	return m.recorder
+}
+
+// CountCategoryChildren mocks base method.
+func (m *MockCategoryRepo) CountCategoryChildren(arg0 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCategoryChildren", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCategoryChildren indicates an expected call of CountCategoryChildren.
+func (mr *MockCategoryRepoMockRecorder) CountCategoryChildren(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCategoryChildren", reflect.TypeOf((*MockCategoryRepo)(nil).CountCategoryChildren), arg0)
+}
+
+// CreateCategory mocks base method.
+func (m *MockCategoryRepo) CreateCategory(arg0 model.Category) (model.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", arg0)
+	ret0, _ := ret[0].(model.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockCategoryRepoMockRecorder) CreateCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCategoryRepo)(nil).CreateCategory), arg0)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockCategoryRepo) DeleteCategory(arg0 int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockCategoryRepoMockRecorder) DeleteCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryRepo)(nil).DeleteCategory), arg0)
+}
+
+// GetCategories mocks base method.
+func (m *MockCategoryRepo) GetCategories() ([]model.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategories")
+	ret0, _ := ret[0].([]model.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategories indicates an expected call of GetCategories.
+func (mr *MockCategoryRepoMockRecorder) GetCategories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockCategoryRepo)(nil).GetCategories))
+}
+
+// GetCategoryById mocks base method.
+func (m *MockCategoryRepo) GetCategoryById(arg0 int64) (model.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryById", arg0)
+	ret0, _ := ret[0].(model.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryById indicates an expected call of GetCategoryById.
+func (mr *MockCategoryRepoMockRecorder) GetCategoryById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryById", reflect.TypeOf((*MockCategoryRepo)(nil).GetCategoryById), arg0)
+}
+
+// GetCategoryDescendantIds mocks base method.
+func (m *MockCategoryRepo) GetCategoryDescendantIds(arg0 int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryDescendantIds", arg0)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryDescendantIds indicates an expected call of GetCategoryDescendantIds.
+func (mr *MockCategoryRepoMockRecorder) GetCategoryDescendantIds(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryDescendantIds", reflect.TypeOf((*MockCategoryRepo)(nil).GetCategoryDescendantIds), arg0)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockCategoryRepo) UpdateCategory(arg0 model.Category) (model.Category, []int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", arg0)
+	ret0, _ := ret[0].(model.Category)
+	ret1, _ := ret[1].([]int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockCategoryRepoMockRecorder) UpdateCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryRepo)(nil).UpdateCategory), arg0)
+}
@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ninth-learn/repository (interfaces: CopyRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "ninth-learn/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCopyRepo is a mock of CopyRepo interface.
+type MockCopyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCopyRepoMockRecorder
+}
+
+// MockCopyRepoMockRecorder is the mock recorder for MockCopyRepo.
+type MockCopyRepoMockRecorder struct {
+	mock *MockCopyRepo
+}
+
+// NewMockCopyRepo creates a new mock instance.
+func NewMockCopyRepo(ctrl *gomock.Controller) *MockCopyRepo {
+	mock := &MockCopyRepo{ctrl: ctrl}
+	mock.recorder = &MockCopyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCopyRepo) EXPECT() *MockCopyRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateCopy mocks base method.
+func (m *MockCopyRepo) CreateCopy(arg0 model.Copy) (model.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCopy", arg0)
+	ret0, _ := ret[0].(model.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCopy indicates an expected call of CreateCopy.
+func (mr *MockCopyRepoMockRecorder) CreateCopy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCopy", reflect.TypeOf((*MockCopyRepo)(nil).CreateCopy), arg0)
+}
+
+// DeleteCopy mocks base method.
+func (m *MockCopyRepo) DeleteCopy(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCopy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCopy indicates an expected call of DeleteCopy.
+func (mr *MockCopyRepoMockRecorder) DeleteCopy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCopy", reflect.TypeOf((*MockCopyRepo)(nil).DeleteCopy), arg0, arg1)
+}
+
+// FillAvailability mocks base method.
+func (m *MockCopyRepo) FillAvailability(arg0 []model.Book) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FillAvailability", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FillAvailability indicates an expected call of FillAvailability.
+func (mr *MockCopyRepoMockRecorder) FillAvailability(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FillAvailability", reflect.TypeOf((*MockCopyRepo)(nil).FillAvailability), arg0)
+}
+
+// GetCopies mocks base method.
+func (m *MockCopyRepo) GetCopies(arg0 int64) ([]model.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCopies", arg0)
+	ret0, _ := ret[0].([]model.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCopies indicates an expected call of GetCopies.
+func (mr *MockCopyRepoMockRecorder) GetCopies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCopies", reflect.TypeOf((*MockCopyRepo)(nil).GetCopies), arg0)
+}
+
+// GetCopyByBarcode mocks base method.
+func (m *MockCopyRepo) GetCopyByBarcode(arg0 string) (model.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCopyByBarcode", arg0)
+	ret0, _ := ret[0].(model.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCopyByBarcode indicates an expected call of GetCopyByBarcode.
+func (mr *MockCopyRepoMockRecorder) GetCopyByBarcode(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCopyByBarcode", reflect.TypeOf((*MockCopyRepo)(nil).GetCopyByBarcode), arg0)
+}
+
+// GetCopyById mocks base method.
+func (m *MockCopyRepo) GetCopyById(arg0, arg1 int64) (model.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCopyById", arg0, arg1)
+	ret0, _ := ret[0].(model.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCopyById indicates an expected call of GetCopyById.
+func (mr *MockCopyRepoMockRecorder) GetCopyById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCopyById", reflect.TypeOf((*MockCopyRepo)(nil).GetCopyById), arg0, arg1)
+}
+
+// UpdateCopy mocks base method.
+func (m *MockCopyRepo) UpdateCopy(arg0 model.Copy) (model.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCopy", arg0)
+	ret0, _ := ret[0].(model.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCopy indicates an expected call of UpdateCopy.
+func (mr *MockCopyRepoMockRecorder) UpdateCopy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCopy", reflect.TypeOf((*MockCopyRepo)(nil).UpdateCopy), arg0)
+}
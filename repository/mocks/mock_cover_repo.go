@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ninth-learn/repository (interfaces: CoverRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "ninth-learn/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCoverRepo is a mock of CoverRepo interface.
+type MockCoverRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCoverRepoMockRecorder
+}
+
+// MockCoverRepoMockRecorder is the mock recorder for MockCoverRepo.
+type MockCoverRepoMockRecorder struct {
+	mock *MockCoverRepo
+}
+
+// NewMockCoverRepo creates a new mock instance.
+func NewMockCoverRepo(ctrl *gomock.Controller) *MockCoverRepo {
+	mock := &MockCoverRepo{ctrl: ctrl}
+	mock.recorder = &MockCoverRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCoverRepo) EXPECT() *MockCoverRepoMockRecorder {
+	return m.recorder
+}
+
+// GetBookCover mocks base method.
+func (m *MockCoverRepo) GetBookCover(arg0 int64, arg1 string) (model.CoverImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookCover", arg0, arg1)
+	ret0, _ := ret[0].(model.CoverImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookCover indicates an expected call of GetBookCover.
+func (mr *MockCoverRepoMockRecorder) GetBookCover(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookCover", reflect.TypeOf((*MockCoverRepo)(nil).GetBookCover), arg0, arg1)
+}
+
+// SaveBookCover mocks base method.
+func (m *MockCoverRepo) SaveBookCover(arg0 int64, arg1 string, arg2 []model.CoverImage) (model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBookCover", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveBookCover indicates an expected call of SaveBookCover.
+func (mr *MockCoverRepoMockRecorder) SaveBookCover(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBookCover", reflect.TypeOf((*MockCoverRepo)(nil).SaveBookCover), arg0, arg1, arg2)
+}
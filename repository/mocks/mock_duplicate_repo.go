@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ninth-learn/repository (interfaces: DuplicateRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "ninth-learn/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDuplicateRepo is a mock of DuplicateRepo interface.
+type MockDuplicateRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockDuplicateRepoMockRecorder
+}
+
+// MockDuplicateRepoMockRecorder is the mock recorder for MockDuplicateRepo.
+type MockDuplicateRepoMockRecorder struct {
+	mock *MockDuplicateRepo
+}
+
+// NewMockDuplicateRepo creates a new mock instance.
+func NewMockDuplicateRepo(ctrl *gomock.Controller) *MockDuplicateRepo {
+	mock := &MockDuplicateRepo{ctrl: ctrl}
+	mock.recorder = &MockDuplicateRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDuplicateRepo) EXPECT() *MockDuplicateRepoMockRecorder {
+	return m.recorder
+}
+
+// GetBookSummaries mocks base method.
+func (m *MockDuplicateRepo) GetBookSummaries() ([]model.BookSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookSummaries")
+	ret0, _ := ret[0].([]model.BookSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookSummaries indicates an expected call of GetBookSummaries.
+func (mr *MockDuplicateRepoMockRecorder) GetBookSummaries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookSummaries", reflect.TypeOf((*MockDuplicateRepo)(nil).GetBookSummaries))
+}
+
+// GetDuplicateCandidates mocks base method.
+func (m *MockDuplicateRepo) GetDuplicateCandidates(arg0 model.BookSummary) ([]model.BookSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuplicateCandidates", arg0)
+	ret0, _ := ret[0].([]model.BookSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuplicateCandidates indicates an expected call of GetDuplicateCandidates.
+func (mr *MockDuplicateRepoMockRecorder) GetDuplicateCandidates(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicateCandidates", reflect.TypeOf((*MockDuplicateRepo)(nil).GetDuplicateCandidates), arg0)
+}
+
+// MergeBooks mocks base method.
+func (m *MockDuplicateRepo) MergeBooks(arg0, arg1 int64) (model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeBooks", arg0, arg1)
+	ret0, _ := ret[0].(model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeBooks indicates an expected call of MergeBooks.
+func (mr *MockDuplicateRepoMockRecorder) MergeBooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeBooks", reflect.TypeOf((*MockDuplicateRepo)(nil).MergeBooks), arg0, arg1)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ninth-learn/repository (interfaces: FineRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "ninth-learn/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFineRepo is a mock of FineRepo interface.
+type MockFineRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockFineRepoMockRecorder
+}
+
+// MockFineRepoMockRecorder is the mock recorder for MockFineRepo.
+type MockFineRepoMockRecorder struct {
+	mock *MockFineRepo
+}
+
+// NewMockFineRepo creates a new mock instance.
+func NewMockFineRepo(ctrl *gomock.Controller) *MockFineRepo {
+	mock := &MockFineRepo{ctrl: ctrl}
+	mock.recorder = &MockFineRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFineRepo) EXPECT() *MockFineRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateFineTransaction mocks base method.
+func (m *MockFineRepo) CreateFineTransaction(arg0 model.FineTransaction) (model.FineTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFineTransaction", arg0)
+	ret0, _ := ret[0].(model.FineTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFineTransaction indicates an expected call of CreateFineTransaction.
+func (mr *MockFineRepoMockRecorder) CreateFineTransaction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFineTransaction", reflect.TypeOf((*MockFineRepo)(nil).CreateFineTransaction), arg0)
+}
+
+// GetFineSummary mocks base method.
+func (m *MockFineRepo) GetFineSummary(arg0 int64) (model.FineSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFineSummary", arg0)
+	ret0, _ := ret[0].(model.FineSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFineSummary indicates an expected call of GetFineSummary.
+func (mr *MockFineRepoMockRecorder) GetFineSummary(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFineSummary", reflect.TypeOf((*MockFineRepo)(nil).GetFineSummary), arg0)
+}
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ninth-learn/repository (interfaces: HoldRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "ninth-learn/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockHoldRepo is a mock of HoldRepo interface.
+type MockHoldRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldRepoMockRecorder
+}
+
+// MockHoldRepoMockRecorder is the mock recorder for MockHoldRepo.
+type MockHoldRepoMockRecorder struct {
+	mock *MockHoldRepo
+}
+
+// NewMockHoldRepo creates a new mock instance.
+func NewMockHoldRepo(ctrl *gomock.Controller) *MockHoldRepo {
+	mock := &MockHoldRepo{ctrl: ctrl}
+	mock.recorder = &MockHoldRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldRepo) EXPECT() *MockHoldRepoMockRecorder {
+	return m.recorder
+}
+
+// CancelHold mocks base method.
+func (m *MockHoldRepo) CancelHold(arg0 int64, arg1 time.Time) (model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelHold", arg0, arg1)
+	ret0, _ := ret[0].(model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelHold indicates an expected call of CancelHold.
+func (mr *MockHoldRepoMockRecorder) CancelHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelHold", reflect.TypeOf((*MockHoldRepo)(nil).CancelHold), arg0, arg1)
+}
+
+// CountActiveHolds mocks base method.
+func (m *MockHoldRepo) CountActiveHolds(arg0 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveHolds", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveHolds indicates an expected call of CountActiveHolds.
+func (mr *MockHoldRepoMockRecorder) CountActiveHolds(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveHolds", reflect.TypeOf((*MockHoldRepo)(nil).CountActiveHolds), arg0)
+}
+
+// ExpireHolds mocks base method.
+func (m *MockHoldRepo) ExpireHolds(arg0 int64, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireHolds", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireHolds indicates an expected call of ExpireHolds.
+func (mr *MockHoldRepoMockRecorder) ExpireHolds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireHolds", reflect.TypeOf((*MockHoldRepo)(nil).ExpireHolds), arg0, arg1)
+}
+
+// GetHoldById mocks base method.
+func (m *MockHoldRepo) GetHoldById(arg0 int64) (model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHoldById", arg0)
+	ret0, _ := ret[0].(model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHoldById indicates an expected call of GetHoldById.
+func (mr *MockHoldRepoMockRecorder) GetHoldById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHoldById", reflect.TypeOf((*MockHoldRepo)(nil).GetHoldById), arg0)
+}
+
+// GetHoldPosition mocks base method.
+func (m *MockHoldRepo) GetHoldPosition(arg0, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHoldPosition", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHoldPosition indicates an expected call of GetHoldPosition.
+func (mr *MockHoldRepoMockRecorder) GetHoldPosition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHoldPosition", reflect.TypeOf((*MockHoldRepo)(nil).GetHoldPosition), arg0, arg1)
+}
+
+// GetHolds mocks base method.
+func (m *MockHoldRepo) GetHolds(arg0 model.HoldFilter) ([]model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHolds", arg0)
+	ret0, _ := ret[0].([]model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHolds indicates an expected call of GetHolds.
+func (mr *MockHoldRepoMockRecorder) GetHolds(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHolds", reflect.TypeOf((*MockHoldRepo)(nil).GetHolds), arg0)
+}
+
+// PlaceHold mocks base method.
+func (m *MockHoldRepo) PlaceHold(arg0, arg1 int64) (model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceHold", arg0, arg1)
+	ret0, _ := ret[0].(model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceHold indicates an expected call of PlaceHold.
+func (mr *MockHoldRepoMockRecorder) PlaceHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceHold", reflect.TypeOf((*MockHoldRepo)(nil).PlaceHold), arg0, arg1)
+}
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ninth-learn/repository (interfaces: LoanRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "ninth-learn/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLoanRepo is a mock of LoanRepo interface.
+type MockLoanRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoanRepoMockRecorder
+}
+
+// MockLoanRepoMockRecorder is the mock recorder for MockLoanRepo.
+type MockLoanRepoMockRecorder struct {
+	mock *MockLoanRepo
+}
+
+// NewMockLoanRepo creates a new mock instance.
+func NewMockLoanRepo(ctrl *gomock.Controller) *MockLoanRepo {
+	mock := &MockLoanRepo{ctrl: ctrl}
+	mock.recorder = &MockLoanRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoanRepo) EXPECT() *MockLoanRepoMockRecorder {
+	return m.recorder
+}
+
+// CheckoutCopy mocks base method.
+func (m *MockLoanRepo) CheckoutCopy(arg0, arg1, arg2 int64, arg3 time.Time, arg4 int) (model.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckoutCopy", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(model.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckoutCopy indicates an expected call of CheckoutCopy.
+func (mr *MockLoanRepoMockRecorder) CheckoutCopy(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckoutCopy", reflect.TypeOf((*MockLoanRepo)(nil).CheckoutCopy), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CountActiveLoans mocks base method.
+func (m *MockLoanRepo) CountActiveLoans(arg0 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveLoans", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveLoans indicates an expected call of CountActiveLoans.
+func (mr *MockLoanRepoMockRecorder) CountActiveLoans(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveLoans", reflect.TypeOf((*MockLoanRepo)(nil).CountActiveLoans), arg0)
+}
+
+// GetLoanById mocks base method.
+func (m *MockLoanRepo) GetLoanById(arg0 int64) (model.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanById", arg0)
+	ret0, _ := ret[0].(model.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanById indicates an expected call of GetLoanById.
+func (mr *MockLoanRepoMockRecorder) GetLoanById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanById", reflect.TypeOf((*MockLoanRepo)(nil).GetLoanById), arg0)
+}
+
+// GetLoans mocks base method.
+func (m *MockLoanRepo) GetLoans(arg0 model.LoanFilter) ([]model.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoans", arg0)
+	ret0, _ := ret[0].([]model.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoans indicates an expected call of GetLoans.
+func (mr *MockLoanRepoMockRecorder) GetLoans(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoans", reflect.TypeOf((*MockLoanRepo)(nil).GetLoans), arg0)
+}
+
+// RenewLoan mocks base method.
+func (m *MockLoanRepo) RenewLoan(arg0 int64, arg1 time.Time, arg2 int, arg3 int64) (model.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewLoan", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(model.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewLoan indicates an expected call of RenewLoan.
+func (mr *MockLoanRepoMockRecorder) RenewLoan(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewLoan", reflect.TypeOf((*MockLoanRepo)(nil).RenewLoan), arg0, arg1, arg2, arg3)
+}
+
+// ReturnLoan mocks base method.
+func (m *MockLoanRepo) ReturnLoan(arg0 int64, arg1 time.Time) (model.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnLoan", arg0, arg1)
+	ret0, _ := ret[0].(model.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnLoan indicates an expected call of ReturnLoan.
+func (mr *MockLoanRepoMockRecorder) ReturnLoan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnLoan", reflect.TypeOf((*MockLoanRepo)(nil).ReturnLoan), arg0, arg1)
+}
+
+// UpdateLoanFine mocks base method.
+func (m *MockLoanRepo) UpdateLoanFine(arg0 model.Loan, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLoanFine", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLoanFine indicates an expected call of UpdateLoanFine.
+func (mr *MockLoanRepoMockRecorder) UpdateLoanFine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoanFine", reflect.TypeOf((*MockLoanRepo)(nil).UpdateLoanFine), arg0, arg1)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ninth-learn/repository (interfaces: OutboxRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "ninth-learn/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOutboxRepo is a mock of OutboxRepo interface.
+type MockOutboxRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepoMockRecorder
+}
+
+// MockOutboxRepoMockRecorder is the mock recorder for MockOutboxRepo.
+type MockOutboxRepoMockRecorder struct {
+	mock *MockOutboxRepo
+}
+
+// NewMockOutboxRepo creates a new mock instance.
+func NewMockOutboxRepo(ctrl *gomock.Controller) *MockOutboxRepo {
+	mock := &MockOutboxRepo{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepo) EXPECT() *MockOutboxRepoMockRecorder {
+	return m.recorder
+}
+
+// GetPublishedOutbox mocks base method.
+func (m *MockOutboxRepo) GetPublishedOutbox(arg0 model.OutboxCursor, arg1 int) ([]model.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublishedOutbox", arg0, arg1)
+	ret0, _ := ret[0].([]model.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublishedOutbox indicates an expected call of GetPublishedOutbox.
+func (mr *MockOutboxRepoMockRecorder) GetPublishedOutbox(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedOutbox", reflect.TypeOf((*MockOutboxRepo)(nil).GetPublishedOutbox), arg0, arg1)
+}
+
+// OutboxHead mocks base method.
+func (m *MockOutboxRepo) OutboxHead() (model.OutboxCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboxHead")
+	ret0, _ := ret[0].(model.OutboxCursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OutboxHead indicates an expected call of OutboxHead.
+func (mr *MockOutboxRepoMockRecorder) OutboxHead() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboxHead", reflect.TypeOf((*MockOutboxRepo)(nil).OutboxHead))
+}
+
+// PruneOutbox mocks base method.
+func (m *MockOutboxRepo) PruneOutbox(arg0 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneOutbox", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneOutbox indicates an expected call of PruneOutbox.
+func (mr *MockOutboxRepoMockRecorder) PruneOutbox(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneOutbox", reflect.TypeOf((*MockOutboxRepo)(nil).PruneOutbox), arg0)
+}
+
+// RelayOutbox mocks base method.
+func (m *MockOutboxRepo) RelayOutbox(arg0 int, arg1 func([]model.OutboxEvent) []int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayOutbox", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelayOutbox indicates an expected call of RelayOutbox.
+func (mr *MockOutboxRepoMockRecorder) RelayOutbox(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayOutbox", reflect.TypeOf((*MockOutboxRepo)(nil).RelayOutbox), arg0, arg1)
+}
@@ -0,0 +1,182 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ninth-learn/repository (interfaces: ReadingListRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "ninth-learn/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReadingListRepo is a mock of ReadingListRepo interface.
+type MockReadingListRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadingListRepoMockRecorder
+}
+
+// MockReadingListRepoMockRecorder is the mock recorder for MockReadingListRepo.
+type MockReadingListRepoMockRecorder struct {
+	mock *MockReadingListRepo
+}
+
+// NewMockReadingListRepo creates a new mock instance.
+func NewMockReadingListRepo(ctrl *gomock.Controller) *MockReadingListRepo {
+	mock := &MockReadingListRepo{ctrl: ctrl}
+	mock.recorder = &MockReadingListRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadingListRepo) EXPECT() *MockReadingListRepoMockRecorder {
+	return m.recorder
+}
+
+// AddListEntry mocks base method.
+func (m *MockReadingListRepo) AddListEntry(arg0 string, arg1 model.ReadingListEntry) (model.ReadingListEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddListEntry", arg0, arg1)
+	ret0, _ := ret[0].(model.ReadingListEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddListEntry indicates an expected call of AddListEntry.
+func (mr *MockReadingListRepoMockRecorder) AddListEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddListEntry", reflect.TypeOf((*MockReadingListRepo)(nil).AddListEntry), arg0, arg1)
+}
+
+// CreateDefaultReadingLists mocks base method.
+func (m *MockReadingListRepo) CreateDefaultReadingLists(arg0 []model.ReadingList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDefaultReadingLists", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDefaultReadingLists indicates an expected call of CreateDefaultReadingLists.
+func (mr *MockReadingListRepoMockRecorder) CreateDefaultReadingLists(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDefaultReadingLists", reflect.TypeOf((*MockReadingListRepo)(nil).CreateDefaultReadingLists), arg0)
+}
+
+// CreateReadingList mocks base method.
+func (m *MockReadingListRepo) CreateReadingList(arg0 model.ReadingList) (model.ReadingList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReadingList", arg0)
+	ret0, _ := ret[0].(model.ReadingList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReadingList indicates an expected call of CreateReadingList.
+func (mr *MockReadingListRepoMockRecorder) CreateReadingList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReadingList", reflect.TypeOf((*MockReadingListRepo)(nil).CreateReadingList), arg0)
+}
+
+// DeleteReadingList mocks base method.
+func (m *MockReadingListRepo) DeleteReadingList(arg0 string, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReadingList", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReadingList indicates an expected call of DeleteReadingList.
+func (mr *MockReadingListRepoMockRecorder) DeleteReadingList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReadingList", reflect.TypeOf((*MockReadingListRepo)(nil).DeleteReadingList), arg0, arg1)
+}
+
+// GetPublicReadingList mocks base method.
+func (m *MockReadingListRepo) GetPublicReadingList(arg0 string) (model.ReadingList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicReadingList", arg0)
+	ret0, _ := ret[0].(model.ReadingList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicReadingList indicates an expected call of GetPublicReadingList.
+func (mr *MockReadingListRepoMockRecorder) GetPublicReadingList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicReadingList", reflect.TypeOf((*MockReadingListRepo)(nil).GetPublicReadingList), arg0)
+}
+
+// GetReadingListById mocks base method.
+func (m *MockReadingListRepo) GetReadingListById(arg0 string, arg1 int64) (model.ReadingList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReadingListById", arg0, arg1)
+	ret0, _ := ret[0].(model.ReadingList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReadingListById indicates an expected call of GetReadingListById.
+func (mr *MockReadingListRepoMockRecorder) GetReadingListById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReadingListById", reflect.TypeOf((*MockReadingListRepo)(nil).GetReadingListById), arg0, arg1)
+}
+
+// GetReadingLists mocks base method.
+func (m *MockReadingListRepo) GetReadingLists(arg0 string) ([]model.ReadingList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReadingLists", arg0)
+	ret0, _ := ret[0].([]model.ReadingList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReadingLists indicates an expected call of GetReadingLists.
+func (mr *MockReadingListRepoMockRecorder) GetReadingLists(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReadingLists", reflect.TypeOf((*MockReadingListRepo)(nil).GetReadingLists), arg0)
+}
+
+// MoveListEntry mocks base method.
+func (m *MockReadingListRepo) MoveListEntry(arg0 string, arg1, arg2 int64, arg3 int) (model.ReadingListEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveListEntry", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(model.ReadingListEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveListEntry indicates an expected call of MoveListEntry.
+func (mr *MockReadingListRepoMockRecorder) MoveListEntry(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveListEntry", reflect.TypeOf((*MockReadingListRepo)(nil).MoveListEntry), arg0, arg1, arg2, arg3)
+}
+
+// RemoveListEntry mocks base method.
+func (m *MockReadingListRepo) RemoveListEntry(arg0 string, arg1, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveListEntry", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveListEntry indicates an expected call of RemoveListEntry.
+func (mr *MockReadingListRepoMockRecorder) RemoveListEntry(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveListEntry", reflect.TypeOf((*MockReadingListRepo)(nil).RemoveListEntry), arg0, arg1, arg2)
+}
+
+// UpdateReadingList mocks base method.
+func (m *MockReadingListRepo) UpdateReadingList(arg0 model.ReadingList) (model.ReadingList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReadingList", arg0)
+	ret0, _ := ret[0].(model.ReadingList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateReadingList indicates an expected call of UpdateReadingList.
+func (mr *MockReadingListRepoMockRecorder) UpdateReadingList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReadingList", reflect.TypeOf((*MockReadingListRepo)(nil).UpdateReadingList), arg0)
+}
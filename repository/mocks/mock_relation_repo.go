@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ninth-learn/repository (interfaces: RelationRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "ninth-learn/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRelationRepo is a mock of RelationRepo interface.
+type MockRelationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelationRepoMockRecorder
+}
+
+// MockRelationRepoMockRecorder is the mock recorder for MockRelationRepo.
+type MockRelationRepoMockRecorder struct {
+	mock *MockRelationRepo
+}
+
+// NewMockRelationRepo creates a new mock instance.
+func NewMockRelationRepo(ctrl *gomock.Controller) *MockRelationRepo {
+	mock := &MockRelationRepo{ctrl: ctrl}
+	mock.recorder = &MockRelationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelationRepo) EXPECT() *MockRelationRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateBookRelation mocks base method.
+func (m *MockRelationRepo) CreateBookRelation(arg0 model.BookRelation) (model.BookRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBookRelation", arg0)
+	ret0, _ := ret[0].(model.BookRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBookRelation indicates an expected call of CreateBookRelation.
+func (mr *MockRelationRepoMockRecorder) CreateBookRelation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBookRelation", reflect.TypeOf((*MockRelationRepo)(nil).CreateBookRelation), arg0)
+}
+
+// DeleteBookRelation mocks base method.
+func (m *MockRelationRepo) DeleteBookRelation(arg0, arg1 int64) (model.BookRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBookRelation", arg0, arg1)
+	ret0, _ := ret[0].(model.BookRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBookRelation indicates an expected call of DeleteBookRelation.
+func (mr *MockRelationRepoMockRecorder) DeleteBookRelation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBookRelation", reflect.TypeOf((*MockRelationRepo)(nil).DeleteBookRelation), arg0, arg1)
+}
+
+// GetBookRelations mocks base method.
+func (m *MockRelationRepo) GetBookRelations(arg0 int64) ([]model.BookRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookRelations", arg0)
+	ret0, _ := ret[0].([]model.BookRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookRelations indicates an expected call of GetBookRelations.
+func (mr *MockRelationRepoMockRecorder) GetBookRelations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookRelations", reflect.TypeOf((*MockRelationRepo)(nil).GetBookRelations), arg0)
+}
+
+// GetRelationsOfBooks mocks base method.
+func (m *MockRelationRepo) GetRelationsOfBooks(arg0 []int64) ([]model.BookRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelationsOfBooks", arg0)
+	ret0, _ := ret[0].([]model.BookRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelationsOfBooks indicates an expected call of GetRelationsOfBooks.
+func (mr *MockRelationRepoMockRecorder) GetRelationsOfBooks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelationsOfBooks", reflect.TypeOf((*MockRelationRepo)(nil).GetRelationsOfBooks), arg0)
+}
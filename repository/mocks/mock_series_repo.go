@@ -0,0 +1,183 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ninth-learn/repository (interfaces: SeriesRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "ninth-learn/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSeriesRepo is a mock of SeriesRepo interface.
+type MockSeriesRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeriesRepoMockRecorder
+}
+
+// MockSeriesRepoMockRecorder is the mock recorder for MockSeriesRepo.
+type MockSeriesRepoMockRecorder struct {
+	mock *MockSeriesRepo
+}
+
+// NewMockSeriesRepo creates a new mock instance.
+func NewMockSeriesRepo(ctrl *gomock.Controller) *MockSeriesRepo {
+	mock := &MockSeriesRepo{ctrl: ctrl}
+	mock.recorder = &MockSeriesRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeriesRepo) EXPECT() *MockSeriesRepoMockRecorder {
+	return m.recorder
+}
+
+// AddSeriesEntry mocks base method.
+func (m *MockSeriesRepo) AddSeriesEntry(arg0 model.SeriesEntry) (model.SeriesEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSeriesEntry", arg0)
+	ret0, _ := ret[0].(model.SeriesEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSeriesEntry indicates an expected call of AddSeriesEntry.
+func (mr *MockSeriesRepoMockRecorder) AddSeriesEntry(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSeriesEntry", reflect.TypeOf((*MockSeriesRepo)(nil).AddSeriesEntry), arg0)
+}
+
+// CreateSeries mocks base method.
+func (m *MockSeriesRepo) CreateSeries(arg0 model.Series) (model.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSeries", arg0)
+	ret0, _ := ret[0].(model.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSeries indicates an expected call of CreateSeries.
+func (mr *MockSeriesRepoMockRecorder) CreateSeries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeries", reflect.TypeOf((*MockSeriesRepo)(nil).CreateSeries), arg0)
+}
+
+// DeleteSeries mocks base method.
+func (m *MockSeriesRepo) DeleteSeries(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockSeriesRepoMockRecorder) DeleteSeries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockSeriesRepo)(nil).DeleteSeries), arg0)
+}
+
+// GetBookSeries mocks base method.
+func (m *MockSeriesRepo) GetBookSeries(arg0 int64) ([]model.BookSeries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookSeries", arg0)
+	ret0, _ := ret[0].([]model.BookSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookSeries indicates an expected call of GetBookSeries.
+func (mr *MockSeriesRepoMockRecorder) GetBookSeries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookSeries", reflect.TypeOf((*MockSeriesRepo)(nil).GetBookSeries), arg0)
+}
+
+// GetSeries mocks base method.
+func (m *MockSeriesRepo) GetSeries() ([]model.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeries")
+	ret0, _ := ret[0].([]model.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeries indicates an expected call of GetSeries.
+func (mr *MockSeriesRepoMockRecorder) GetSeries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeries", reflect.TypeOf((*MockSeriesRepo)(nil).GetSeries))
+}
+
+// GetSeriesById mocks base method.
+func (m *MockSeriesRepo) GetSeriesById(arg0 int64) (model.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeriesById", arg0)
+	ret0, _ := ret[0].(model.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeriesById indicates an expected call of GetSeriesById.
+func (mr *MockSeriesRepoMockRecorder) GetSeriesById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeriesById", reflect.TypeOf((*MockSeriesRepo)(nil).GetSeriesById), arg0)
+}
+
+// GetSeriesOfBooks mocks base method.
+func (m *MockSeriesRepo) GetSeriesOfBooks(arg0 []int64) (map[int64][]model.BookSeries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeriesOfBooks", arg0)
+	ret0, _ := ret[0].(map[int64][]model.BookSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeriesOfBooks indicates an expected call of GetSeriesOfBooks.
+func (mr *MockSeriesRepoMockRecorder) GetSeriesOfBooks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeriesOfBooks", reflect.TypeOf((*MockSeriesRepo)(nil).GetSeriesOfBooks), arg0)
+}
+
+// MoveSeriesEntry mocks base method.
+func (m *MockSeriesRepo) MoveSeriesEntry(arg0, arg1 int64, arg2 int) (model.SeriesEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveSeriesEntry", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.SeriesEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveSeriesEntry indicates an expected call of MoveSeriesEntry.
+func (mr *MockSeriesRepoMockRecorder) MoveSeriesEntry(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveSeriesEntry", reflect.TypeOf((*MockSeriesRepo)(nil).MoveSeriesEntry), arg0, arg1, arg2)
+}
+
+// RemoveSeriesEntry mocks base method.
+func (m *MockSeriesRepo) RemoveSeriesEntry(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSeriesEntry", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSeriesEntry indicates an expected call of RemoveSeriesEntry.
+func (mr *MockSeriesRepoMockRecorder) RemoveSeriesEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSeriesEntry", reflect.TypeOf((*MockSeriesRepo)(nil).RemoveSeriesEntry), arg0, arg1)
+}
+
+// UpdateSeries mocks base method.
+func (m *MockSeriesRepo) UpdateSeries(arg0 model.Series) (model.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSeries", arg0)
+	ret0, _ := ret[0].(model.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSeries indicates an expected call of UpdateSeries.
+func (mr *MockSeriesRepoMockRecorder) UpdateSeries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeries", reflect.TypeOf((*MockSeriesRepo)(nil).UpdateSeries), arg0)
+}
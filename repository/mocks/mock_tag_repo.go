@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ninth-learn/repository (interfaces: TagRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "ninth-learn/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTagRepo is a mock of TagRepo interface.
+type MockTagRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagRepoMockRecorder
+}
+
+// MockTagRepoMockRecorder is the mock recorder for MockTagRepo.
+type MockTagRepoMockRecorder struct {
+	mock *MockTagRepo
+}
+
+// NewMockTagRepo creates a new mock instance.
+func NewMockTagRepo(ctrl *gomock.Controller) *MockTagRepo {
+	mock := &MockTagRepo{ctrl: ctrl}
+	mock.recorder = &MockTagRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagRepo) EXPECT() *MockTagRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateTag mocks base method.
+func (m *MockTagRepo) CreateTag(arg0 model.Tag) (model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", arg0)
+	ret0, _ := ret[0].(model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockTagRepoMockRecorder) CreateTag(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockTagRepo)(nil).CreateTag), arg0)
+}
+
+// DeleteTag mocks base method.
+func (m *MockTagRepo) DeleteTag(arg0 int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", arg0)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockTagRepoMockRecorder) DeleteTag(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockTagRepo)(nil).DeleteTag), arg0)
+}
+
+// GetTags mocks base method.
+func (m *MockTagRepo) GetTags() ([]model.TagUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags")
+	ret0, _ := ret[0].([]model.TagUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockTagRepoMockRecorder) GetTags() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockTagRepo)(nil).GetTags))
+}
+
+// UpdateTag mocks base method.
+func (m *MockTagRepo) UpdateTag(arg0 model.Tag) (model.Tag, []int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTag", arg0)
+	ret0, _ := ret[0].(model.Tag)
+	ret1, _ := ret[1].([]int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateTag indicates an expected call of UpdateTag.
+func (mr *MockTagRepoMockRecorder) UpdateTag(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTag", reflect.TypeOf((*MockTagRepo)(nil).UpdateTag), arg0)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ninth-learn/repository (interfaces: TenantRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "ninth-learn/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTenantRepo is a mock of TenantRepo interface.
+type MockTenantRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantRepoMockRecorder
+}
+
+// MockTenantRepoMockRecorder is the mock recorder for MockTenantRepo.
+type MockTenantRepoMockRecorder struct {
+	mock *MockTenantRepo
+}
+
+// NewMockTenantRepo creates a new mock instance.
+func NewMockTenantRepo(ctrl *gomock.Controller) *MockTenantRepo {
+	mock := &MockTenantRepo{ctrl: ctrl}
+	mock.recorder = &MockTenantRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantRepo) EXPECT() *MockTenantRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateTenant mocks base method.
+func (m *MockTenantRepo) CreateTenant(arg0 model.Tenant) (model.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTenant", arg0)
+	ret0, _ := ret[0].(model.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTenant indicates an expected call of CreateTenant.
+func (mr *MockTenantRepoMockRecorder) CreateTenant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTenant", reflect.TypeOf((*MockTenantRepo)(nil).CreateTenant), arg0)
+}
+
+// GetTenantById mocks base method.
+func (m *MockTenantRepo) GetTenantById(arg0 int64) (model.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenantById", arg0)
+	ret0, _ := ret[0].(model.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenantById indicates an expected call of GetTenantById.
+func (mr *MockTenantRepoMockRecorder) GetTenantById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenantById", reflect.TypeOf((*MockTenantRepo)(nil).GetTenantById), arg0)
+}
+
+// GetTenantBySlug mocks base method.
+func (m *MockTenantRepo) GetTenantBySlug(arg0 string) (model.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenantBySlug", arg0)
+	ret0, _ := ret[0].(model.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenantBySlug indicates an expected call of GetTenantBySlug.
+func (mr *MockTenantRepoMockRecorder) GetTenantBySlug(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenantBySlug", reflect.TypeOf((*MockTenantRepo)(nil).GetTenantBySlug), arg0)
+}
+
+// GetTenants mocks base method.
+func (m *MockTenantRepo) GetTenants() ([]model.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenants")
+	ret0, _ := ret[0].([]model.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenants indicates an expected call of GetTenants.
+func (mr *MockTenantRepoMockRecorder) GetTenants() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenants", reflect.TypeOf((*MockTenantRepo)(nil).GetTenants))
+}
+
+// UpdateTenantStatus mocks base method.
+func (m *MockTenantRepo) UpdateTenantStatus(arg0 int64, arg1 string) (model.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTenantStatus", arg0, arg1)
+	ret0, _ := ret[0].(model.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTenantStatus indicates an expected call of UpdateTenantStatus.
+func (mr *MockTenantRepoMockRecorder) UpdateTenantStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTenantStatus", reflect.TypeOf((*MockTenantRepo)(nil).UpdateTenantStatus), arg0, arg1)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ninth-learn/repository (interfaces: TranslationRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "ninth-learn/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTranslationRepo is a mock of TranslationRepo interface.
+type MockTranslationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslationRepoMockRecorder
+}
+
+// MockTranslationRepoMockRecorder is the mock recorder for MockTranslationRepo.
+type MockTranslationRepoMockRecorder struct {
+	mock *MockTranslationRepo
+}
+
+// NewMockTranslationRepo creates a new mock instance.
+func NewMockTranslationRepo(ctrl *gomock.Controller) *MockTranslationRepo {
+	mock := &MockTranslationRepo{ctrl: ctrl}
+	mock.recorder = &MockTranslationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslationRepo) EXPECT() *MockTranslationRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteBookTranslation mocks base method.
+func (m *MockTranslationRepo) DeleteBookTranslation(arg0 int64, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBookTranslation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBookTranslation indicates an expected call of DeleteBookTranslation.
+func (mr *MockTranslationRepoMockRecorder) DeleteBookTranslation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBookTranslation", reflect.TypeOf((*MockTranslationRepo)(nil).DeleteBookTranslation), arg0, arg1)
+}
+
+// GetBookTranslations mocks base method.
+func (m *MockTranslationRepo) GetBookTranslations(arg0 int64) ([]model.BookTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookTranslations", arg0)
+	ret0, _ := ret[0].([]model.BookTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookTranslations indicates an expected call of GetBookTranslations.
+func (mr *MockTranslationRepoMockRecorder) GetBookTranslations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookTranslations", reflect.TypeOf((*MockTranslationRepo)(nil).GetBookTranslations), arg0)
+}
+
+// SaveBookTranslation mocks base method.
+func (m *MockTranslationRepo) SaveBookTranslation(arg0 model.BookTranslation) (model.BookTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBookTranslation", arg0)
+	ret0, _ := ret[0].(model.BookTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveBookTranslation indicates an expected call of SaveBookTranslation.
+func (mr *MockTranslationRepoMockRecorder) SaveBookTranslation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBookTranslation", reflect.TypeOf((*MockTranslationRepo)(nil).SaveBookTranslation), arg0)
+}
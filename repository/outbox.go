@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"encoding/json"
+	"ninth-learn/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// outboxLock keys the advisory lock of the relay. one relay at a time keeps
+// the events of a book in order when several instances run
+const outboxLock = 0x6f7574626f78
+
+// interface Outbox
+type OutboxRepo interface {
+	// RelayOutbox hands the oldest unpublished events to publish and marks
+	// the ones it returns as published. nothing is relayed while another
+	// instance is relaying
+	RelayOutbox(limit int, publish func(events []model.OutboxEvent) (published []int64)) (relayed int64, err error)
+	PruneOutbox(before time.Time) (pruned int64, err error)
+	// OutboxHead is the place of the event published last
+	OutboxHead() (res model.OutboxCursor, err error)
+	// GetPublishedOutbox returns the events published after the cursor, in
+	// the order they were published
+	GetPublishedOutbox(after model.OutboxCursor, limit int) ([]model.OutboxEvent, error)
+}
+
+func (r Repo) RelayOutbox(limit int, publish func(events []model.OutboxEvent) (published []int64)) (relayed int64, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", outboxLock).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		var events []model.OutboxEvent
+		if err := tx.Where("published_at IS NULL").Order("id").Limit(limit).Find(&events).Error; err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		ids := publish(events)
+		if len(ids) == 0 {
+			return nil
+		}
+
+		// a crash past this point publishes the events again, consumers
+		// drop the repeats by event id. the database clock read after the
+		// lock was taken orders the rounds of every instance
+		result := tx.Model(&model.OutboxEvent{}).Where("id IN ?", ids).Update("published_at", gorm.Expr("clock_timestamp()"))
+		relayed = result.RowsAffected
+		return result.Error
+	})
+	return relayed, err
+}
+
+// PruneOutbox deletes the events published before the time
+func (r Repo) PruneOutbox(before time.Time) (pruned int64, err error) {
+	result := r.db.Where("published_at < ?", before).Delete(&model.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
+
+func (r Repo) OutboxHead() (res model.OutboxCursor, err error) {
+	event := model.OutboxEvent{}
+	err = r.db.Where("published_at IS NOT NULL").Order("published_at DESC, id DESC").Limit(1).Find(&event).Error
+	if err != nil || event.PublishedAt == nil {
+		return res, err
+	}
+	return model.OutboxCursor{PublishedAt: *event.PublishedAt, ID: event.ID}, nil
+}
+
+func (r Repo) GetPublishedOutbox(after model.OutboxCursor, limit int) ([]model.OutboxEvent, error) {
+	var events []model.OutboxEvent
+	err := r.db.Where("published_at IS NOT NULL AND (published_at, id) > (?, ?)", after.PublishedAt, after.ID).
+		Order("published_at, id").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// recordBookEvent adds a book event to the outbox within the transaction of
+// the change. it has to follow the write to the book row, whose lock holds
+// back concurrent changes of the book until this transaction commits, so
+// the events of a book are numbered in commit order
+func (r Repo) recordBookEvent(tx *gorm.DB, eventType string, bookID int64) error {
+	event := model.BookEvent{Type: eventType, BookID: bookID, OccurredAt: time.Now()}
+	if eventType != model.BookDeleted {
+		book := model.Book{}
+		if err := r.preloadBook(tx).Where("id = ?", bookID).First(&book).Error; err != nil {
+			return err
+		}
+		event.Book = &book
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&model.OutboxEvent{
+		TenantID:      r.tenantID,
+		AggregateType: model.AggregateBook,
+		AggregateID:   bookID,
+		Type:          eventType,
+		Payload:       string(payload),
+		CreatedAt:     event.OccurredAt,
+	}).Error
+}
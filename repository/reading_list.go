@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"ninth-learn/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// interface ReadingList
+type ReadingListRepo interface {
+	GetReadingLists(owner string) ([]model.ReadingList, error)
+	GetReadingListById(owner string, id int64) (res model.ReadingList, err error)
+	GetPublicReadingList(token string) (res model.ReadingList, err error)
+	CreateReadingList(in model.ReadingList) (res model.ReadingList, err error)
+	CreateDefaultReadingLists(lists []model.ReadingList) (err error)
+	UpdateReadingList(in model.ReadingList) (res model.ReadingList, err error)
+	DeleteReadingList(owner string, id int64) (err error)
+	AddListEntry(owner string, in model.ReadingListEntry) (res model.ReadingListEntry, err error)
+	MoveListEntry(owner string, listID, bookID int64, position int) (res model.ReadingListEntry, err error)
+	RemoveListEntry(owner string, listID, bookID int64) (err error)
+}
+
+func (r Repo) GetReadingLists(owner string) ([]model.ReadingList, error) {
+	var lists []model.ReadingList
+	err := r.preloadEntries(r.db).Where("owner = ?", owner).Order("id").Find(&lists).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return lists, nil
+}
+
+func (r Repo) GetReadingListById(owner string, id int64) (res model.ReadingList, err error) {
+	if err := r.preloadEntries(r.db).Where("owner = ? AND id = ?", owner, id).First(&res).Error; err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// GetPublicReadingList finds a list by its share token, private lists are
+// not found so their links give nothing away
+func (r Repo) GetPublicReadingList(token string) (res model.ReadingList, err error) {
+	err = r.preloadEntries(r.db).
+		Where("share_token = ? AND visibility = ?", token, model.ListVisibilityPublic).
+		First(&res).Error
+	if err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+func (r Repo) CreateReadingList(in model.ReadingList) (res model.ReadingList, err error) {
+	in.TenantID = r.tenantID
+	in.Entries = nil
+
+	result := r.db.Create(&in)
+	if result.Error != nil {
+		return res, result.Error
+	}
+
+	in.Entries = []model.ReadingListEntry{}
+	return in, nil
+}
+
+// CreateDefaultReadingLists adds the built-in lists an owner is missing,
+// lists they already have are left alone
+func (r Repo) CreateDefaultReadingLists(lists []model.ReadingList) (err error) {
+	for i := range lists {
+		lists[i].TenantID = r.tenantID
+	}
+
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&lists).Error
+}
+
+func (r Repo) UpdateReadingList(in model.ReadingList) (res model.ReadingList, err error) {
+	// Find the list to update
+	list := model.ReadingList{}
+	if err := r.db.Where("owner = ? AND id = ?", in.Owner, in.ID).First(&list).Error; err != nil {
+		return in, err
+	}
+
+	// Update the list
+	list.Name = in.Name
+	list.Visibility = in.Visibility
+	list.ShareToken = in.ShareToken
+
+	err = r.db.Omit(clause.Associations).Save(&list).Error
+	if err != nil {
+		return res, err
+	}
+
+	return r.GetReadingListById(list.Owner, list.ID)
+}
+
+func (r Repo) DeleteReadingList(owner string, id int64) (err error) {
+	// Find the list to delete
+	list := model.ReadingList{}
+	if err := r.db.Where("owner = ? AND id = ?", owner, id).First(&list).Error; err != nil {
+		return err
+	}
+	if list.Kind != model.ListKindCustom {
+		return model.ErrBuiltInList
+	}
+
+	// Delete the entries, then the list
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("list_id = ?", list.ID).Delete(&model.ReadingListEntry{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&list).Error
+	})
+}
+
+// AddListEntry puts a book on a list at the given position, or at the end
+// when the position is left out or past the end
+func (r Repo) AddListEntry(owner string, in model.ReadingListEntry) (res model.ReadingListEntry, err error) {
+	in.TenantID = r.tenantID
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		size, err := r.lockList(tx, owner, in.ListID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Select("id").Where("id = ?", in.BookID).First(&model.Book{}).Error; err != nil {
+			return err
+		}
+
+		if in.Position < 1 || in.Position > size+1 {
+			in.Position = size + 1
+		}
+		err = tx.Model(&model.ReadingListEntry{}).
+			Where("list_id = ? AND position >= ?", in.ListID, in.Position).
+			UpdateColumn("position", gorm.Expr("position + 1")).Error
+		if err != nil {
+			return err
+		}
+
+		in.AddedAt = time.Now()
+		return tx.Omit(clause.Associations).Create(&in).Error
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return in, nil
+}
+
+// MoveListEntry moves a book to another position on its list, the books in
+// between shift up or down by one
+func (r Repo) MoveListEntry(owner string, listID, bookID int64, position int) (res model.ReadingListEntry, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		size, err := r.lockList(tx, owner, listID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("list_id = ? AND book_id = ?", listID, bookID).First(&res).Error; err != nil {
+			return err
+		}
+
+		if position > size {
+			position = size
+		}
+		shift := tx.Model(&model.ReadingListEntry{}).Where("list_id = ?", listID)
+		switch {
+		case position < res.Position:
+			err = shift.Where("position >= ? AND position < ?", position, res.Position).
+				UpdateColumn("position", gorm.Expr("position + 1")).Error
+		case position > res.Position:
+			err = shift.Where("position > ? AND position <= ?", res.Position, position).
+				UpdateColumn("position", gorm.Expr("position - 1")).Error
+		default:
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		res.Position = position
+		return tx.Model(&res).UpdateColumn("position", position).Error
+	})
+	return res, err
+}
+
+func (r Repo) RemoveListEntry(owner string, listID, bookID int64) (err error) {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := r.lockList(tx, owner, listID); err != nil {
+			return err
+		}
+
+		entry := model.ReadingListEntry{}
+		if err := tx.Where("list_id = ? AND book_id = ?", listID, bookID).First(&entry).Error; err != nil {
+			return err
+		}
+		return r.removeEntries(tx, []model.ReadingListEntry{entry})
+	})
+}
+
+// lockList locks the owner's list against concurrent reordering and
+// returns its number of entries
+func (r Repo) lockList(tx *gorm.DB, owner string, listID int64) (size int, err error) {
+	list := model.ReadingList{}
+	if err := tx.Clauses(forUpdate).Where("owner = ? AND id = ?", owner, listID).First(&list).Error; err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := tx.Model(&model.ReadingListEntry{}).Where("list_id = ?", listID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// removeEntries deletes entries and closes the gaps they leave in their
+// lists
+func (r Repo) removeEntries(tx *gorm.DB, entries []model.ReadingListEntry) error {
+	for _, entry := range entries {
+		if err := tx.Delete(&entry).Error; err != nil {
+			return err
+		}
+		err := tx.Model(&model.ReadingListEntry{}).
+			Where("list_id = ? AND position > ?", entry.ListID, entry.Position).
+			UpdateColumn("position", gorm.Expr("position - 1")).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unlistBook takes a book off every list it is on
+func (r Repo) unlistBook(tx *gorm.DB, bookID int64) error {
+	var entries []model.ReadingListEntry
+	if err := tx.Where("book_id = ?", bookID).Find(&entries).Error; err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	listIDs := make([]int64, 0, len(entries))
+	for _, e := range entries {
+		listIDs = append(listIDs, e.ListID)
+	}
+	if err := tx.Clauses(forUpdate).Where("id IN ?", listIDs).Order("id").Find(&[]model.ReadingList{}).Error; err != nil {
+		return err
+	}
+
+	return r.removeEntries(tx, entries)
+}
+
+// preloadEntries loads the entries of lists in list order, with their books
+func (r Repo) preloadEntries(db *gorm.DB) *gorm.DB {
+	return db.Preload("Entries", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}).Preload("Entries.Book")
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"ninth-learn/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// interface Relation
+type RelationRepo interface {
+	GetBookRelations(bookID int64) ([]model.BookRelation, error)
+	GetRelationsOfBooks(bookIDs []int64) ([]model.BookRelation, error)
+	CreateBookRelation(in model.BookRelation) (res model.BookRelation, err error)
+	DeleteBookRelation(bookID, id int64) (res model.BookRelation, err error)
+}
+
+// relationLock keys the advisory lock taken while a relation is checked and
+// added, cycles closing through two concurrent relations are not missed
+const relationLock = 0x72656c
+
+// CreateBookRelation refuses relations that would close a loop, a book can
+// not end up the sequel of its own sequel, nor its own prequel
+
+// GetBookRelations returns the relations from and to the book, with the
+// titles of the books on both ends
+func (r Repo) GetBookRelations(bookID int64) ([]model.BookRelation, error) {
+	return r.GetRelationsOfBooks([]int64{bookID})
+}
+
+// GetRelationsOfBooks is GetBookRelations for many books at once
+func (r Repo) GetRelationsOfBooks(bookIDs []int64) ([]model.BookRelation, error) {
+	var relations []model.BookRelation
+	err := r.preloadRelatedBooks(r.db).
+		Where("book_id IN ? OR related_id IN ?", bookIDs, bookIDs).
+		Order("id").
+		Find(&relations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return relations, nil
+}
+
+func (r Repo) CreateBookRelation(in model.BookRelation) (res model.BookRelation, err error) {
+	in.TenantID = r.tenantID
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", relationLock, int32(r.tenantID)).Error; err != nil {
+			return err
+		}
+
+		// both books have to exist within the tenant
+		var count int64
+		if err := tx.Model(&model.Book{}).Where("id IN ?", []int64{in.BookID, in.RelatedID}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count != int64(len(uniqueIDs([]int64{in.BookID, in.RelatedID}))) {
+			return gorm.ErrRecordNotFound
+		}
+
+		chain, err := r.relationChain(tx, in.RelatedID, in.Type)
+		if err != nil {
+			return err
+		}
+		for _, id := range chain {
+			if id == in.BookID {
+				return model.ErrRelationCycle
+			}
+		}
+
+		if err := tx.Omit(clause.Associations).Create(&in).Error; err != nil {
+			return err
+		}
+		return touchBooks(tx, in.BookID, in.RelatedID)
+	})
+	if err != nil {
+		return res, err
+	}
+
+	err = r.preloadRelatedBooks(r.db).Where("id = ?", in.ID).First(&res).Error
+	return res, err
+}
+
+// DeleteBookRelation deletes a relation from or to the book and returns it
+func (r Repo) DeleteBookRelation(bookID, id int64) (res model.BookRelation, err error) {
+	// Find the relation to delete
+	relation := model.BookRelation{}
+	err = r.db.Where("id = ?", id).
+		Where("book_id = ? OR related_id = ?", bookID, bookID).
+		First(&relation).Error
+	if err != nil {
+		return res, err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&relation).Error; err != nil {
+			return err
+		}
+		return touchBooks(tx, relation.BookID, relation.RelatedID)
+	})
+	if err != nil {
+		return res, err
+	}
+	return relation, nil
+}
+
+// relationChain returns the book and every book it leads to through
+// relations of the type, a sequel of a sequel and so on. relations of the
+// opposite type are followed the other way, a book whose prequel is the
+// book leads to that prequel's sequel all the same
+func (r Repo) relationChain(tx *gorm.DB, bookID int64, relationType string) ([]int64, error) {
+	var ids []int64
+	err := tx.Raw(`
+		WITH RECURSIVE chain AS (
+			SELECT id FROM public.books WHERE id = ? AND tenant_id = ?
+			UNION
+			SELECT CASE WHEN r.type = ? THEN r.related_id ELSE r.book_id END
+			FROM public.book_relations r JOIN chain c
+				ON (r.type = ? AND r.book_id = c.id) OR (r.type = ? AND r.related_id = c.id)
+		)
+		SELECT id FROM chain`, bookID, r.tenantID, relationType, relationType, model.OppositeRelation(relationType)).Scan(&ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// unrelateBook deletes the relations from and to a book
+func (r Repo) unrelateBook(tx *gorm.DB, bookID int64) error {
+	return tx.Where("book_id = ? OR related_id = ?", bookID, bookID).Delete(&model.BookRelation{}).Error
+}
+
+// mergeRelations moves the relations of the source onto the target. those
+// between the two books and those the target already has are dropped
+func (r Repo) mergeRelations(tx *gorm.DB, sourceID, targetID int64) error {
+	err := tx.Where("(book_id = ? AND related_id = ?) OR (book_id = ? AND related_id = ?)", sourceID, targetID, targetID, sourceID).
+		Delete(&model.BookRelation{}).Error
+	if err != nil {
+		return err
+	}
+
+	for _, end := range []struct{ column, other string }{{"book_id", "related_id"}, {"related_id", "book_id"}} {
+		err := tx.Where(end.column+" = ?", sourceID).
+			Where("("+end.other+", type) IN (?)", tx.Model(&model.BookRelation{}).Select(end.other+", type").Where(end.column+" = ?", targetID)).
+			Delete(&model.BookRelation{}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&model.BookRelation{}).Where(end.column+" = ?", sourceID).Update(end.column, targetID).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// preloadRelatedBooks loads the id and title of the books on both ends of
+// relations
+func (r Repo) preloadRelatedBooks(db *gorm.DB) *gorm.DB {
+	titles := func(db *gorm.DB) *gorm.DB {
+		return db.Select("id", "title")
+	}
+	return db.Preload("Book", titles).Preload("Related", titles)
+}
@@ -1,16 +1,51 @@
 package repository
 
-import "gorm.io/gorm"
+import (
+	"ninth-learn/storage"
+
+	"gorm.io/gorm"
+)
 
 type Repo struct {
-	db *gorm.DB
+	db       *gorm.DB
+	blobs    storage.BlobStore
+	tenantID int64
 }
 
 type RepoInterface interface {
 	BookRepo
+	TenantRepo
+	AuthorRepo
+	CategoryRepo
+	TagRepo
+	CoverRepo
+	CopyRepo
+	MemberRepo
+	LoanRepo
+	HoldRepo
+	FineRepo
+	ReviewRepo
+	ReadingListRepo
+	DuplicateRepo
+	TranslationRepo
+	SeriesRepo
+	RelationRepo
+	WebhookRepo
+	OutboxRepo
+
+	// WithTenant returns a repo whose queries are scoped to a single tenant
+	WithTenant(tenantID int64) RepoInterface
 }
 
 // constructor function
-func NewRepo(db *gorm.DB) *Repo {
-	return &Repo{db: db} // handle dependencies
+func NewRepo(db *gorm.DB, blobs storage.BlobStore) *Repo {
+	return &Repo{db: db, blobs: blobs} // handle dependencies
+}
+
+func (r Repo) WithTenant(tenantID int64) RepoInterface {
+	return &Repo{
+		db:       r.db.Scopes(TenantScope(tenantID)).Session(&gorm.Session{}),
+		blobs:    r.blobs,
+		tenantID: tenantID,
+	}
 }
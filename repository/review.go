@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"math"
+	"ninth-learn/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// interface Review
+type ReviewRepo interface {
+	GetReviews(filter model.ReviewFilter) ([]model.Review, error)
+	GetReviewById(bookID, id int64) (res model.Review, err error)
+	CreateReview(in model.Review) (res model.Review, err error)
+	UpdateReview(in model.Review) (res model.Review, err error)
+	SetReviewStatus(bookID, id int64, status string) (res model.Review, err error)
+	DeleteReview(bookID, id int64) (err error)
+}
+
+// ratingColumns are only ever changed by adjustRating, book writes leave
+// them alone
+var ratingColumns = []string{"rating_average", "rating_count", "rating_sum"}
+
+func (r Repo) GetReviews(filter model.ReviewFilter) ([]model.Review, error) {
+	query := r.db.Where("book_id = ?", filter.BookID)
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var reviews []model.Review
+	err := query.Order("created_at DESC, id DESC").Find(&reviews).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (r Repo) GetReviewById(bookID, id int64) (res model.Review, err error) {
+	if err := r.db.Where("book_id = ? AND id = ?", bookID, id).First(&res).Error; err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+func (r Repo) CreateReview(in model.Review) (res model.Review, err error) {
+	in.TenantID = r.tenantID
+	if in.Status == "" {
+		in.Status = model.ReviewStatusApproved
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Select("id").Where("id = ?", in.BookID).First(&model.Book{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&in).Error; err != nil {
+			return err
+		}
+		if in.Counts() {
+			return r.adjustRating(tx, in.BookID, 1, int64(in.Rating))
+		}
+		return nil
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return in, nil
+}
+
+func (r Repo) UpdateReview(in model.Review) (res model.Review, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(forUpdate).Where("book_id = ? AND id = ?", in.BookID, in.ID).First(&res).Error; err != nil {
+			return err
+		}
+		delta := int64(in.Rating - res.Rating)
+
+		res.Rating = in.Rating
+		res.Text = in.Text
+		if err := tx.Save(&res).Error; err != nil {
+			return err
+		}
+
+		if res.Counts() && delta != 0 {
+			return r.adjustRating(tx, res.BookID, 0, delta)
+		}
+		return nil
+	})
+	return res, err
+}
+
+// SetReviewStatus moderates a review, taking it in or out of the rating of
+// its book
+func (r Repo) SetReviewStatus(bookID, id int64, status string) (res model.Review, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(forUpdate).Where("book_id = ? AND id = ?", bookID, id).First(&res).Error; err != nil {
+			return err
+		}
+		counted := res.Counts()
+
+		res.Status = status
+		if err := tx.Save(&res).Error; err != nil {
+			return err
+		}
+
+		switch {
+		case res.Counts() && !counted:
+			return r.adjustRating(tx, res.BookID, 1, int64(res.Rating))
+		case !res.Counts() && counted:
+			return r.adjustRating(tx, res.BookID, -1, -int64(res.Rating))
+		}
+		return nil
+	})
+	return res, err
+}
+
+func (r Repo) DeleteReview(bookID, id int64) (err error) {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		review := model.Review{}
+		if err := tx.Clauses(forUpdate).Where("book_id = ? AND id = ?", bookID, id).First(&review).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&review).Error; err != nil {
+			return err
+		}
+		if review.Counts() {
+			return r.adjustRating(tx, review.BookID, -1, -int64(review.Rating))
+		}
+		return nil
+	})
+}
+
+// adjustRating moves the rating aggregates of a book by the given deltas.
+// the update is relative, so concurrent reviews of a book never overwrite
+// each other's change.
+func (r Repo) adjustRating(tx *gorm.DB, bookID, count, sum int64) error {
+	return tx.Model(&model.Book{}).Where("id = ?", bookID).UpdateColumns(map[string]interface{}{
+		"rating_count":   gorm.Expr("rating_count + ?", count),
+		"rating_sum":     gorm.Expr("rating_sum + ?", sum),
+		"rating_average": gorm.Expr("COALESCE(ROUND((rating_sum + ?)::numeric / NULLIF(rating_count + ?, 0), 2), 0)", sum, count),
+		"updated_at":     time.Now(),
+	}).Error
+}
+
+// recomputeRating rebuilds the rating aggregates of a book from its
+// approved reviews
+func (r Repo) recomputeRating(tx *gorm.DB, bookID int64) error {
+	var agg struct {
+		Count int64
+		Sum   int64
+	}
+	err := tx.Model(&model.Review{}).
+		Select("COUNT(*) AS count, COALESCE(SUM(rating), 0) AS sum").
+		Where("book_id = ? AND status = ?", bookID, model.ReviewStatusApproved).
+		Scan(&agg).Error
+	if err != nil {
+		return err
+	}
+
+	average := 0.0
+	if agg.Count > 0 {
+		average = math.Round(float64(agg.Sum)/float64(agg.Count)*100) / 100
+	}
+	return tx.Model(&model.Book{}).Where("id = ?", bookID).UpdateColumns(map[string]interface{}{
+		"rating_count":   agg.Count,
+		"rating_sum":     agg.Sum,
+		"rating_average": average,
+		"updated_at":     time.Now(),
+	}).Error
+}
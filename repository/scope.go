@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TenantScope restricts every statement on a model with a tenant_id column
+// to the given tenant. models without the column are left untouched, so the
+// scope is safe to attach to a shared connection.
+func TenantScope(tenantID int64) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		model := db.Statement.Model
+		if model == nil {
+			model = db.Statement.Dest
+		}
+		if model == nil {
+			return db
+		}
+
+		if err := db.Statement.Parse(model); err != nil {
+			return db
+		}
+		if db.Statement.Schema.LookUpField("tenant_id") == nil {
+			return db
+		}
+
+		return db.Where(clause.Eq{
+			Column: clause.Column{Table: clause.CurrentTable, Name: "tenant_id"},
+			Value:  tenantID,
+		})
+	}
+}
@@ -0,0 +1,420 @@
+package repository
+
+import (
+	"errors"
+	"ninth-learn/model"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// interface Series
+type SeriesRepo interface {
+	GetSeries() ([]model.Series, error)
+	GetSeriesById(id int64) (res model.Series, err error)
+	CreateSeries(in model.Series) (res model.Series, err error)
+	UpdateSeries(in model.Series) (res model.Series, err error)
+	DeleteSeries(id int64) (err error)
+	AddSeriesEntry(in model.SeriesEntry) (res model.SeriesEntry, err error)
+	MoveSeriesEntry(seriesID, bookID int64, position int) (res model.SeriesEntry, err error)
+	RemoveSeriesEntry(seriesID, bookID int64) (err error)
+	GetBookSeries(bookID int64) ([]model.BookSeries, error)
+	GetSeriesOfBooks(bookIDs []int64) (map[int64][]model.BookSeries, error)
+}
+
+func (r Repo) GetSeries() ([]model.Series, error) {
+	var series []model.Series
+	err := r.preloadSeriesEntries(r.db).Order("name").Find(&series).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+func (r Repo) GetSeriesById(id int64) (res model.Series, err error) {
+	if err := r.preloadSeriesEntries(r.db).Where("id = ?", id).First(&res).Error; err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+func (r Repo) CreateSeries(in model.Series) (res model.Series, err error) {
+	in.TenantID = r.tenantID
+	in.Entries = nil
+
+	result := r.db.Create(&in)
+	if result.Error != nil {
+		return res, result.Error
+	}
+
+	in.Entries = []model.SeriesEntry{}
+	return in, nil
+}
+
+func (r Repo) UpdateSeries(in model.Series) (res model.Series, err error) {
+	// Find the series to update
+	series := model.Series{}
+	if err := r.db.Where("id = ?", in.ID).First(&series).Error; err != nil {
+		return in, err
+	}
+
+	// Update the series
+	series.Name = in.Name
+	series.Description = in.Description
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(&series).Error; err != nil {
+			return err
+		}
+		return touchSeries(tx, series.ID)
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return r.GetSeriesById(series.ID)
+}
+
+func (r Repo) DeleteSeries(id int64) (err error) {
+	// Find the series to delete
+	series := model.Series{}
+	if err := r.db.Where("id = ?", id).First(&series).Error; err != nil {
+		return err
+	}
+
+	// Delete the entries, then the series. the books are kept
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := touchSeries(tx, series.ID); err != nil {
+			return err
+		}
+		if err := tx.Where("series_id = ?", series.ID).Delete(&model.SeriesEntry{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&series).Error
+	})
+}
+
+// AddSeriesEntry puts a book in a series at the given position, or at the
+// end when the position is left out or past the end
+func (r Repo) AddSeriesEntry(in model.SeriesEntry) (res model.SeriesEntry, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		size, err := r.lockSeries(tx, in.SeriesID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Select("id").Where("id = ?", in.BookID).First(&model.Book{}).Error; err != nil {
+			return err
+		}
+
+		if in.Position < 1 || in.Position > size+1 {
+			in.Position = size + 1
+		}
+		in, err = r.insertSeriesEntry(tx, in)
+		return err
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return in, nil
+}
+
+// MoveSeriesEntry moves a book to another position in its series, the books
+// in between shift up or down by one
+func (r Repo) MoveSeriesEntry(seriesID, bookID int64, position int) (res model.SeriesEntry, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		size, err := r.lockSeries(tx, seriesID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("series_id = ? AND book_id = ?", seriesID, bookID).First(&res).Error; err != nil {
+			return err
+		}
+
+		if position > size {
+			position = size
+		}
+		shift := tx.Model(&model.SeriesEntry{}).Where("series_id = ?", seriesID)
+		switch {
+		case position < res.Position:
+			err = shift.Where("position >= ? AND position < ?", position, res.Position).
+				UpdateColumn("position", gorm.Expr("position + 1")).Error
+		case position > res.Position:
+			err = shift.Where("position > ? AND position <= ?", res.Position, position).
+				UpdateColumn("position", gorm.Expr("position - 1")).Error
+		default:
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		res.Position = position
+		if err := tx.Model(&res).UpdateColumn("position", position).Error; err != nil {
+			return err
+		}
+		return touchSeries(tx, seriesID)
+	})
+	return res, err
+}
+
+func (r Repo) RemoveSeriesEntry(seriesID, bookID int64) (err error) {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := r.lockSeries(tx, seriesID); err != nil {
+			return err
+		}
+
+		entry := model.SeriesEntry{}
+		if err := tx.Where("series_id = ? AND book_id = ?", seriesID, bookID).First(&entry).Error; err != nil {
+			return err
+		}
+		return r.removeSeriesEntries(tx, []model.SeriesEntry{entry})
+	})
+}
+
+// GetBookSeries tells where the book stands in each of its series, with
+// the books right before and after it
+func (r Repo) GetBookSeries(bookID int64) ([]model.BookSeries, error) {
+	series, err := r.GetSeriesOfBooks([]int64{bookID})
+	if err != nil {
+		return nil, err
+	}
+	if series[bookID] == nil {
+		return []model.BookSeries{}, nil
+	}
+	return series[bookID], nil
+}
+
+// GetSeriesOfBooks is GetBookSeries for many books at once, keyed by book
+func (r Repo) GetSeriesOfBooks(bookIDs []int64) (map[int64][]model.BookSeries, error) {
+	res := make(map[int64][]model.BookSeries, len(bookIDs))
+
+	var series []model.Series
+	err := r.db.Where("id IN (?)", r.db.Model(&model.SeriesEntry{}).Select("series_id").Where("book_id IN ?", bookIDs)).
+		Preload("Entries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		Preload("Entries.Book", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "title")
+		}).
+		Order("id").
+		Find(&series).Error
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[int64]bool, len(bookIDs))
+	for _, id := range bookIDs {
+		wanted[id] = true
+	}
+	link := func(e model.SeriesEntry) *model.BookLink {
+		if e.Book == nil {
+			return nil
+		}
+		return model.NewBookLink(e.Book.ID, e.Book.Title)
+	}
+
+	for _, s := range series {
+		for i, entry := range s.Entries {
+			if !wanted[entry.BookID] {
+				continue
+			}
+
+			standing := model.BookSeries{SeriesID: s.ID, Name: s.Name, Position: entry.Position, Total: len(s.Entries)}
+			if i > 0 {
+				standing.Previous = link(s.Entries[i-1])
+			}
+			if i < len(s.Entries)-1 {
+				standing.Next = link(s.Entries[i+1])
+			}
+			res[entry.BookID] = append(res[entry.BookID], standing)
+		}
+	}
+
+	return res, nil
+}
+
+// seriesEntries selects the entries of the series with the given name
+func (r Repo) seriesEntries(name string) *gorm.DB {
+	return r.db.Model(&model.SeriesEntry{}).
+		Joins("JOIN public.series s ON s.id = series_entries.series_id").
+		Where("LOWER(s.name) = LOWER(?)", name)
+}
+
+// linkSeries adds a new book to the series named on it, after the books
+// numbered before it. the series is created on first use
+func (r Repo) linkSeries(tx *gorm.DB, book model.Book) error {
+	if strings.TrimSpace(book.SeriesName) == "" {
+		return nil
+	}
+
+	series := model.Series{}
+	err := tx.Where("LOWER(name) = LOWER(?)", book.SeriesName).First(&series).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		series = model.Series{TenantID: r.tenantID, Name: book.SeriesName}
+		err = tx.Omit(clause.Associations).Create(&series).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.lockSeries(tx, series.ID); err != nil {
+		return err
+	}
+	var listed int64
+	if err := tx.Model(&model.SeriesEntry{}).Where("series_id = ? AND book_id = ?", series.ID, book.ID).Count(&listed).Error; err != nil {
+		return err
+	}
+	if listed > 0 {
+		return nil
+	}
+
+	var before int64
+	err = tx.Model(&model.SeriesEntry{}).
+		Joins("JOIN public.books b ON b.id = series_entries.book_id").
+		Where("series_entries.series_id = ? AND b.series_position > 0 AND b.series_position <= ?", series.ID, book.SeriesPosition).
+		Count(&before).Error
+	if err != nil {
+		return err
+	}
+
+	_, err = r.insertSeriesEntry(tx, model.SeriesEntry{SeriesID: series.ID, BookID: book.ID, Position: int(before) + 1})
+	return err
+}
+
+// unlinkSeries takes a book out of the series that was named on it
+func (r Repo) unlinkSeries(tx *gorm.DB, bookID int64, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return nil
+	}
+
+	series := model.Series{}
+	err := tx.Where("LOWER(name) = LOWER(?)", name).First(&series).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := r.lockSeries(tx, series.ID); err != nil {
+		return err
+	}
+
+	var entries []model.SeriesEntry
+	if err := tx.Where("series_id = ? AND book_id = ?", series.ID, bookID).Find(&entries).Error; err != nil {
+		return err
+	}
+	return r.removeSeriesEntries(tx, entries)
+}
+
+// insertSeriesEntry makes room at the entry's position and adds it, the
+// series has to be locked
+func (r Repo) insertSeriesEntry(tx *gorm.DB, in model.SeriesEntry) (model.SeriesEntry, error) {
+	in.TenantID = r.tenantID
+
+	err := tx.Model(&model.SeriesEntry{}).
+		Where("series_id = ? AND position >= ?", in.SeriesID, in.Position).
+		UpdateColumn("position", gorm.Expr("position + 1")).Error
+	if err != nil {
+		return in, err
+	}
+
+	if err := tx.Omit(clause.Associations).Create(&in).Error; err != nil {
+		return in, err
+	}
+	return in, touchSeries(tx, in.SeriesID)
+}
+
+// lockSeries locks the series against concurrent reordering and returns its
+// number of entries
+func (r Repo) lockSeries(tx *gorm.DB, seriesID int64) (size int, err error) {
+	series := model.Series{}
+	if err := tx.Clauses(forUpdate).Where("id = ?", seriesID).First(&series).Error; err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := tx.Model(&model.SeriesEntry{}).Where("series_id = ?", seriesID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// removeSeriesEntries deletes entries and closes the gaps they leave in
+// their series
+func (r Repo) removeSeriesEntries(tx *gorm.DB, entries []model.SeriesEntry) error {
+	for _, entry := range entries {
+		// the neighbours and the book itself lose their standing
+		if err := touchSeries(tx, entry.SeriesID); err != nil {
+			return err
+		}
+		if err := tx.Delete(&entry).Error; err != nil {
+			return err
+		}
+		err := tx.Model(&model.SeriesEntry{}).
+			Where("series_id = ? AND position > ?", entry.SeriesID, entry.Position).
+			UpdateColumn("position", gorm.Expr("position - 1")).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unseriesBook takes a book out of every series it is in
+func (r Repo) unseriesBook(tx *gorm.DB, bookID int64) error {
+	var entries []model.SeriesEntry
+	if err := tx.Where("book_id = ?", bookID).Find(&entries).Error; err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	seriesIDs := make([]int64, 0, len(entries))
+	for _, e := range entries {
+		seriesIDs = append(seriesIDs, e.SeriesID)
+	}
+	if err := tx.Clauses(forUpdate).Where("id IN ?", seriesIDs).Order("id").Find(&[]model.Series{}).Error; err != nil {
+		return err
+	}
+
+	return r.removeSeriesEntries(tx, entries)
+}
+
+// mergeSeriesEntries moves the source onto the target in its series, series
+// that already hold the target drop the source
+func (r Repo) mergeSeriesEntries(tx *gorm.DB, sourceID, targetID int64) error {
+	var both []model.SeriesEntry
+	err := tx.Where("book_id = ?", sourceID).
+		Where("series_id IN (?)", tx.Model(&model.SeriesEntry{}).Select("series_id").Where("book_id = ?", targetID)).
+		Find(&both).Error
+	if err != nil {
+		return err
+	}
+	if err := r.removeSeriesEntries(tx, both); err != nil {
+		return err
+	}
+
+	if err := tx.Model(&model.SeriesEntry{}).Where("book_id = ?", sourceID).Update("book_id", targetID).Error; err != nil {
+		return err
+	}
+	return touchNeighbours(tx, targetID)
+}
+
+// touchSeries touches the books in the series
+func touchSeries(tx *gorm.DB, seriesID int64) error {
+	books := tx.Model(&model.SeriesEntry{}).Select("book_id").Where("series_id = ?", seriesID)
+	return tx.Model(&model.Book{}).Where("id IN (?)", books).UpdateColumn("updated_at", time.Now()).Error
+}
+
+// preloadSeriesEntries loads the entries of series in series order, with
+// their books
+func (r Repo) preloadSeriesEntries(db *gorm.DB) *gorm.DB {
+	return db.Preload("Entries", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}).Preload("Entries.Book")
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"ninth-learn/model"
+
+	"gorm.io/gorm"
+)
+
+// interface Tag
+type TagRepo interface {
+	GetTags() ([]model.TagUsage, error)
+	CreateTag(in model.Tag) (res model.Tag, err error)
+	// UpdateTag and DeleteTag return the books that showed the tag
+	UpdateTag(in model.Tag) (res model.Tag, bookIDs []int64, err error)
+	DeleteTag(id int64) (bookIDs []int64, err error)
+}
+
+// GetTags lists every tag with the number of books carrying it, most used first
+func (r Repo) GetTags() ([]model.TagUsage, error) {
+	var tags []model.TagUsage
+	err := r.db.Model(&model.Tag{}).
+		Select("tags.*, COUNT(bt.book_id) AS book_count").
+		Joins("LEFT JOIN public.book_tags bt ON bt.tag_id = tags.id").
+		Group("tags.id").
+		Order("book_count DESC, tags.name").
+		Scan(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (r Repo) CreateTag(in model.Tag) (res model.Tag, err error) {
+	in.TenantID = r.tenantID
+	in.Name = model.NormalizeTagName(in.Name)
+
+	result := r.db.Create(&in)
+	if result.Error != nil {
+		return res, result.Error
+	}
+
+	return in, nil
+}
+
+func (r Repo) UpdateTag(in model.Tag) (res model.Tag, bookIDs []int64, err error) {
+	// Find the tag to update
+	tag := model.Tag{}
+	if err := r.db.Where("id = ?", in.ID).First(&tag).Error; err != nil {
+		return in, nil, err
+	}
+
+	// Rename the tag
+	tag.Name = model.NormalizeTagName(in.Name)
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&tag).Error; err != nil {
+			return err
+		}
+		bookIDs, err = touchLinked(tx, &model.BookTag{}, "tag_id", tag.ID)
+		return err
+	})
+	if err != nil {
+		return res, nil, err
+	}
+
+	res = tag
+	return res, bookIDs, nil
+}
+
+func (r Repo) DeleteTag(id int64) (bookIDs []int64, err error) {
+	// Find the tag to delete
+	tag := model.Tag{}
+	if err := r.db.Where("id = ?", id).First(&tag).Error; err != nil {
+		return nil, err
+	}
+
+	// Untag its books, then delete it
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		bookIDs, err = touchLinked(tx, &model.BookTag{}, "tag_id", tag.ID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("tag_id = ?", tag.ID).Delete(&model.BookTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&tag).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bookIDs, nil
+}
+
+// findOrCreateTags returns the tags with the given names, creating missing ones
+func (r Repo) findOrCreateTags(tx *gorm.DB, tags []model.Tag) ([]model.Tag, error) {
+	out := make([]model.Tag, 0, len(tags))
+	for _, t := range tags {
+		tag := model.Tag{}
+		err := tx.Where(model.Tag{TenantID: r.tenantID, Name: model.NormalizeTagName(t.Name)}).
+			FirstOrCreate(&tag).Error
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, tag)
+	}
+	return out, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"ninth-learn/model"
+)
+
+// interface Tenant
+type TenantRepo interface {
+	GetTenants() ([]model.Tenant, error)
+	CreateTenant(in model.Tenant) (res model.Tenant, err error)
+	GetTenantById(id int64) (res model.Tenant, err error)
+	GetTenantBySlug(slug string) (res model.Tenant, err error)
+	UpdateTenantStatus(id int64, status string) (res model.Tenant, err error)
+}
+
+func (r Repo) GetTenants() ([]model.Tenant, error) {
+	var tenants []model.Tenant
+	err := r.db.Order("id").Find(&tenants).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tenants, nil
+}
+
+func (r Repo) CreateTenant(in model.Tenant) (res model.Tenant, err error) {
+	in.Status = model.TenantStatusActive
+
+	result := r.db.Create(&in)
+	if result.Error != nil {
+		return res, result.Error
+	}
+
+	return in, nil
+}
+
+func (r Repo) GetTenantById(id int64) (res model.Tenant, err error) {
+	if err := r.db.Where("id = ?", id).First(&res).Error; err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+func (r Repo) GetTenantBySlug(slug string) (res model.Tenant, err error) {
+	if err := r.db.Where("slug = ?", slug).First(&res).Error; err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+func (r Repo) UpdateTenantStatus(id int64, status string) (res model.Tenant, err error) {
+	// Find the tenant to update
+	tenant := model.Tenant{}
+	if err := r.db.Where("id = ?", id).First(&tenant).Error; err != nil {
+		return res, err
+	}
+
+	tenant.Status = status
+
+	err = r.db.Save(&tenant).Error
+	if err != nil {
+		return res, err
+	}
+
+	return tenant, nil
+}
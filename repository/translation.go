@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"ninth-learn/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// interface Translation
+type TranslationRepo interface {
+	GetBookTranslations(bookID int64) ([]model.BookTranslation, error)
+	SaveBookTranslation(in model.BookTranslation) (res model.BookTranslation, err error)
+	DeleteBookTranslation(bookID int64, language string) (err error)
+}
+
+func (r Repo) GetBookTranslations(bookID int64) ([]model.BookTranslation, error) {
+	var translations []model.BookTranslation
+	err := r.db.Where("book_id = ?", bookID).Order("language").Find(&translations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return translations, nil
+}
+
+// SaveBookTranslation adds the translation, or replaces the one already in
+// its language
+func (r Repo) SaveBookTranslation(in model.BookTranslation) (res model.BookTranslation, err error) {
+	in.TenantID = r.tenantID
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Select("id").Where("id = ?", in.BookID).First(&model.Book{}).Error; err != nil {
+			return err
+		}
+
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "book_id"}, {Name: "language"}},
+			DoUpdates: clause.AssignmentColumns([]string{"title", "subtitle", "description", "updated_at"}),
+		}).Create(&in).Error
+		if err != nil {
+			return err
+		}
+		return touchBooks(tx, in.BookID)
+	})
+	if err != nil {
+		return res, err
+	}
+
+	err = r.db.Where("book_id = ? AND language = ?", in.BookID, in.Language).First(&res).Error
+	return res, err
+}
+
+func (r Repo) DeleteBookTranslation(bookID int64, language string) (err error) {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("book_id = ? AND language = ?", bookID, language).Delete(&model.BookTranslation{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return touchBooks(tx, bookID)
+	})
+}
+
+// mergeTranslations keeps the target's translation in languages both books
+// are translated to
+func (r Repo) mergeTranslations(tx *gorm.DB, sourceID, targetID int64) error {
+	err := tx.Where("book_id = ?", sourceID).
+		Where("language IN (?)", tx.Model(&model.BookTranslation{}).Select("language").Where("book_id = ?", targetID)).
+		Delete(&model.BookTranslation{}).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&model.BookTranslation{}).Where("book_id = ?", sourceID).Update("book_id", targetID).Error
+}
+
+// textSearch matches books whose own text or any of whose translations
+// match the query, each stemmed in its own language
+func (r Repo) textSearch(query string) *gorm.DB {
+	match := "to_tsvector(" + model.SearchConfigSQL("language") + ", " + model.SearchDocumentSQL + ") @@ " +
+		"plainto_tsquery(" + model.SearchConfigSQL("language") + ", ?)"
+
+	return r.db.Model(&model.Book{}).Select("id").Where(match, query).
+		Or("id IN (?)", r.db.Model(&model.BookTranslation{}).Select("book_id").Where(match, query))
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"ninth-learn/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// webhookDeliveryLog is how many deliveries of a webhook are listed
+const webhookDeliveryLog = 100
+
+// skipLocked leaves rows locked by another instance to that instance
+var skipLocked = clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}
+
+// interface Webhook
+type WebhookRepo interface {
+	GetWebhooks() ([]model.Webhook, error)
+	GetWebhookById(id int64) (model.Webhook, error)
+	CreateWebhook(in model.Webhook) (res model.Webhook, err error)
+	UpdateWebhook(in model.Webhook) (res model.Webhook, err error)
+	DeleteWebhook(id int64) (err error)
+	GetActiveWebhooks(event string) ([]model.Webhook, error)
+	CreateWebhookDeliveries(in []model.WebhookDelivery) (res []model.WebhookDelivery, err error)
+	GetWebhookDeliveries(webhookID int64) ([]model.WebhookDelivery, error)
+	GetWebhookDeliveryById(webhookID, id int64) (model.WebhookDelivery, error)
+	ClaimWebhookDeliveries(now time.Time, limit int, lease time.Duration) ([]model.WebhookDelivery, error)
+	SaveWebhookDelivery(in model.WebhookDelivery) (err error)
+}
+
+func (r Repo) GetWebhooks() ([]model.Webhook, error) {
+	var webhooks []model.Webhook
+	err := r.db.Order("id").Find(&webhooks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (r Repo) GetWebhookById(id int64) (model.Webhook, error) {
+	var webhook model.Webhook
+	err := r.db.Where("id = ?", id).First(&webhook).Error
+	return webhook, err
+}
+
+func (r Repo) CreateWebhook(in model.Webhook) (res model.Webhook, err error) {
+	in.TenantID = r.tenantID
+
+	if err := r.db.Create(&in).Error; err != nil {
+		return res, err
+	}
+
+	return in, nil
+}
+
+func (r Repo) UpdateWebhook(in model.Webhook) (res model.Webhook, err error) {
+	// Find the webhook to update
+	webhook := model.Webhook{}
+	if err := r.db.Where("id = ?", in.ID).First(&webhook).Error; err != nil {
+		return in, err
+	}
+
+	// Update the webhook
+	webhook.URL = in.URL
+	webhook.Events = in.Events
+	webhook.Secret = in.Secret
+	webhook.Active = in.Active
+
+	err = r.db.Save(&webhook).Error
+	if err != nil {
+		return res, err
+	}
+
+	return webhook, nil
+}
+
+// DeleteWebhook drops the webhook along with its delivery log
+func (r Repo) DeleteWebhook(id int64) (err error) {
+	// Find the webhook to delete
+	webhook := model.Webhook{}
+	if err := r.db.Where("id = ?", id).First(&webhook).Error; err != nil {
+		return err
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", webhook.ID).Delete(&model.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&webhook).Error
+	})
+}
+
+// GetActiveWebhooks returns the active webhooks subscribed to the event
+func (r Repo) GetActiveWebhooks(event string) ([]model.Webhook, error) {
+	var webhooks []model.Webhook
+	if err := r.db.Where("active").Order("id").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+
+	// the subscriptions are a json list, few enough to filter here
+	res := []model.Webhook{}
+	for _, webhook := range webhooks {
+		if webhook.Subscribes(event) {
+			res = append(res, webhook)
+		}
+	}
+	return res, nil
+}
+
+func (r Repo) CreateWebhookDeliveries(in []model.WebhookDelivery) (res []model.WebhookDelivery, err error) {
+	if len(in) == 0 {
+		return in, nil
+	}
+	for i := range in {
+		in[i].TenantID = r.tenantID
+	}
+
+	if err := r.db.Omit(clause.Associations).Create(&in).Error; err != nil {
+		return nil, err
+	}
+
+	return in, nil
+}
+
+// GetWebhookDeliveries returns the latest deliveries of the webhook, most
+// recent first
+func (r Repo) GetWebhookDeliveries(webhookID int64) ([]model.WebhookDelivery, error) {
+	if _, err := r.GetWebhookById(webhookID); err != nil {
+		return nil, err
+	}
+
+	var deliveries []model.WebhookDelivery
+	err := r.db.Where("webhook_id = ?", webhookID).
+		Order("id DESC").
+		Limit(webhookDeliveryLog).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r Repo) GetWebhookDeliveryById(webhookID, id int64) (model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	err := r.db.Preload("Webhook").
+		Where("webhook_id = ? AND id = ?", webhookID, id).
+		First(&delivery).Error
+	return delivery, err
+}
+
+// ClaimWebhookDeliveries leases the pending deliveries that are due, with
+// their webhooks. a claimed delivery is not due again until the lease runs
+// out, so instances sharing the database never send it twice at once, and
+// one that dies mid-send leaves it to be retried.
+func (r Repo) ClaimWebhookDeliveries(now time.Time, limit int, lease time.Duration) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ids []int64
+		err := tx.Clauses(skipLocked).
+			Model(&model.WebhookDelivery{}).
+			Where("status = ? AND next_attempt_at <= ?", model.DeliveryStatusPending, now).
+			Order("next_attempt_at, id").
+			Limit(limit).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		err = tx.Model(&model.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Preload("Webhook").Where("id IN ?", ids).Order("id").Find(&deliveries).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// SaveWebhookDelivery records the outcome of an attempt
+func (r Repo) SaveWebhookDelivery(in model.WebhookDelivery) (err error) {
+	return r.db.Omit(clause.Associations).Save(&in).Error
+}
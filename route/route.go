@@ -1,7 +1,11 @@
 package route
 
 import (
+	"expvar"
+	"ninth-learn/graph"
 	"ninth-learn/handler"
+	"ninth-learn/middleware"
+	"ninth-learn/model"
 	"ninth-learn/service"
 
 	_ "ninth-learn/docs"
@@ -26,16 +30,168 @@ import (
 
 // @host      localhost:8080
 // @BasePath  /
-func RegisterApi(r *gin.Engine, app service.ServiceInterface) {
-	server := handler.NewHttpServer(app)
-	api := r.Group("/books")
+func RegisterApi(r *gin.Engine, app service.ServiceInterface, cacheControl string) {
+	server := handler.NewHttpServer(app, cacheControl)
+	r.Use(middleware.Authenticate())
+
+	api := r.Group("/books", middleware.Tenant(app))
 	{
 		api.GET("", server.GetBooks)
+		api.GET("events", server.StreamBookEvents)
+		api.GET("duplicates", server.GetDuplicateBooks)
+		api.POST("merge", server.MergeBooks)
 		api.GET(":id", server.GetBookById)
+		api.GET("isbn/:isbn", server.GetBookByISBN)
 		api.POST("", server.CreateBook)
 		api.PUT(":id", server.UpdateBook)
+		api.GET(":id/cover", server.GetBookCover)
+		api.PUT(":id/cover", server.UploadBookCover)
+		api.GET(":id/copies", server.GetCopies)
+		api.GET(":id/copies/:copy_id", server.GetCopyById)
+		api.POST(":id/copies", server.CreateCopy)
+		api.PUT(":id/copies/:copy_id", server.UpdateCopy)
+		api.DELETE(":id/copies/:copy_id", server.DeleteCopy)
+		api.POST(":id/checkout", server.CheckoutBook)
+		api.GET(":id/loans", server.GetBookLoans)
+		api.GET(":id/holds", server.GetBookHolds)
+		api.POST(":id/holds", server.PlaceHold)
+		api.GET(":id/similar", server.GetSimilarBooks)
+		api.GET(":id/translations", server.GetBookTranslations)
+		api.PUT(":id/translations/:lang", server.PutBookTranslation)
+		api.DELETE(":id/translations/:lang", server.DeleteBookTranslation)
+		api.GET(":id/relations", server.GetBookRelations)
+		api.POST(":id/relations", server.CreateBookRelation)
+		api.DELETE(":id/relations/:relation_id", server.DeleteBookRelation)
+		api.GET(":id/reviews", server.GetBookReviews)
+		api.POST(":id/reviews", middleware.RequireAuth(), server.CreateReview)
+		api.PUT(":id/reviews/:review_id", middleware.RequireAuth(), server.UpdateReview)
+		api.DELETE(":id/reviews/:review_id", middleware.RequireAuth(), server.DeleteReview)
+		api.PUT(":id/reviews/:review_id/status", middleware.RequireRole(model.RoleAdmin), server.ModerateReview)
 		api.DELETE(":id", server.DeleteBook)
 	}
 
+	series := r.Group("/series", middleware.Tenant(app))
+	{
+		series.GET("", server.GetSeries)
+		series.GET(":id", server.GetSeriesById)
+		series.POST("", server.CreateSeries)
+		series.PUT(":id", server.UpdateSeries)
+		series.DELETE(":id", server.DeleteSeries)
+		series.POST(":id/books", server.AddSeriesBook)
+		series.PUT(":id/books/:book_id", server.MoveSeriesBook)
+		series.DELETE(":id/books/:book_id", server.RemoveSeriesBook)
+	}
+
+	copies := r.Group("/copies", middleware.Tenant(app))
+	{
+		copies.GET("barcode/:barcode", server.GetCopyByBarcode)
+	}
+
+	members := r.Group("/members", middleware.Tenant(app))
+	{
+		members.GET("", server.GetMembers)
+		members.GET(":id", server.GetMemberById)
+		members.GET(":id/loans", server.GetMemberLoans)
+		members.GET(":id/holds", server.GetMemberHolds)
+		members.GET(":id/fines", server.GetMemberFines)
+		members.POST(":id/fines/payments", server.PayFine)
+		members.POST(":id/fines/waivers", server.WaiveFine)
+		members.POST("", server.CreateMember)
+		members.PUT(":id", server.UpdateMember)
+		members.DELETE(":id", server.DeleteMember)
+	}
+
+	loans := r.Group("/loans", middleware.Tenant(app))
+	{
+		loans.GET("overdue", server.GetOverdueLoans)
+		loans.GET(":id", server.GetLoanById)
+		loans.PUT(":id/return", server.ReturnLoan)
+		loans.PUT(":id/renew", server.RenewLoan)
+	}
+
+	holds := r.Group("/holds", middleware.Tenant(app))
+	{
+		holds.GET(":id", server.GetHoldById)
+		holds.PUT(":id/cancel", server.CancelHold)
+	}
+
+	me := r.Group("/me", middleware.RequireAuth(), middleware.Tenant(app))
+	{
+		me.GET("lists", server.GetMyLists)
+		me.GET("lists/:id", server.GetMyList)
+		me.POST("lists", server.CreateList)
+		me.PUT("lists/:id", server.UpdateList)
+		me.PUT("lists/:id/share", server.ShareList)
+		me.DELETE("lists/:id", server.DeleteList)
+		me.POST("lists/:id/books", server.AddListBook)
+		me.PUT("lists/:id/books/:book_id", server.MoveListBook)
+		me.DELETE("lists/:id/books/:book_id", server.RemoveListBook)
+	}
+
+	lists := r.Group("/lists", middleware.Tenant(app))
+	{
+		lists.GET(":token", server.GetSharedList)
+	}
+
+	authors := r.Group("/authors", middleware.Tenant(app))
+	{
+		authors.GET("", server.GetAuthors)
+		authors.GET(":id", server.GetAuthorById)
+		authors.GET(":id/books", server.GetAuthorBooks)
+		authors.POST("", server.CreateAuthor)
+		authors.PUT(":id", server.UpdateAuthor)
+		authors.DELETE(":id", server.DeleteAuthor)
+	}
+
+	categories := r.Group("/categories", middleware.Tenant(app))
+	{
+		categories.GET("", server.GetCategories)
+		categories.GET(":id", server.GetCategoryById)
+		categories.POST("", server.CreateCategory)
+		categories.PUT(":id", server.UpdateCategory)
+		categories.DELETE(":id", server.DeleteCategory)
+	}
+
+	tags := r.Group("/tags", middleware.Tenant(app))
+	{
+		tags.GET("", server.GetTags)
+		tags.POST("", server.CreateTag)
+		tags.PUT(":id", server.UpdateTag)
+		tags.DELETE(":id", server.DeleteTag)
+	}
+
+	webhooks := r.Group("/webhooks", middleware.RequireRole(model.RoleAdmin), middleware.Tenant(app))
+	{
+		webhooks.GET("", server.GetWebhooks)
+		webhooks.POST("", server.CreateWebhook)
+		webhooks.GET(":id", server.GetWebhookById)
+		webhooks.PUT(":id", server.UpdateWebhook)
+		webhooks.DELETE(":id", server.DeleteWebhook)
+		webhooks.GET(":id/deliveries", server.GetWebhookDeliveries)
+		webhooks.POST(":id/deliveries/:delivery_id/redeliver", server.RedeliverWebhook)
+	}
+
+	admin := r.Group("/admin", middleware.RequirePlatformAdmin())
+	{
+		admin.GET("tenants", server.GetTenants)
+		admin.POST("tenants", server.CreateTenant)
+		admin.PUT("tenants/:id/suspend", server.SuspendTenant)
+		admin.PUT("tenants/:id/activate", server.ActivateTenant)
+	}
+
+	// cache hit and miss counts among the runtime stats
+	r.GET("/debug/vars", middleware.RequirePlatformAdmin(), gin.WrapH(expvar.Handler()))
+
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 }
+
+// RegisterGraphQL serves the catalogue on /graphql, with GraphiQL on GET in
+// debug mode
+func RegisterGraphQL(r *gin.Engine, app service.ServiceInterface, limits graph.Limits) {
+	server := graph.NewServer(app, limits)
+	r.POST("/graphql", middleware.Tenant(app), server.Handle)
+
+	if gin.IsDebugging() {
+		r.GET("/graphql", graph.Playground("/graphql"))
+	}
+}
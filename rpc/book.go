@@ -0,0 +1,122 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"ninth-learn/helper"
+	"ninth-learn/rpc/bookpb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// listBatch is how many books ListBooks loads at a time
+const listBatch = 100
+
+type bookServer struct {
+	bookpb.UnimplementedBookServiceServer
+}
+
+func (s *bookServer) GetBook(ctx context.Context, in *bookpb.GetBookRequest) (*bookpb.Book, error) {
+	res, err := tenantApp(ctx).GetBookById(in.Id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toBook(res), nil
+}
+
+func (s *bookServer) ListBooks(in *bookpb.ListBooksRequest, stream bookpb.BookService_ListBooksServer) error {
+	filter, err := fromListRequest(in)
+	if err != nil {
+		return invalidArgument(err)
+	}
+	app := tenantApp(stream.Context())
+
+	// a limit of 0 streams every match
+	remaining := int(in.Limit)
+	filter.Offset = int(in.Offset)
+	for {
+		filter.Limit = listBatch
+		if remaining > 0 && remaining < listBatch {
+			filter.Limit = remaining
+		}
+
+		books, err := app.GetBooks(filter)
+		if err != nil {
+			return statusError(err)
+		}
+		for _, b := range books {
+			if err := stream.Send(toBook(b)); err != nil {
+				return err
+			}
+		}
+
+		if in.Limit > 0 {
+			if remaining -= len(books); remaining <= 0 {
+				return nil
+			}
+		}
+		if len(books) < filter.Limit {
+			return nil
+		}
+		filter.Offset += len(books)
+	}
+}
+
+func (s *bookServer) CreateBook(ctx context.Context, in *bookpb.CreateBookRequest) (*bookpb.Book, error) {
+	book, err := fromBookInput(in.Book)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	res, err := tenantApp(ctx).CreateBook(book)
+	if err != nil {
+		if err.Error() == helper.ErrNotFound {
+			return nil, invalidArgument(errors.New("Unknown author or category"))
+		}
+		return nil, statusError(err)
+	}
+	return toBook(res), nil
+}
+
+func (s *bookServer) UpdateBook(ctx context.Context, in *bookpb.UpdateBookRequest) (*bookpb.Book, error) {
+	book, err := fromBookInput(in.Book)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	book.ID = in.Id
+
+	res, err := tenantApp(ctx).UpdateBook(book)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toBook(res), nil
+}
+
+func (s *bookServer) DeleteBook(ctx context.Context, in *bookpb.DeleteBookRequest) (*emptypb.Empty, error) {
+	if err := tenantApp(ctx).DeleteBook(in.Id, in.Force); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// WatchBooks streams the changes of the tenant until the client goes away
+func (s *bookServer) WatchBooks(in *bookpb.WatchBooksRequest, stream bookpb.BookService_WatchBooksServer) error {
+	events, stop := tenantApp(stream.Context()).WatchBooks()
+	defer stop()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "fell too far behind, watch again")
+			}
+			if err := stream.Send(toEvent(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package rpc
+
+import (
+	"errors"
+	"ninth-learn/model"
+	"ninth-learn/rpc/bookpb"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var eventTypes = map[string]bookpb.BookEvent_Type{
+	model.BookCreated: bookpb.BookEvent_CREATED,
+	model.BookUpdated: bookpb.BookEvent_UPDATED,
+	model.BookDeleted: bookpb.BookEvent_DELETED,
+}
+
+func toBook(b model.Book) *bookpb.Book {
+	out := &bookpb.Book{
+		Id:              b.ID,
+		Title:           b.Title,
+		Subtitle:        b.Subtitle,
+		Author:          b.Author,
+		Description:     b.Description,
+		Isbn_10:         b.ISBN10,
+		Isbn_13:         b.ISBN13,
+		Publisher:       b.Publisher,
+		Edition:         b.Edition,
+		Language:        b.Language,
+		PageCount:       int32(b.PageCount),
+		Format:          b.Format,
+		SeriesName:      b.SeriesName,
+		SeriesPosition:  b.SeriesPosition,
+		AverageRating:   b.RatingAverage,
+		ReviewCount:     b.RatingCount,
+		TotalCopies:     b.TotalCopies,
+		AvailableCopies: b.AvailableCopies,
+		CreatedAt:       timestamppb.New(b.CreatedAt),
+		UpdatedAt:       timestamppb.New(b.UpdatedAt),
+	}
+	if b.PublicationDate != nil {
+		out.PublicationDate = b.PublicationDate.String()
+	}
+	for _, a := range b.Authors {
+		contributor := &bookpb.Contributor{AuthorId: a.AuthorID, Role: a.Role}
+		if a.Author != nil {
+			contributor.Name = a.Author.Name
+		}
+		out.Authors = append(out.Authors, contributor)
+	}
+	for _, c := range b.Categories {
+		out.Categories = append(out.Categories, &bookpb.Category{Id: c.ID, Name: c.Name})
+	}
+	for _, t := range b.Tags {
+		out.Tags = append(out.Tags, t.Name)
+	}
+	return out
+}
+
+func toEvent(e model.BookEvent) *bookpb.BookEvent {
+	out := &bookpb.BookEvent{
+		Type:       eventTypes[e.Type],
+		BookId:     e.BookID,
+		OccurredAt: timestamppb.New(e.OccurredAt),
+	}
+	if e.Book != nil {
+		out.Book = toBook(*e.Book)
+	}
+	return out
+}
+
+// fromBookInput builds the book the way the REST handlers bind and validate it
+func fromBookInput(in *bookpb.BookInput) (book model.Book, err error) {
+	if in == nil {
+		return book, errors.New("book is required")
+	}
+
+	book = model.Book{
+		Title:          in.Title,
+		Subtitle:       in.Subtitle,
+		Author:         in.Author,
+		Description:    in.Description,
+		ISBN10:         in.Isbn_10,
+		ISBN13:         in.Isbn_13,
+		Publisher:      in.Publisher,
+		Edition:        in.Edition,
+		Language:       in.Language,
+		PageCount:      int(in.PageCount),
+		Format:         in.Format,
+		SeriesName:     in.SeriesName,
+		SeriesPosition: in.SeriesPosition,
+	}
+	if in.PublicationDate != "" {
+		date, err := model.ParseDate(in.PublicationDate)
+		if err != nil {
+			return book, errors.New("publication_date " + err.Error())
+		}
+		book.PublicationDate = &date
+	}
+	for _, a := range in.Authors {
+		book.Authors = append(book.Authors, model.BookAuthor{AuthorID: a.AuthorId, Role: a.Role})
+	}
+	for _, id := range in.CategoryIds {
+		book.Categories = append(book.Categories, model.Category{ID: id})
+	}
+	for _, name := range in.Tags {
+		book.Tags = append(book.Tags, model.Tag{Name: name})
+	}
+
+	return book, book.Validation()
+}
+
+func fromListRequest(in *bookpb.ListBooksRequest) (filter model.BookFilter, err error) {
+	if in.Limit < 0 || in.Offset < 0 {
+		return filter, errors.New("limit and offset must not be negative")
+	}
+
+	filter = model.BookFilter{
+		Query:        strings.TrimSpace(in.Query),
+		CategoryID:   in.CategoryId,
+		Tags:         in.Tags,
+		MatchAllTags: in.MatchAllTags,
+		Publisher:    in.Publisher,
+		Language:     strings.ToLower(in.Language),
+		Format:       strings.ToLower(in.Format),
+		SeriesName:   in.SeriesName,
+	}
+	if in.PublishedAfter != "" {
+		date, err := model.ParseDate(in.PublishedAfter)
+		if err != nil {
+			return filter, errors.New("published_after " + err.Error())
+		}
+		filter.PublishedAfter = &date
+	}
+	if in.PublishedBefore != "" {
+		date, err := model.ParseDate(in.PublishedBefore)
+		if err != nil {
+			return filter, errors.New("published_before " + err.Error())
+		}
+		filter.PublishedBefore = &date
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,32 @@
+package rpc
+
+import (
+	"errors"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"ninth-learn/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusError maps the errors of the service to gRPC status codes, the way
+// the REST handlers map them to HTTP statuses
+func statusError(err error) error {
+	switch {
+	case err.Error() == helper.ErrNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case err.Error() == helper.ErrDuplicatedKey:
+		return status.Error(codes.AlreadyExists, "A book with this ISBN already exists")
+	case errors.Is(err, model.ErrBookHasCopies), errors.Is(err, model.ErrLoansActive), errors.Is(err, model.ErrLoansFined):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrTenantSuspended):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
@@ -0,0 +1,37 @@
+// Package rpc serves the book catalogue over gRPC for internal services, on
+// top of the same service as the REST handlers.
+package rpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative -I bookpb bookpb/book.proto
+
+import (
+	"ninth-learn/rpc/bookpb"
+	"ninth-learn/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+var bookServiceName = bookpb.BookService_ServiceDesc.ServiceName
+
+// NewServer returns a gRPC server with the book service, health checks and
+// reflection registered
+func NewServer(app service.ServiceInterface) *grpc.Server {
+	t := newTenants(app)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(t.unary),
+		grpc.ChainStreamInterceptor(t.stream),
+	)
+
+	bookpb.RegisterBookServiceServer(server, &bookServer{})
+
+	checks := health.NewServer()
+	checks.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	checks.SetServingStatus(bookServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, checks)
+
+	reflection.Register(server)
+	return server
+}
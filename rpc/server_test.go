@@ -0,0 +1,205 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"ninth-learn/rpc/bookpb"
+	"ninth-learn/service"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeApp serves a catalogue of numbered books to the tenant "library"
+type fakeApp struct {
+	service.ServiceInterface
+	tenantID int64
+	books    int
+	pages    []model.BookFilter
+	events   chan model.BookEvent
+}
+
+func (f *fakeApp) ResolveTenant(slug string) (model.Tenant, error) {
+	switch slug {
+	case "library":
+		return model.Tenant{ID: 7, Slug: slug}, nil
+	case "closed":
+		return model.Tenant{}, service.ErrTenantSuspended
+	}
+	return model.Tenant{}, errors.New(helper.ErrNotFound)
+}
+
+func (f *fakeApp) WithTenant(tenantID int64) service.ServiceInterface {
+	f.tenantID = tenantID
+	return f
+}
+
+func (f *fakeApp) GetBookById(id int64) (model.Book, error) {
+	if id < 1 || int(id) > f.books {
+		return model.Book{}, errors.New(helper.ErrNotFound)
+	}
+	return model.Book{ID: id, Title: "Book", Tags: []model.Tag{{Name: "jazz-age"}}}, nil
+}
+
+func (f *fakeApp) GetBooks(filter model.BookFilter) ([]model.Book, error) {
+	f.pages = append(f.pages, filter)
+
+	books := []model.Book{}
+	for id := filter.Offset + 1; id <= f.books && len(books) < filter.Limit; id++ {
+		books = append(books, model.Book{ID: int64(id)})
+	}
+	return books, nil
+}
+
+func (f *fakeApp) CreateBook(in model.Book) (model.Book, error) {
+	if in.ISBN13 == "9780743273565" {
+		return in, errors.New(helper.ErrDuplicatedKey)
+	}
+	in.ID = 1
+	return in, nil
+}
+
+func (f *fakeApp) DeleteBook(id int64, force bool) error {
+	if !force {
+		return model.ErrBookHasCopies
+	}
+	return nil
+}
+
+func (f *fakeApp) WatchBooks() (<-chan model.BookEvent, func()) {
+	return f.events, func() {}
+}
+
+func dial(t *testing.T, app service.ServiceInterface) *grpc.ClientConn {
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(app)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func tenant(slug string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), TenantKey, slug)
+}
+
+func Test_BookServer_Get(t *testing.T) {
+	app := &fakeApp{books: 1}
+	client := bookpb.NewBookServiceClient(dial(t, app))
+
+	res, err := client.GetBook(tenant("library"), &bookpb.GetBookRequest{Id: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.Id)
+	assert.Equal(t, []string{"jazz-age"}, res.Tags)
+	assert.Equal(t, int64(7), app.tenantID)
+
+	_, err = client.GetBook(tenant("library"), &bookpb.GetBookRequest{Id: 2})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.GetBook(tenant("nowhere"), &bookpb.GetBookRequest{Id: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.GetBook(tenant("closed"), &bookpb.GetBookRequest{Id: 1})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func Test_BookServer_List(t *testing.T) {
+	tests := []struct {
+		name   string
+		limit  int32
+		offset int32
+		want   int
+		pages  int
+	}{
+		{name: "every book in batches", want: 250, pages: 3},
+		{name: "limit", limit: 120, want: 120, pages: 2},
+		{name: "offset", offset: 240, want: 10, pages: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &fakeApp{books: 250}
+			client := bookpb.NewBookServiceClient(dial(t, app))
+
+			stream, err := client.ListBooks(tenant("library"), &bookpb.ListBooksRequest{Limit: tt.limit, Offset: tt.offset})
+			assert.NoError(t, err)
+
+			got := 0
+			for {
+				book, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				assert.NoError(t, err)
+				got++
+				assert.Equal(t, int64(int(tt.offset)+got), book.Id)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Len(t, app.pages, tt.pages)
+		})
+	}
+}
+
+func Test_BookServer_Mutations(t *testing.T) {
+	client := bookpb.NewBookServiceClient(dial(t, &fakeApp{}))
+	ctx := tenant("library")
+	input := &bookpb.BookInput{Title: "The Great Gatsby", Author: "F. Scott Fitzgerald", Description: "A novel"}
+
+	res, err := client.CreateBook(ctx, &bookpb.CreateBookRequest{Book: input})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.Id)
+
+	_, err = client.CreateBook(ctx, &bookpb.CreateBookRequest{Book: &bookpb.BookInput{Title: "No"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	input.Isbn_13 = "9780743273565"
+	_, err = client.CreateBook(ctx, &bookpb.CreateBookRequest{Book: input})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, err = client.DeleteBook(ctx, &bookpb.DeleteBookRequest{Id: 1})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = client.DeleteBook(ctx, &bookpb.DeleteBookRequest{Id: 1, Force: true})
+	assert.NoError(t, err)
+}
+
+func Test_BookServer_Watch(t *testing.T) {
+	app := &fakeApp{events: make(chan model.BookEvent, 2)}
+	client := bookpb.NewBookServiceClient(dial(t, app))
+
+	stream, err := client.WatchBooks(tenant("library"), &bookpb.WatchBooksRequest{})
+	assert.NoError(t, err)
+
+	app.events <- model.BookEvent{Type: model.BookDeleted, BookID: 3}
+	e, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, bookpb.BookEvent_DELETED, e.Type)
+	assert.Equal(t, int64(3), e.BookId)
+	assert.Nil(t, e.Book)
+
+	// the feed dropped the watcher
+	close(app.events)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func Test_Health(t *testing.T) {
+	client := healthpb.NewHealthClient(dial(t, &fakeApp{}))
+
+	res, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: bookServiceName})
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+}
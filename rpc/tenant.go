@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"context"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"ninth-learn/service"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TenantKey is the metadata key naming the tenant of a call, the gRPC
+// counterpart of the X-Tenant-ID header
+const TenantKey = "x-tenant-id"
+
+type tenantKey struct{}
+
+// tenants resolves the tenant of each call from its metadata, falling back
+// to DEFAULT_TENANT, and hands the handlers the service scoped to it
+type tenants struct {
+	app      service.ServiceInterface
+	fallback string
+}
+
+func newTenants(app service.ServiceInterface) tenants {
+	fallback := os.Getenv("DEFAULT_TENANT")
+	if fallback == "" {
+		fallback = model.DefaultTenantSlug
+	}
+	return tenants{app: app, fallback: fallback}
+}
+
+func (t tenants) scope(ctx context.Context) (context.Context, error) {
+	slug := t.fallback
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(TenantKey); len(values) > 0 && strings.TrimSpace(values[0]) != "" {
+			slug = strings.TrimSpace(values[0])
+		}
+	}
+
+	tenant, err := t.app.ResolveTenant(slug)
+	if err != nil {
+		if err.Error() == helper.ErrNotFound {
+			return ctx, status.Error(codes.NotFound, "Unknown tenant")
+		}
+		return ctx, statusError(err)
+	}
+	return context.WithValue(ctx, tenantKey{}, t.app.WithTenant(tenant.ID)), nil
+}
+
+func (t tenants) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !isBookService(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	ctx, err := t.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (t tenants) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !isBookService(info.FullMethod) {
+		return handler(srv, ss)
+	}
+	ctx, err := t.scope(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, scopedStream{ServerStream: ss, ctx: ctx})
+}
+
+// health and reflection are not tenant scoped
+func isBookService(method string) bool {
+	return strings.HasPrefix(method, "/"+bookServiceName+"/")
+}
+
+type scopedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s scopedStream) Context() context.Context {
+	return s.ctx
+}
+
+// tenantApp returns the service scoped to the tenant of the call
+func tenantApp(ctx context.Context) service.ServiceInterface {
+	return ctx.Value(tenantKey{}).(service.ServiceInterface)
+}
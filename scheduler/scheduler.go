@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Job is a unit of periodic work
+type Job struct {
+	Name string
+	Run  func() error
+}
+
+// Scheduler runs its jobs one after another every interval, starting right
+// away, until stopped
+type Scheduler struct {
+	interval time.Duration
+	jobs     []Job
+	stop     chan struct{}
+	done     sync.WaitGroup
+}
+
+func New(interval time.Duration, jobs ...Job) *Scheduler {
+	return &Scheduler{interval: interval, jobs: jobs, stop: make(chan struct{})}
+}
+
+func (s *Scheduler) Start() {
+	s.done.Add(1)
+	go func() {
+		defer s.done.Done()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.runJobs()
+
+			select {
+			case <-ticker.C:
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop waits for the running round of jobs to finish
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.done.Wait()
+}
+
+func (s *Scheduler) runJobs() {
+	for _, job := range s.jobs {
+		s.runJob(job)
+	}
+}
+
+// runJob keeps a failing or panicking job from taking the others down
+func (s *Scheduler) runJob(job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("scheduler: %s panicked: %v", job.Name, r)
+		}
+	}()
+
+	if err := job.Run(); err != nil {
+		log.Printf("scheduler: %s: %v", job.Name, err)
+	}
+}
@@ -0,0 +1,31 @@
+package scheduler
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Scheduler(t *testing.T) {
+	var runs, after int32
+
+	s := New(10*time.Millisecond,
+		Job{Name: "failing", Run: func() error { return errors.New("boom") }},
+		Job{Name: "panicking", Run: func() error { panic("boom") }},
+		Job{Name: "counting", Run: func() error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		}},
+	)
+	s.Start()
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&runs) >= 3 }, time.Second, 5*time.Millisecond)
+
+	s.Stop()
+	after = atomic.LoadInt32(&runs)
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, after, atomic.LoadInt32(&runs))
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"errors"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+)
+
+var ErrAuthorExists = errors.New("author already exists")
+
+type AuthorService interface {
+	GetAuthors() ([]model.Author, error)
+	CreateAuthor(in model.Author) (res model.Author, err error)
+	GetAuthorById(id int64) (model.Author, error)
+	UpdateAuthor(in model.Author) (res model.Author, err error)
+	DeleteAuthor(id int64) (err error)
+	GetAuthorBooks(id int64) ([]model.Book, error)
+}
+
+func (s Service) GetAuthors() ([]model.Author, error) {
+	return s.repo.GetAuthors()
+}
+
+// CreateAuthor refuses spelling variants of an author that already exists
+func (s *Service) CreateAuthor(in model.Author) (res model.Author, err error) {
+	_, err = s.repo.GetAuthorByName(in.Name)
+	if err == nil {
+		return res, ErrAuthorExists
+	}
+	if err.Error() != helper.ErrNotFound {
+		return res, err
+	}
+
+	return s.repo.CreateAuthor(in)
+}
+
+func (s *Service) GetAuthorById(id int64) (res model.Author, err error) {
+	return s.repo.GetAuthorById(id)
+}
+
+// UpdateAuthor purges the books showing the author's name
+func (s *Service) UpdateAuthor(in model.Author) (res model.Author, err error) {
+	res, bookIDs, err := s.repo.UpdateAuthor(in)
+	if err != nil {
+		return res, err
+	}
+	s.bookChanged(bookIDs...)
+	return res, nil
+}
+
+func (s *Service) DeleteAuthor(id int64) (err error) {
+	bookIDs, err := s.repo.DeleteAuthor(id)
+	if err != nil {
+		return err
+	}
+	s.bookChanged(bookIDs...)
+	return nil
+}
+
+func (s *Service) GetAuthorBooks(id int64) ([]model.Book, error) {
+	_, err := s.repo.GetAuthorById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetBooksByAuthor(id)
+}
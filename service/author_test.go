@@ -0,0 +1,173 @@
+package service
+
+import (
+	"errors"
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AuthorService_CreateAuthor(t *testing.T) {
+	type testCase struct {
+		name           string
+		wantError      bool
+		input          model.Author
+		expectedResult model.Author
+		expectedError  error
+		onAuthorRepo   func(mock *mocks.MockAuthorRepo)
+	}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "success",
+		wantError: false,
+		input: model.Author{
+			Name: "F. Scott Fitzgerald",
+		},
+		onAuthorRepo: func(mock *mocks.MockAuthorRepo) {
+			mock.EXPECT().GetAuthorByName("F. Scott Fitzgerald").Return(model.Author{}, errors.New("record not found")).Times(1)
+			mock.EXPECT().CreateAuthor(gomock.Any()).Return(model.Author{
+				ID:        1,
+				Name:      "F. Scott Fitzgerald",
+				CreatedAt: time.Date(2023, time.April, 22, 14, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2023, time.April, 22, 14, 0, 0, 0, time.UTC),
+			}, nil).Times(1)
+		},
+		expectedResult: model.Author{
+			ID:        1,
+			Name:      "F. Scott Fitzgerald",
+			CreatedAt: time.Date(2023, time.April, 22, 14, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2023, time.April, 22, 14, 0, 0, 0, time.UTC),
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:      "spelling variant of existing author",
+		wantError: true,
+		input: model.Author{
+			Name: "F Scott Fitzgerald",
+		},
+		expectedError: ErrAuthorExists,
+		onAuthorRepo: func(mock *mocks.MockAuthorRepo) {
+			mock.EXPECT().GetAuthorByName("F Scott Fitzgerald").Return(model.Author{
+				ID:   1,
+				Name: "F. Scott Fitzgerald",
+			}, nil).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:      "unexpected error",
+		wantError: true,
+		input: model.Author{
+			Name: "Harper Lee",
+		},
+		expectedError: errors.New("unexpected error"),
+		onAuthorRepo: func(mock *mocks.MockAuthorRepo) {
+			mock.EXPECT().GetAuthorByName("Harper Lee").Return(model.Author{}, errors.New("unexpected error")).Times(1)
+		},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			authorRepo := mocks.NewMockAuthorRepo(mockCtrl)
+
+			if testCase.onAuthorRepo != nil {
+				testCase.onAuthorRepo(authorRepo)
+			}
+
+			service := Service{
+				repo: mockRepo{MockAuthorRepo: authorRepo},
+			}
+
+			res, err := service.CreateAuthor(testCase.input)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, testCase.expectedResult, res)
+			}
+		})
+	}
+}
+
+func Test_AuthorService_GetAuthorBooks(t *testing.T) {
+	type testCase struct {
+		name           string
+		id             int64
+		wantError      bool
+		expectedResult []model.Book
+		expectedError  error
+		onAuthorRepo   func(mock *mocks.MockAuthorRepo)
+	}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "success",
+		id:        1,
+		wantError: false,
+		onAuthorRepo: func(mock *mocks.MockAuthorRepo) {
+			mock.EXPECT().GetAuthorById(int64(1)).Return(model.Author{ID: 1, Name: "George Orwell"}, nil).Times(1)
+			mock.EXPECT().GetBooksByAuthor(int64(1)).Return([]model.Book{
+				{
+					ID:          2,
+					Title:       "1984",
+					Author:      "George Orwell",
+					Description: "A dystopian novel",
+				},
+			}, nil).Times(1)
+		},
+		expectedResult: []model.Book{
+			{
+				ID:          2,
+				Title:       "1984",
+				Author:      "George Orwell",
+				Description: "A dystopian novel",
+			},
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "record not found",
+		id:            2,
+		wantError:     true,
+		expectedError: errors.New("record not found"),
+		onAuthorRepo: func(mock *mocks.MockAuthorRepo) {
+			mock.EXPECT().GetAuthorById(int64(2)).Return(model.Author{}, errors.New("record not found")).Times(1)
+		},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			authorRepo := mocks.NewMockAuthorRepo(mockCtrl)
+
+			if testCase.onAuthorRepo != nil {
+				testCase.onAuthorRepo(authorRepo)
+			}
+
+			service := Service{
+				repo: mockRepo{MockAuthorRepo: authorRepo},
+			}
+
+			res, err := service.GetAuthorBooks(testCase.id)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, testCase.expectedResult, res)
+			}
+		})
+	}
+}
@@ -2,32 +2,120 @@ package service
 
 // usecase
 
-import "ninth-learn/model"
+import (
+	"errors"
+	"log"
+	"ninth-learn/model"
+	"time"
+)
 
 type BookService interface {
-	GetBooks() ([]model.Book, error)
+	GetBooks(filter model.BookFilter) ([]model.Book, error)
+	CountBooks(filter model.BookFilter) (int64, error)
+	// BooksModifiedAt dates every list of the tenant's books
+	BooksModifiedAt() (time.Time, error)
+	GetBooksByIds(ids []int64) ([]model.Book, error)
 	CreateBook(in model.Book) (res model.Book, err error)
 	GetBookById(id int64) (model.Book, error)
+	GetBookByISBN(isbn string) (model.Book, error)
 	UpdateBook(in model.Book) (res model.Book, err error)
-	DeleteBook(id int64) (err error)
+	DeleteBook(id int64, force bool) (err error)
 }
 
+// CreateBook warns about likely duplicates already in the catalogue through
+// the possible duplicates of the new book, it is created regardless
 func (s *Service) CreateBook(in model.Book) (res model.Book, err error) {
-	return s.repo.CreateBook(in)
+	in.NormalizeISBN()
+	in.NormalizeMetadata()
+
+	res, err = s.repo.CreateBook(in)
+	if err != nil {
+		return res, err
+	}
+	s.indexBook(res)
+	s.bookChanged(res.ID)
+	s.notifyBook(model.BookChangeInsert, res.TenantID, res.ID)
+
+	book := model.BookSummary{
+		ID:     res.ID,
+		Title:  res.Title,
+		Author: res.Author,
+		ISBN10: res.ISBN10,
+		ISBN13: res.ISBN13,
+	}
+	// the database picks the candidates, they are matched the way the
+	// catalogue-wide search matches books
+	others, err := s.repo.GetDuplicateCandidates(book)
+	if err != nil {
+		log.Printf("find duplicates of book %d: %v", res.ID, err)
+		return res, nil
+	}
+	res.Duplicates = matchBook(book, others)
+	return res, nil
 }
 
 func (s *Service) GetBookById(id int64) (res model.Book, err error) {
 	return s.repo.GetBookById(id)
 }
 
-func (s Service) GetBooks() ([]model.Book, error) {
-	return s.repo.GetBooks()
+func (s Service) GetBooks(filter model.BookFilter) ([]model.Book, error) {
+	return s.repo.GetBooks(filter)
+}
+
+func (s Service) CountBooks(filter model.BookFilter) (int64, error) {
+	return s.repo.CountBooks(filter)
+}
+
+func (s Service) BooksModifiedAt() (time.Time, error) {
+	return s.repo.BooksModifiedAt()
+}
+
+// GetBooksByIds returns the books found among the ids, in no particular order
+func (s *Service) GetBooksByIds(ids []int64) ([]model.Book, error) {
+	if len(ids) == 0 {
+		return []model.Book{}, nil
+	}
+	return s.repo.GetBooksByIds(ids)
+}
+
+// GetBookByISBN accepts either form of the ISBN, with or without hyphens
+func (s *Service) GetBookByISBN(isbn string) (res model.Book, err error) {
+	isbn13, err := model.CanonicalISBN(isbn)
+	if err != nil {
+		return res, err
+	}
+
+	return s.repo.GetBookByISBN(isbn13)
 }
 
 func (s *Service) UpdateBook(in model.Book) (res model.Book, err error) {
-	return s.repo.UpdateBook(in)
+	in.NormalizeISBN()
+	in.NormalizeMetadata()
+
+	res, err = s.repo.UpdateBook(in)
+	if err != nil {
+		return res, err
+	}
+	s.indexBook(res)
+	s.bookChanged(res.ID)
+	s.notifyBook(model.BookChangeUpdate, res.TenantID, res.ID)
+	return res, nil
 }
 
-func (s *Service) DeleteBook(id int64) (err error) {
-	return s.repo.DeleteBook(id)
+// DeleteBook refuses to drop a book that still has copies unless forced,
+// a forced delete removes the copies along with it. Loans still out or
+// fined are never dropped. the repo checks both under the lock of the book.
+func (s *Service) DeleteBook(id int64, force bool) (err error) {
+	// the book is gone even when its cover was left behind, the caller is
+	// not to retry the delete
+	err = s.repo.DeleteBook(id, force)
+	if errors.Is(err, model.ErrCoverLeft) {
+		log.Printf("delete book %d: %v", id, err)
+	} else if err != nil {
+		return err
+	}
+	s.similar.Remove(s.tenantID, id)
+	s.bookChanged(id)
+	s.notifyBook(model.BookChangeDelete, s.tenantID, id)
+	return nil
 }
@@ -18,6 +18,7 @@ func Test_BookService_CreateBook(t *testing.T) {
 		input          model.Book
 		expectedResult model.Book
 		expectedError  error
+		candidates     []model.BookSummary
 		onBookRepo     func(mock *mocks.MockBookRepo)
 	}
 
@@ -51,6 +52,77 @@ func Test_BookService_CreateBook(t *testing.T) {
 		},
 	})
 
+	testTable = append(testTable, testCase{
+		name:      "isbn normalized",
+		wantError: false,
+		input: model.Book{
+			Title:       "The Great Gatsby",
+			Author:      "F. Scott Fitzgerald",
+			Description: "A novel about the decadence of the Jazz Age",
+			ISBN10:      "0-7432-7356-7",
+		},
+		onBookRepo: func(mock *mocks.MockBookRepo) {
+			mock.EXPECT().CreateBook(model.Book{
+				Title:       "The Great Gatsby",
+				Author:      "F. Scott Fitzgerald",
+				Description: "A novel about the decadence of the Jazz Age",
+				ISBN10:      "0743273567",
+				ISBN13:      "9780743273565",
+			}).Return(model.Book{
+				ID:          1,
+				Title:       "The Great Gatsby",
+				Author:      "F. Scott Fitzgerald",
+				Description: "A novel about the decadence of the Jazz Age",
+				ISBN10:      "0743273567",
+				ISBN13:      "9780743273565",
+			}, nil).Times(1)
+		},
+		expectedResult: model.Book{
+			ID:          1,
+			Title:       "The Great Gatsby",
+			Author:      "F. Scott Fitzgerald",
+			Description: "A novel about the decadence of the Jazz Age",
+			ISBN10:      "0743273567",
+			ISBN13:      "9780743273565",
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:      "metadata normalized",
+		wantError: false,
+		input: model.Book{
+			Title:       "Le Petit Prince",
+			Author:      "Antoine de Saint-Exupery",
+			Description: "A poetic tale about a young prince",
+			Language:    " FR",
+			Format:      "Hardcover",
+		},
+		onBookRepo: func(mock *mocks.MockBookRepo) {
+			mock.EXPECT().CreateBook(model.Book{
+				Title:       "Le Petit Prince",
+				Author:      "Antoine de Saint-Exupery",
+				Description: "A poetic tale about a young prince",
+				Language:    "fr",
+				Format:      model.FormatHardcover,
+			}).Return(model.Book{
+				ID:          3,
+				Title:       "Le Petit Prince",
+				Author:      "Antoine de Saint-Exupery",
+				Description: "A poetic tale about a young prince",
+				Language:    "fr",
+				Format:      model.FormatHardcover,
+			}, nil).Times(1)
+		},
+		expectedResult: model.Book{
+			ID:          3,
+			Title:       "Le Petit Prince",
+			Author:      "Antoine de Saint-Exupery",
+			Description: "A poetic tale about a young prince",
+			Language:    "fr",
+			Format:      model.FormatHardcover,
+		},
+	})
+
 	testTable = append(testTable, testCase{
 		name:      "unexpected error",
 		wantError: true,
@@ -107,18 +179,59 @@ func Test_BookService_CreateBook(t *testing.T) {
 		},
 	})
 
+	testTable = append(testTable, testCase{
+		name:      "warns on likely duplicate",
+		wantError: false,
+		input: model.Book{
+			Title:       "Great Gatsby",
+			Author:      "F Scott Fitzgerald",
+			Description: "A novel about the decadence of the Jazz Age",
+		},
+		candidates: []model.BookSummary{
+			{ID: 1, Title: "The Great Gatsby", Author: "F. Scott Fitzgerald"},
+			{ID: 2, Title: "Tender Is the Night", Author: "F. Scott Fitzgerald"},
+			{ID: 3, Title: "Great Gatsby", Author: "F Scott Fitzgerald"},
+		},
+		onBookRepo: func(mock *mocks.MockBookRepo) {
+			mock.EXPECT().CreateBook(gomock.Any()).Return(model.Book{
+				ID:     3,
+				Title:  "Great Gatsby",
+				Author: "F Scott Fitzgerald",
+			}, nil).Times(1)
+		},
+		expectedResult: model.Book{
+			ID:     3,
+			Title:  "Great Gatsby",
+			Author: "F Scott Fitzgerald",
+			Duplicates: []model.DuplicateMatch{
+				{BookID: 3, OtherID: 1, Score: 1, Reason: model.DuplicateReasonSimilar},
+			},
+		},
+	})
+
 	for _, testCase := range testTable {
 		t.Run(testCase.name, func(t *testing.T) {
 			mockCtrl := gomock.NewController(t)
 
 			bookRepo := mocks.NewMockBookRepo(mockCtrl)
+			duplicateRepo := mocks.NewMockDuplicateRepo(mockCtrl)
 
 			if testCase.onBookRepo != nil {
 				testCase.onBookRepo(bookRepo)
 			}
+			if !testCase.wantError {
+				want := testCase.expectedResult
+				duplicateRepo.EXPECT().GetDuplicateCandidates(model.BookSummary{
+					ID:     want.ID,
+					Title:  want.Title,
+					Author: want.Author,
+					ISBN10: want.ISBN10,
+					ISBN13: want.ISBN13,
+				}).Return(testCase.candidates, nil).Times(1)
+			}
 
 			service := Service{
-				repo: bookRepo,
+				repo: mockRepo{MockBookRepo: bookRepo, MockDuplicateRepo: duplicateRepo},
 			}
 
 			res, err := service.CreateBook(testCase.input)
@@ -200,7 +313,7 @@ func Test_BookService_GetBookById(t *testing.T) {
 			}
 
 			service := Service{
-				repo: bookRepo,
+				repo: mockRepo{MockBookRepo: bookRepo},
 			}
 
 			res, err := service.GetBookById(testCase.id)
@@ -230,7 +343,7 @@ func Test_BookService_GetBooks(t *testing.T) {
 		name:      "success",
 		wantError: false,
 		onBookRepo: func(mock *mocks.MockBookRepo) {
-			mock.EXPECT().GetBooks().Return([]model.Book{
+			mock.EXPECT().GetBooks(gomock.Any()).Return([]model.Book{
 				{
 					ID:          1,
 					Title:       "The Hitchhiker's Guide to the Galaxy",
@@ -274,7 +387,7 @@ func Test_BookService_GetBooks(t *testing.T) {
 		wantError:     true,
 		expectedError: errors.New("unexpected error"),
 		onBookRepo: func(mock *mocks.MockBookRepo) {
-			mock.EXPECT().GetBooks().Return([]model.Book{}, errors.New("unexpected error")).Times(1)
+			mock.EXPECT().GetBooks(gomock.Any()).Return([]model.Book{}, errors.New("unexpected error")).Times(1)
 		},
 	})
 
@@ -289,10 +402,10 @@ func Test_BookService_GetBooks(t *testing.T) {
 			}
 
 			service := Service{
-				repo: bookRepo,
+				repo: mockRepo{MockBookRepo: bookRepo},
 			}
 
-			res, err := service.GetBooks()
+			res, err := service.GetBooks(model.BookFilter{})
 
 			if testCase.wantError {
 				assert.EqualError(t, err, testCase.expectedError.Error())
@@ -373,7 +486,7 @@ func Test_BookService_UpdateBook(t *testing.T) {
 			}
 
 			service := Service{
-				repo: bookRepo,
+				repo: mockRepo{MockBookRepo: bookRepo},
 			}
 
 			res, err := service.UpdateBook(testCase.input)
@@ -393,6 +506,7 @@ func Test_BookService_DeleteBook(t *testing.T) {
 		name          string
 		wantError     bool
 		input         int64
+		force         bool
 		expectedError error
 		onBookRepo    func(mock *mocks.MockBookRepo)
 	}
@@ -404,7 +518,7 @@ func Test_BookService_DeleteBook(t *testing.T) {
 		wantError: false,
 		input:     1,
 		onBookRepo: func(mock *mocks.MockBookRepo) {
-			mock.EXPECT().DeleteBook(gomock.Any()).Return(nil).Times(1)
+			mock.EXPECT().DeleteBook(int64(1), false).Return(nil).Times(1)
 		},
 	})
 
@@ -414,7 +528,7 @@ func Test_BookService_DeleteBook(t *testing.T) {
 		input:         1,
 		expectedError: errors.New("unexpected error"),
 		onBookRepo: func(mock *mocks.MockBookRepo) {
-			mock.EXPECT().DeleteBook(gomock.Any()).Return(errors.New("unexpected error")).Times(1)
+			mock.EXPECT().DeleteBook(int64(1), false).Return(errors.New("unexpected error")).Times(1)
 		},
 	})
 
@@ -424,7 +538,125 @@ func Test_BookService_DeleteBook(t *testing.T) {
 		input:         1,
 		expectedError: errors.New("book not found"),
 		onBookRepo: func(mock *mocks.MockBookRepo) {
-			mock.EXPECT().DeleteBook(gomock.Any()).Return(errors.New("book not found")).Times(1)
+			mock.EXPECT().DeleteBook(int64(1), false).Return(errors.New("book not found")).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:      "cover left",
+		wantError: false,
+		input:     1,
+		onBookRepo: func(mock *mocks.MockBookRepo) {
+			mock.EXPECT().DeleteBook(int64(1), false).Return(model.ErrCoverLeft).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "has copies",
+		wantError:     true,
+		input:         1,
+		expectedError: model.ErrBookHasCopies,
+		onBookRepo: func(mock *mocks.MockBookRepo) {
+			mock.EXPECT().DeleteBook(int64(1), false).Return(model.ErrBookHasCopies).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:      "forced with copies",
+		wantError: false,
+		input:     1,
+		force:     true,
+		onBookRepo: func(mock *mocks.MockBookRepo) {
+			mock.EXPECT().DeleteBook(int64(1), true).Return(nil).Times(1)
+		},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			bookRepo := mocks.NewMockBookRepo(mockCtrl)
+			testCase.onBookRepo(bookRepo)
+
+			service := Service{
+				repo: mockRepo{MockBookRepo: bookRepo},
+			}
+
+			err := service.DeleteBook(testCase.input, testCase.force)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func Test_BookService_GetBookByISBN(t *testing.T) {
+	type testCase struct {
+		name           string
+		isbn           string
+		wantError      bool
+		expectedResult model.Book
+		expectedError  error
+		onBookRepo     func(mock *mocks.MockBookRepo)
+	}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "hyphenated isbn-10",
+		isbn:      "0-7432-7356-7",
+		wantError: false,
+		onBookRepo: func(mock *mocks.MockBookRepo) {
+			mock.EXPECT().GetBookByISBN("9780743273565").Return(model.Book{
+				ID:     1,
+				Title:  "The Great Gatsby",
+				Author: "F. Scott Fitzgerald",
+				ISBN10: "0743273567",
+				ISBN13: "9780743273565",
+			}, nil).Times(1)
+		},
+		expectedResult: model.Book{
+			ID:     1,
+			Title:  "The Great Gatsby",
+			Author: "F. Scott Fitzgerald",
+			ISBN10: "0743273567",
+			ISBN13: "9780743273565",
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:      "isbn-13",
+		isbn:      "978-0-306-40615-7",
+		wantError: false,
+		onBookRepo: func(mock *mocks.MockBookRepo) {
+			mock.EXPECT().GetBookByISBN("9780306406157").Return(model.Book{
+				ID:     2,
+				ISBN13: "9780306406157",
+			}, nil).Times(1)
+		},
+		expectedResult: model.Book{
+			ID:     2,
+			ISBN13: "9780306406157",
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "bad checksum",
+		isbn:          "978-0-306-40615-8",
+		wantError:     true,
+		expectedError: model.ErrInvalidISBN,
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "record not found",
+		isbn:          "080442957X",
+		wantError:     true,
+		expectedError: errors.New("record not found"),
+		onBookRepo: func(mock *mocks.MockBookRepo) {
+			mock.EXPECT().GetBookByISBN("9780804429573").Return(model.Book{}, errors.New("record not found")).Times(1)
 		},
 	})
 
@@ -439,15 +671,16 @@ func Test_BookService_DeleteBook(t *testing.T) {
 			}
 
 			service := Service{
-				repo: bookRepo,
+				repo: mockRepo{MockBookRepo: bookRepo},
 			}
 
-			err := service.DeleteBook(testCase.input)
+			res, err := service.GetBookByISBN(testCase.isbn)
 
 			if testCase.wantError {
 				assert.EqualError(t, err, testCase.expectedError.Error())
 			} else {
 				assert.Nil(t, err)
+				assert.Equal(t, testCase.expectedResult, res)
 			}
 		})
 	}
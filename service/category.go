@@ -0,0 +1,82 @@
+package service
+
+import (
+	"errors"
+	"ninth-learn/model"
+)
+
+var (
+	ErrCategoryCycle       = errors.New("category cannot be moved below itself")
+	ErrCategoryHasChildren = errors.New("category still has subcategories")
+)
+
+type CategoryService interface {
+	GetCategories() ([]model.Category, error)
+	CreateCategory(in model.Category) (res model.Category, err error)
+	GetCategoryById(id int64) (model.Category, error)
+	UpdateCategory(in model.Category) (res model.Category, err error)
+	DeleteCategory(id int64) (err error)
+}
+
+func (s Service) GetCategories() ([]model.Category, error) {
+	return s.repo.GetCategories()
+}
+
+func (s *Service) CreateCategory(in model.Category) (res model.Category, err error) {
+	if in.ParentID != nil {
+		// the parent has to exist within the tenant
+		if _, err := s.repo.GetCategoryById(*in.ParentID); err != nil {
+			return res, err
+		}
+	}
+
+	return s.repo.CreateCategory(in)
+}
+
+func (s *Service) GetCategoryById(id int64) (res model.Category, err error) {
+	return s.repo.GetCategoryById(id)
+}
+
+// UpdateCategory refuses to move a category below itself or its descendants
+func (s *Service) UpdateCategory(in model.Category) (res model.Category, err error) {
+	if in.ParentID != nil {
+		if _, err := s.repo.GetCategoryById(*in.ParentID); err != nil {
+			return res, err
+		}
+
+		descendants, err := s.repo.GetCategoryDescendantIds(in.ID)
+		if err != nil {
+			return res, err
+		}
+		for _, id := range descendants {
+			if id == *in.ParentID {
+				return res, ErrCategoryCycle
+			}
+		}
+	}
+
+	res, bookIDs, err := s.repo.UpdateCategory(in)
+	if err != nil {
+		return res, err
+	}
+	// the books show the category's name
+	s.bookChanged(bookIDs...)
+	return res, nil
+}
+
+func (s *Service) DeleteCategory(id int64) (err error) {
+	children, err := s.repo.CountCategoryChildren(id)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return ErrCategoryHasChildren
+	}
+
+	bookIDs, err := s.repo.DeleteCategory(id)
+	if err != nil {
+		return err
+	}
+	s.bookChanged(bookIDs...)
+	return nil
+}
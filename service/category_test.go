@@ -0,0 +1,161 @@
+package service
+
+import (
+	"errors"
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CategoryService_UpdateCategory(t *testing.T) {
+	type testCase struct {
+		name           string
+		wantError      bool
+		input          model.Category
+		expectedResult model.Category
+		expectedError  error
+		onCategoryRepo func(mock *mocks.MockCategoryRepo)
+	}
+
+	parentID := int64(2)
+	childID := int64(3)
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "success",
+		wantError: false,
+		input: model.Category{
+			ID:       1,
+			ParentID: &parentID,
+			Name:     "Science Fiction",
+		},
+		onCategoryRepo: func(mock *mocks.MockCategoryRepo) {
+			mock.EXPECT().GetCategoryById(parentID).Return(model.Category{ID: parentID, Name: "Fiction"}, nil).Times(1)
+			mock.EXPECT().GetCategoryDescendantIds(int64(1)).Return([]int64{1, childID}, nil).Times(1)
+			mock.EXPECT().UpdateCategory(gomock.Any()).Return(model.Category{
+				ID:       1,
+				ParentID: &parentID,
+				Name:     "Science Fiction",
+			}, []int64{4}, nil).Times(1)
+		},
+		expectedResult: model.Category{
+			ID:       1,
+			ParentID: &parentID,
+			Name:     "Science Fiction",
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:      "moved below its own descendant",
+		wantError: true,
+		input: model.Category{
+			ID:       1,
+			ParentID: &childID,
+			Name:     "Science Fiction",
+		},
+		expectedError: ErrCategoryCycle,
+		onCategoryRepo: func(mock *mocks.MockCategoryRepo) {
+			mock.EXPECT().GetCategoryById(childID).Return(model.Category{ID: childID, Name: "Space Opera"}, nil).Times(1)
+			mock.EXPECT().GetCategoryDescendantIds(int64(1)).Return([]int64{1, childID}, nil).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:      "parent not found",
+		wantError: true,
+		input: model.Category{
+			ID:       1,
+			ParentID: &parentID,
+			Name:     "Science Fiction",
+		},
+		expectedError: errors.New("record not found"),
+		onCategoryRepo: func(mock *mocks.MockCategoryRepo) {
+			mock.EXPECT().GetCategoryById(parentID).Return(model.Category{}, errors.New("record not found")).Times(1)
+		},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			categoryRepo := mocks.NewMockCategoryRepo(mockCtrl)
+
+			if testCase.onCategoryRepo != nil {
+				testCase.onCategoryRepo(categoryRepo)
+			}
+
+			service := Service{
+				repo: mockRepo{MockCategoryRepo: categoryRepo},
+			}
+
+			res, err := service.UpdateCategory(testCase.input)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, testCase.expectedResult, res)
+			}
+		})
+	}
+}
+
+func Test_CategoryService_DeleteCategory(t *testing.T) {
+	type testCase struct {
+		name           string
+		wantError      bool
+		input          int64
+		expectedError  error
+		onCategoryRepo func(mock *mocks.MockCategoryRepo)
+	}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "success",
+		wantError: false,
+		input:     1,
+		onCategoryRepo: func(mock *mocks.MockCategoryRepo) {
+			mock.EXPECT().CountCategoryChildren(int64(1)).Return(int64(0), nil).Times(1)
+			mock.EXPECT().DeleteCategory(int64(1)).Return([]int64{4}, nil).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "has subcategories",
+		wantError:     true,
+		input:         1,
+		expectedError: ErrCategoryHasChildren,
+		onCategoryRepo: func(mock *mocks.MockCategoryRepo) {
+			mock.EXPECT().CountCategoryChildren(int64(1)).Return(int64(2), nil).Times(1)
+		},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			categoryRepo := mocks.NewMockCategoryRepo(mockCtrl)
+
+			if testCase.onCategoryRepo != nil {
+				testCase.onCategoryRepo(categoryRepo)
+			}
+
+			service := Service{
+				repo: mockRepo{MockCategoryRepo: categoryRepo},
+			}
+
+			err := service.DeleteCategory(testCase.input)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+	"ninth-learn/model"
+	"strings"
+)
+
+var (
+	ErrCopyOnLoan = errors.New("copy is on loan, return it first")
+	ErrCopyOnHold = errors.New("copy is set aside for a hold, cancel the hold first")
+)
+
+type CopyService interface {
+	GetCopies(bookID int64) ([]model.Copy, error)
+	CreateCopy(in model.Copy) (res model.Copy, err error)
+	GetCopyById(bookID, id int64) (model.Copy, error)
+	GetCopyByBarcode(barcode string) (model.Copy, error)
+	UpdateCopy(in model.Copy) (res model.Copy, err error)
+	DeleteCopy(bookID, id int64) (err error)
+}
+
+// GetCopies lists the copies of a book, failing when the book is unknown
+func (s *Service) GetCopies(bookID int64) ([]model.Copy, error) {
+	if _, err := s.repo.GetBookById(bookID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetCopies(bookID)
+}
+
+func (s *Service) CreateCopy(in model.Copy) (res model.Copy, err error) {
+	if _, err := s.repo.GetBookById(in.BookID); err != nil {
+		return res, err
+	}
+
+	in.Normalize()
+	return s.repo.CreateCopy(in)
+}
+
+func (s *Service) GetCopyById(bookID, id int64) (res model.Copy, err error) {
+	return s.repo.GetCopyById(bookID, id)
+}
+
+func (s *Service) GetCopyByBarcode(barcode string) (res model.Copy, err error) {
+	return s.repo.GetCopyByBarcode(strings.TrimSpace(barcode))
+}
+
+func (s *Service) UpdateCopy(in model.Copy) (res model.Copy, err error) {
+	in.Normalize()
+	return s.repo.UpdateCopy(in)
+}
+
+func (s *Service) DeleteCopy(bookID, id int64) (err error) {
+	cp, err := s.repo.GetCopyById(bookID, id)
+	if err != nil {
+		return err
+	}
+	switch cp.Status {
+	case model.CopyStatusOnLoan:
+		return ErrCopyOnLoan
+	case model.CopyStatusOnHold:
+		return ErrCopyOnHold
+	}
+
+	return s.repo.DeleteCopy(bookID, id)
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"errors"
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CopyService_CreateCopy(t *testing.T) {
+	type testCase struct {
+		name           string
+		wantError      bool
+		input          model.Copy
+		expectedResult model.Copy
+		expectedError  error
+		onBookRepo     func(mock *mocks.MockBookRepo)
+		onCopyRepo     func(mock *mocks.MockCopyRepo)
+	}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "defaults filled in",
+		wantError: false,
+		input: model.Copy{
+			BookID:  1,
+			Barcode: " LIB-0001 ",
+		},
+		onBookRepo: func(mock *mocks.MockBookRepo) {
+			mock.EXPECT().GetBookById(int64(1)).Return(model.Book{ID: 1}, nil).Times(1)
+		},
+		onCopyRepo: func(mock *mocks.MockCopyRepo) {
+			mock.EXPECT().CreateCopy(model.Copy{
+				BookID:    1,
+				Barcode:   "LIB-0001",
+				Condition: model.CopyConditionGood,
+				Status:    model.CopyStatusAvailable,
+			}).Return(model.Copy{
+				ID:        1,
+				BookID:    1,
+				Barcode:   "LIB-0001",
+				Condition: model.CopyConditionGood,
+				Status:    model.CopyStatusAvailable,
+			}, nil).Times(1)
+		},
+		expectedResult: model.Copy{
+			ID:        1,
+			BookID:    1,
+			Barcode:   "LIB-0001",
+			Condition: model.CopyConditionGood,
+			Status:    model.CopyStatusAvailable,
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:      "book not found",
+		wantError: true,
+		input: model.Copy{
+			BookID:  1,
+			Barcode: "LIB-0001",
+		},
+		expectedError: errors.New("record not found"),
+		onBookRepo: func(mock *mocks.MockBookRepo) {
+			mock.EXPECT().GetBookById(int64(1)).Return(model.Book{}, errors.New("record not found")).Times(1)
+		},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			bookRepo := mocks.NewMockBookRepo(mockCtrl)
+			copyRepo := mocks.NewMockCopyRepo(mockCtrl)
+
+			if testCase.onBookRepo != nil {
+				testCase.onBookRepo(bookRepo)
+			}
+			if testCase.onCopyRepo != nil {
+				testCase.onCopyRepo(copyRepo)
+			}
+
+			service := Service{
+				repo: mockRepo{MockBookRepo: bookRepo, MockCopyRepo: copyRepo},
+			}
+
+			res, err := service.CreateCopy(testCase.input)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, testCase.expectedResult, res)
+			}
+		})
+	}
+}
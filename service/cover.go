@@ -0,0 +1,110 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+	"ninth-learn/model"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	coverMaxDimension = 8000
+	coverJPEGQuality  = 85
+)
+
+var (
+	ErrUnsupportedCover = errors.New("cover must be a JPEG, PNG or WebP image")
+	ErrCoverDimensions  = errors.New("cover dimensions are too large")
+	ErrInvalidCoverSize = errors.New("size must be one of original, small, medium, large")
+)
+
+// allowedCoverTypes are the sniffed content types accepted as covers
+var allowedCoverTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+type CoverService interface {
+	UploadBookCover(id int64, data []byte) (res model.Book, err error)
+	GetBookCover(id int64, size string) (res model.CoverImage, err error)
+}
+
+// UploadBookCover stores the original image along with JPEG thumbnails
+func (s *Service) UploadBookCover(id int64, data []byte) (res model.Book, err error) {
+	// trust the bytes, not the declared content type
+	contentType := http.DetectContentType(data)
+	if !allowedCoverTypes[contentType] {
+		return res, ErrUnsupportedCover
+	}
+
+	// check the header before decoding, so huge images are never allocated
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return res, ErrUnsupportedCover
+	}
+	if cfg.Width > coverMaxDimension || cfg.Height > coverMaxDimension {
+		return res, ErrCoverDimensions
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return res, ErrUnsupportedCover
+	}
+
+	images := []model.CoverImage{{Size: model.CoverSizeOriginal, ContentType: contentType, Data: data}}
+	for _, size := range model.CoverSizes[1:] {
+		thumb, err := thumbnail(src, model.CoverWidths[size])
+		if err != nil {
+			return res, err
+		}
+		images = append(images, model.CoverImage{Size: size, ContentType: "image/jpeg", Data: thumb})
+	}
+
+	sum := sha256.Sum256(data)
+	res, err = s.repo.SaveBookCover(id, hex.EncodeToString(sum[:8]), images)
+	if err != nil {
+		return res, err
+	}
+	s.purgeBooks(id)
+	return res, nil
+}
+
+func (s *Service) GetBookCover(id int64, size string) (res model.CoverImage, err error) {
+	if !model.ValidCoverSize(size) {
+		return res, ErrInvalidCoverSize
+	}
+	return s.repo.GetBookCover(id, size)
+}
+
+// thumbnail scales src down to width, keeping its aspect ratio, and encodes
+// it as JPEG on a white background
+func thumbnail(src image.Image, width int) ([]byte, error) {
+	b := src.Bounds()
+	if b.Dx() < width {
+		width = b.Dx()
+	}
+	height := b.Dy() * width / b.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: coverJPEGQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func testPNG(width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.RGBA{R: 200, A: 255})
+	}
+
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+func Test_CoverService_UploadBookCover(t *testing.T) {
+	type testCase struct {
+		name          string
+		wantError     bool
+		input         []byte
+		expectedError error
+		onCoverRepo   func(mock *mocks.MockCoverRepo)
+	}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "success",
+		wantError: false,
+		input:     testPNG(800, 1200),
+		onCoverRepo: func(mock *mocks.MockCoverRepo) {
+			mock.EXPECT().SaveBookCover(int64(1), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(id int64, etag string, images []model.CoverImage) (model.Book, error) {
+					assert.Len(t, etag, 16)
+					assert.Len(t, images, 4)
+					assert.Equal(t, model.CoverSizeOriginal, images[0].Size)
+					assert.Equal(t, "image/png", images[0].ContentType)
+
+					for _, img := range images[1:] {
+						assert.Equal(t, "image/jpeg", img.ContentType)
+						cfg, err := jpeg.DecodeConfig(bytes.NewReader(img.Data))
+						assert.Nil(t, err)
+						assert.Equal(t, model.CoverWidths[img.Size], cfg.Width)
+						assert.Equal(t, model.CoverWidths[img.Size]*3/2, cfg.Height)
+					}
+					return model.Book{ID: 1}, nil
+				}).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:      "small image not upscaled",
+		wantError: false,
+		input:     testPNG(200, 100),
+		onCoverRepo: func(mock *mocks.MockCoverRepo) {
+			mock.EXPECT().SaveBookCover(int64(1), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(id int64, etag string, images []model.CoverImage) (model.Book, error) {
+					cfg, err := jpeg.DecodeConfig(bytes.NewReader(images[3].Data))
+					assert.Nil(t, err)
+					assert.Equal(t, 200, cfg.Width)
+					return model.Book{ID: 1}, nil
+				}).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "not an image",
+		wantError:     true,
+		input:         []byte("%PDF-1.4 not a cover"),
+		expectedError: ErrUnsupportedCover,
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "truncated image",
+		wantError:     true,
+		input:         testPNG(10, 10)[:40],
+		expectedError: ErrUnsupportedCover,
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "too large",
+		wantError:     true,
+		input:         testPNG(coverMaxDimension+1, 1),
+		expectedError: ErrCoverDimensions,
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			coverRepo := mocks.NewMockCoverRepo(mockCtrl)
+
+			if testCase.onCoverRepo != nil {
+				testCase.onCoverRepo(coverRepo)
+			}
+
+			service := Service{
+				repo: mockRepo{MockCoverRepo: coverRepo},
+			}
+
+			_, err := service.UploadBookCover(1, testCase.input)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func Test_CoverService_GetBookCover(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	coverRepo := mocks.NewMockCoverRepo(mockCtrl)
+	coverRepo.EXPECT().GetBookCover(int64(1), model.CoverSizeSmall).Return(model.CoverImage{Size: model.CoverSizeSmall}, nil).Times(1)
+
+	service := Service{
+		repo: mockRepo{MockCoverRepo: coverRepo},
+	}
+
+	res, err := service.GetBookCover(1, model.CoverSizeSmall)
+	assert.Nil(t, err)
+	assert.Equal(t, model.CoverSizeSmall, res.Size)
+
+	_, err = service.GetBookCover(1, "huge")
+	assert.EqualError(t, err, ErrInvalidCoverSize.Error())
+}
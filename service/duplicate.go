@@ -0,0 +1,213 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"math"
+	"ninth-learn/model"
+	"sort"
+)
+
+type DuplicateService interface {
+	GetDuplicateBooks() ([]model.DuplicateCluster, error)
+	MergeBooks(sourceID, targetID int64) (res model.Book, err error)
+}
+
+// GetDuplicateBooks groups the catalogue into clusters of likely
+// duplicates, the most certain clusters first
+func (s *Service) GetDuplicateBooks() ([]model.DuplicateCluster, error) {
+	books, err := s.repo.GetBookSummaries()
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterDuplicates(books, findDuplicates(books)), nil
+}
+
+// MergeBooks folds the source book into the target, the source ID keeps
+// resolving to the target afterwards
+func (s *Service) MergeBooks(sourceID, targetID int64) (res model.Book, err error) {
+	if sourceID == targetID {
+		return res, model.ErrMergeSameBook
+	}
+
+	// the source is gone even when its cover was left behind
+	res, err = s.repo.MergeBooks(sourceID, targetID)
+	if errors.Is(err, model.ErrCoverLeft) {
+		log.Printf("merge book %d into %d: %v", sourceID, targetID, err)
+	} else if err != nil {
+		return res, err
+	}
+	s.similar.Remove(s.tenantID, sourceID)
+	s.indexBook(res)
+	s.bookChanged(sourceID, res.ID)
+	s.notifyBook(model.BookChangeDelete, res.TenantID, sourceID)
+	s.notifyBook(model.BookChangeUpdate, res.TenantID, res.ID)
+	return res, nil
+}
+
+// fingerprint is what books are compared on
+type fingerprint struct {
+	title  map[string]bool
+	author map[string]bool
+	isbns  []string
+}
+
+func fingerprintOf(b model.BookSummary) fingerprint {
+	f := fingerprint{
+		title:  model.Trigrams(model.NormalizeTitle(b.Title)),
+		author: model.Trigrams(model.NormalizeAuthorName(b.Author)),
+	}
+	// both forms are compared as ISBN-13
+	for _, isbn := range []string{b.ISBN10, b.ISBN13} {
+		if isbn13, err := model.CanonicalISBN(isbn); err == nil {
+			f.isbns = append(f.isbns, isbn13)
+		}
+	}
+	return f
+}
+
+// compare scores two books, ok is false when they are not likely duplicates
+func compare(a, b fingerprint) (match model.DuplicateMatch, ok bool) {
+	for _, x := range a.isbns {
+		for _, y := range b.isbns {
+			if x == y {
+				return model.DuplicateMatch{Score: 1, Reason: model.DuplicateReasonISBN}, true
+			}
+		}
+	}
+
+	title := model.TrigramSimilarity(a.title, b.title)
+	if title < model.DuplicateTitleSimilarity {
+		return match, false
+	}
+	author := model.TrigramSimilarity(a.author, b.author)
+	if author < model.DuplicateAuthorSimilarity {
+		return match, false
+	}
+
+	score := math.Round((title+author)/2*100) / 100
+	return model.DuplicateMatch{Score: score, Reason: model.DuplicateReasonSimilar}, true
+}
+
+// findDuplicates compares every pair of books sharing an ISBN or a title
+// trigram, books with nothing in common are never compared
+func findDuplicates(books []model.BookSummary) []model.DuplicateMatch {
+	prints := make([]fingerprint, len(books))
+	index := map[string][]int{}
+	for i, b := range books {
+		prints[i] = fingerprintOf(b)
+		for t := range prints[i].title {
+			index[t] = append(index[t], i)
+		}
+		for _, isbn := range prints[i].isbns {
+			index["isbn:"+isbn] = append(index["isbn:"+isbn], i)
+		}
+	}
+
+	var matches []model.DuplicateMatch
+	for i := range books {
+		seen := map[int]bool{}
+		for key := range prints[i].title {
+			for _, j := range index[key] {
+				seen[j] = true
+			}
+		}
+		for _, isbn := range prints[i].isbns {
+			for _, j := range index["isbn:"+isbn] {
+				seen[j] = true
+			}
+		}
+
+		for j := range seen {
+			if j <= i {
+				continue
+			}
+			if match, ok := compare(prints[i], prints[j]); ok {
+				match.BookID, match.OtherID = books[i].ID, books[j].ID
+				matches = append(matches, match)
+			}
+		}
+	}
+
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].BookID != matches[b].BookID {
+			return matches[a].BookID < matches[b].BookID
+		}
+		return matches[a].OtherID < matches[b].OtherID
+	})
+	return matches
+}
+
+// clusterDuplicates joins books linked by matches, directly or through
+// other books, into clusters
+func clusterDuplicates(books []model.BookSummary, matches []model.DuplicateMatch) []model.DuplicateCluster {
+	parent := map[int64]int64{}
+	var find func(id int64) int64
+	find = func(id int64) int64 {
+		if p, ok := parent[id]; ok && p != id {
+			parent[id] = find(p)
+			return parent[id]
+		}
+		return id
+	}
+	for _, m := range matches {
+		a, b := find(m.BookID), find(m.OtherID)
+		if a > b {
+			a, b = b, a
+		}
+		parent[a] = a
+		parent[b] = a
+	}
+
+	byRoot := map[int64]*model.DuplicateCluster{}
+	var roots []int64
+	for _, b := range books {
+		if _, ok := parent[b.ID]; !ok {
+			continue
+		}
+		root := find(b.ID)
+		if byRoot[root] == nil {
+			byRoot[root] = &model.DuplicateCluster{}
+			roots = append(roots, root)
+		}
+		byRoot[root].Books = append(byRoot[root].Books, b)
+	}
+	for _, m := range matches {
+		cluster := byRoot[find(m.BookID)]
+		cluster.Matches = append(cluster.Matches, m)
+		if m.Score > cluster.Score {
+			cluster.Score = m.Score
+		}
+	}
+
+	clusters := make([]model.DuplicateCluster, 0, len(roots))
+	for _, root := range roots {
+		clusters = append(clusters, *byRoot[root])
+	}
+	sort.SliceStable(clusters, func(a, b int) bool {
+		return clusters[a].Score > clusters[b].Score
+	})
+	return clusters
+}
+
+// matchBook lists the books likely duplicating the given one
+func matchBook(book model.BookSummary, others []model.BookSummary) []model.DuplicateMatch {
+	fp := fingerprintOf(book)
+
+	var matches []model.DuplicateMatch
+	for _, other := range others {
+		if other.ID == book.ID {
+			continue
+		}
+		if match, ok := compare(fp, fingerprintOf(other)); ok {
+			match.BookID, match.OtherID = book.ID, other.ID
+			matches = append(matches, match)
+		}
+	}
+
+	sort.SliceStable(matches, func(a, b int) bool {
+		return matches[a].Score > matches[b].Score
+	})
+	return matches
+}
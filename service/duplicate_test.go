@@ -0,0 +1,62 @@
+package service
+
+import (
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DuplicateService_GetDuplicateBooks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	duplicateRepo := mocks.NewMockDuplicateRepo(mockCtrl)
+	duplicateRepo.EXPECT().GetBookSummaries().Return([]model.BookSummary{
+		{ID: 1, Title: "The Great Gatsby", Author: "F. Scott Fitzgerald"},
+		{ID: 2, Title: "Great Gatsby", Author: "Fitzgerald, F. Scott"},
+		{ID: 3, Title: "The Great Gatsby: A Novel", Author: "Scott Fitzgerald"},
+		{ID: 4, Title: "Tender Is the Night", Author: "F. Scott Fitzgerald"},
+		{ID: 5, Title: "The Sun Also Rises", Author: "Ernest Hemingway", ISBN10: "0-7432-7356-7"},
+		{ID: 6, Title: "Fiesta", Author: "Ernest Hemingway", ISBN13: "978-0-7432-7356-5"},
+		{ID: 7, Title: "Moby Dick", Author: "Herman Melville"},
+	}, nil).Times(1)
+
+	service := Service{repo: mockRepo{MockDuplicateRepo: duplicateRepo}}
+
+	res, err := service.GetDuplicateBooks()
+	assert.Nil(t, err)
+	assert.Len(t, res, 2)
+
+	// spelling variants cluster together, through each other if need be
+	ids := []int64{}
+	for _, b := range res[0].Books {
+		ids = append(ids, b.ID)
+	}
+	assert.Equal(t, []int64{1, 2, 3}, ids)
+	assert.Equal(t, float64(1), res[0].Score)
+
+	// the same ISBN in either form is certain
+	assert.Equal(t, []model.DuplicateMatch{{BookID: 5, OtherID: 6, Score: 1, Reason: model.DuplicateReasonISBN}}, res[1].Matches)
+}
+
+func Test_DuplicateService_MergeBooks(t *testing.T) {
+	service := Service{}
+
+	_, err := service.MergeBooks(1, 1)
+	assert.EqualError(t, err, model.ErrMergeSameBook.Error())
+}
+
+func Test_DuplicateService_MergeBooks_CoverLeft(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	duplicateRepo := mocks.NewMockDuplicateRepo(mockCtrl)
+	merged := model.Book{ID: 2, Title: "Dune"}
+	duplicateRepo.EXPECT().MergeBooks(int64(1), int64(2)).Return(merged, model.ErrCoverLeft).Times(1)
+
+	service := Service{repo: mockRepo{MockDuplicateRepo: duplicateRepo}}
+
+	// the merge went through, only the source cover is an orphan
+	res, err := service.MergeBooks(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, merged, res)
+}
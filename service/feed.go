@@ -0,0 +1,239 @@
+package service
+
+import (
+	"encoding/json"
+	"ninth-learn/helper"
+	"ninth-learn/model"
+	"ninth-learn/outbox"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// feedBuffer is how far a watcher may fall behind before it is dropped
+	feedBuffer = 64
+	// feedHistory is how many of the latest events a watcher can resume from
+	feedHistory = 1024
+)
+
+type BookFeedService interface {
+	// WatchBooks streams the book changes of the tenant until stop is called.
+	// the channel is closed when the watcher falls too far behind.
+	WatchBooks() (events <-chan model.BookEvent, stop func())
+	// ResumeBooks is WatchBooks picking up after the event with the id, the
+	// events missed since come first. complete is false when they no longer
+	// reach back that far, or the id is of another process.
+	ResumeBooks(lastEventID string) (missed []model.BookEvent, events <-chan model.BookEvent, stop func(), complete bool)
+	// ReplicateBookChange brings this instance up to date with a book
+	// written by another one
+	ReplicateBookChange(change model.BookChange) error
+	// BookChangesLost is called when changes of other instances may have
+	// been missed, whatever was derived from the books is rebuilt
+	BookChangesLost()
+	// BroadcastBookEvent tells the watchers of this instance about a book
+	// event the outbox relay published
+	BroadcastBookEvent(m outbox.Message) error
+}
+
+// bookFeed fans book changes out to the watchers in this process and keeps
+// the latest of them for watchers coming back
+type bookFeed struct {
+	mu       sync.Mutex
+	watchers map[*bookWatcher]bool
+	// epoch tells the event ids of this process from those of earlier ones
+	epoch   string
+	seq     int64
+	history []feedEntry
+}
+
+type feedEntry struct {
+	seq   int64
+	event model.BookEvent
+}
+
+type bookWatcher struct {
+	// zero watches every tenant
+	tenantID int64
+	events   chan model.BookEvent
+}
+
+func newBookFeed() *bookFeed {
+	return &bookFeed{
+		watchers: map[*bookWatcher]bool{},
+		epoch:    newEpoch(),
+	}
+}
+
+func newEpoch() string {
+	return strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+func (f *bookFeed) watch(tenantID int64) *bookWatcher {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.add(tenantID)
+}
+
+// resume registers the watcher and collects what it missed in one go, so
+// no event falls in between or arrives twice
+func (f *bookFeed) resume(tenantID int64, lastEventID string) (missed []model.BookEvent, w *bookWatcher, complete bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w = f.add(tenantID)
+
+	if lastEventID == "" {
+		return nil, w, true
+	}
+	epoch, seq, ok := strings.Cut(lastEventID, "-")
+	last, err := strconv.ParseInt(seq, 10, 64)
+	if !ok || err != nil || epoch != f.epoch || last > f.seq {
+		return nil, w, false
+	}
+
+	complete = len(f.history) == 0 || f.history[0].seq <= last+1
+	for _, entry := range f.history {
+		if entry.seq > last && (tenantID == 0 || entry.event.TenantID == tenantID) {
+			missed = append(missed, entry.event)
+		}
+	}
+	return missed, w, complete
+}
+
+func (f *bookFeed) add(tenantID int64) *bookWatcher {
+	w := &bookWatcher{tenantID: tenantID, events: make(chan model.BookEvent, feedBuffer)}
+	f.watchers[w] = true
+	return w
+}
+
+func (f *bookFeed) stop(w *bookWatcher) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.drop(w)
+}
+
+func (f *bookFeed) drop(w *bookWatcher) {
+	if f.watchers[w] {
+		delete(f.watchers, w)
+		close(w.events)
+	}
+}
+
+// publish never blocks the writer, a watcher with a full buffer is dropped
+func (f *bookFeed) publish(e model.BookEvent) {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.seq++
+	e.ID = f.epoch + "-" + strconv.FormatInt(f.seq, 10)
+	f.remember(feedEntry{seq: f.seq, event: e})
+
+	for w := range f.watchers {
+		if w.tenantID != 0 && w.tenantID != e.TenantID {
+			continue
+		}
+		select {
+		case w.events <- e:
+		default:
+			f.drop(w)
+		}
+	}
+}
+
+// reset drops every watcher and starts a new epoch, the ids handed out so
+// far no longer resume and the clients coming back are told to reload
+func (f *bookFeed) reset() {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for epoch := f.epoch; f.epoch == epoch; {
+		f.epoch = newEpoch()
+	}
+	f.history = nil
+	for w := range f.watchers {
+		f.drop(w)
+	}
+}
+
+// remember keeps the latest feedHistory events, copying them down once the
+// history has grown to twice that
+func (f *bookFeed) remember(entry feedEntry) {
+	f.history = append(f.history, entry)
+	if len(f.history) >= 2*feedHistory {
+		f.history = append([]feedEntry(nil), f.history[len(f.history)-feedHistory:]...)
+	}
+}
+
+func (s *Service) WatchBooks() (<-chan model.BookEvent, func()) {
+	w := s.feed.watch(s.tenantID)
+	return w.events, func() { s.feed.stop(w) }
+}
+
+func (s *Service) ResumeBooks(lastEventID string) ([]model.BookEvent, <-chan model.BookEvent, func(), bool) {
+	missed, w, complete := s.feed.resume(s.tenantID, lastEventID)
+	return missed, w.events, func() { s.feed.stop(w) }, complete
+}
+
+// bookChanged purges the responses caches keep of a book written by this
+// service. the watchers and webhooks hear of it from the outbox, once the
+// relay published the event written along with the book
+func (s *Service) bookChanged(ids ...int64) {
+	s.purgeBooks(ids...)
+}
+
+func (s *Service) BroadcastBookEvent(m outbox.Message) error {
+	e, ok, err := bookEventOf(m)
+	if !ok {
+		return err
+	}
+	s.feed.publish(e)
+	return nil
+}
+
+// bookEventOf reads the book event of an outbox message, ok is false for
+// the events of other aggregates
+func bookEventOf(m outbox.Message) (e model.BookEvent, ok bool, err error) {
+	if !strings.HasPrefix(m.Key, model.AggregateBook+":") {
+		return e, false, nil
+	}
+	if err := json.Unmarshal(m.Payload, &e); err != nil {
+		return e, false, err
+	}
+	e.TenantID = m.TenantID
+	return e, true, nil
+}
+
+// ReplicateBookChange reads the book back rather than trusting the change,
+// the row may have moved on since. the watchers and webhooks hear of it
+// from the outbox
+func (s *Service) ReplicateBookChange(change model.BookChange) error {
+	if change.Op == model.BookChangeDelete {
+		s.similar.Remove(change.TenantID, change.BookID)
+		return nil
+	}
+
+	book, err := s.repo.WithTenant(change.TenantID).GetBookById(change.BookID)
+	if err != nil && err.Error() != helper.ErrNotFound {
+		return err
+	}
+	if err != nil {
+		s.similar.Remove(change.TenantID, change.BookID)
+		return nil
+	}
+	s.similar.Upsert(change.TenantID, bookDocument(book))
+	return nil
+}
+
+// BookChangesLost forgets the similarity index, and has the watchers
+// reconnect to a reset, what they were sent may have missed the changes
+func (s *Service) BookChangesLost() {
+	s.similar.Forget()
+	s.feed.reset()
+}
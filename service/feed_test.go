@@ -0,0 +1,220 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"ninth-learn/model"
+	"ninth-learn/outbox"
+	"ninth-learn/recommend"
+	"ninth-learn/repository/mocks"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// bookMessage is a book event the way the outbox relay publishes it
+func bookMessage(tenantID int64, eventType string, id int64, book *model.Book) outbox.Message {
+	payload, _ := json.Marshal(model.BookEvent{Type: eventType, BookID: id, Book: book})
+	return outbox.Message{Type: eventType, Key: model.AggregateBook + ":" + strconv.FormatInt(id, 10), TenantID: tenantID, Payload: payload}
+}
+
+func Test_BookFeed(t *testing.T) {
+	feed := newBookFeed()
+	root := &Service{feed: feed}
+	library := &Service{feed: feed, tenantID: 1}
+
+	everything, stopEverything := root.WatchBooks()
+	mine, stopMine := library.WatchBooks()
+	defer stopEverything()
+
+	assert.NoError(t, root.BroadcastBookEvent(bookMessage(1, model.BookCreated, 10, &model.Book{ID: 10})))
+	assert.NoError(t, root.BroadcastBookEvent(bookMessage(2, model.BookDeleted, 20, nil)))
+
+	e := <-mine
+	assert.Equal(t, model.BookEvent{ID: feed.epoch + "-1", Type: model.BookCreated, TenantID: 1, BookID: 10, Book: &model.Book{ID: 10}, OccurredAt: e.OccurredAt}, e)
+	assert.Len(t, mine, 0, "other tenants stay out")
+	assert.Len(t, everything, 2)
+
+	stopMine()
+	_, open := <-mine
+	assert.False(t, open)
+	// stopping twice is harmless
+	stopMine()
+
+	// events of other aggregates are not about books
+	assert.NoError(t, root.BroadcastBookEvent(outbox.Message{Key: "member:1", Payload: []byte(`{}`)}))
+	assert.Len(t, everything, 2)
+}
+
+func Test_BookFeed_SlowWatcher(t *testing.T) {
+	s := &Service{feed: newBookFeed()}
+	events, stop := s.WatchBooks()
+	defer stop()
+
+	// the writer never waits, the watcher that fell behind is dropped
+	for i := 0; i <= feedBuffer; i++ {
+		s.BroadcastBookEvent(bookMessage(0, model.BookUpdated, int64(i), nil))
+	}
+
+	received := 0
+	for range events {
+		received++
+	}
+	assert.Equal(t, feedBuffer, received)
+}
+
+func Test_BookFeed_Resume(t *testing.T) {
+	feed := newBookFeed()
+	library := &Service{feed: feed, tenantID: 1}
+
+	library.BroadcastBookEvent(bookMessage(1, model.BookCreated, 10, &model.Book{ID: 10}))
+	library.BroadcastBookEvent(bookMessage(2, model.BookCreated, 20, &model.Book{ID: 20}))
+	library.BroadcastBookEvent(bookMessage(1, model.BookUpdated, 10, &model.Book{ID: 10}))
+	library.BroadcastBookEvent(bookMessage(1, model.BookDeleted, 10, nil))
+
+	missed, events, stop, complete := library.ResumeBooks(feed.epoch + "-1")
+	assert.True(t, complete)
+	assert.Len(t, missed, 2, "other tenants stay out")
+	assert.Equal(t, feed.epoch+"-3", missed[0].ID)
+	assert.Equal(t, model.BookDeleted, missed[1].Type)
+
+	// nothing published before the resume comes again on the channel
+	library.BroadcastBookEvent(bookMessage(1, model.BookCreated, 11, nil))
+	e := <-events
+	assert.Equal(t, feed.epoch+"-5", e.ID)
+	stop()
+
+	// a fresh start has nothing to catch up on
+	missed, _, stop, complete = library.ResumeBooks("")
+	assert.True(t, complete)
+	assert.Empty(t, missed)
+	stop()
+
+	// ids of another process, or from the future, cannot be resumed
+	for _, id := range []string{"old-3", feed.epoch + "-99", "garbage"} {
+		missed, _, stop, complete = library.ResumeBooks(id)
+		assert.False(t, complete, id)
+		assert.Empty(t, missed, id)
+		stop()
+	}
+}
+
+func Test_BookFeed_ResumeBeyondHistory(t *testing.T) {
+	feed := newBookFeed()
+	s := &Service{feed: feed}
+
+	for i := 0; i < 2*feedHistory+10; i++ {
+		s.BroadcastBookEvent(bookMessage(0, model.BookUpdated, int64(i), nil))
+	}
+	assert.LessOrEqual(t, len(feed.history), 2*feedHistory)
+
+	// the events after 5 are long gone
+	missed, _, stop, complete := s.ResumeBooks(feed.epoch + "-5")
+	defer stop()
+	assert.False(t, complete)
+	assert.Equal(t, len(feed.history), len(missed))
+	assert.Equal(t, feed.epoch+"-"+strconv.Itoa(2*feedHistory+10), missed[len(missed)-1].ID)
+
+	// the oldest one kept is still complete
+	oldest := feed.history[0].seq
+	_, _, stopOldest, complete := s.ResumeBooks(feed.epoch + "-" + strconv.FormatInt(oldest-1, 10))
+	defer stopOldest()
+	assert.True(t, complete)
+}
+
+func Test_BookFeedService_ReplicateBookChange(t *testing.T) {
+	type testCase struct {
+		name      string
+		change    model.BookChange
+		book      model.Book
+		err       error
+		wantBook  bool
+		wantError bool
+	}
+
+	book := model.Book{ID: 3, Title: "Children of Dune", Description: "The desert planet after the emperor"}
+	testCases := []testCase{
+		{
+			name:     "insert",
+			change:   model.BookChange{Op: model.BookChangeInsert, BookID: 3, TenantID: 1},
+			book:     book,
+			wantBook: true,
+		},
+		{
+			name:     "update",
+			change:   model.BookChange{Op: model.BookChangeUpdate, BookID: 3, TenantID: 1},
+			book:     book,
+			wantBook: true,
+		},
+		{
+			name:   "delete",
+			change: model.BookChange{Op: model.BookChangeDelete, BookID: 3, TenantID: 1},
+		},
+		{
+			name:   "deleted since",
+			change: model.BookChange{Op: model.BookChangeUpdate, BookID: 3, TenantID: 1},
+			err:    gorm.ErrRecordNotFound,
+		},
+		{
+			name:      "database down",
+			change:    model.BookChange{Op: model.BookChangeUpdate, BookID: 3, TenantID: 1},
+			err:       errors.New("connection refused"),
+			wantError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			bookRepo := mocks.NewMockBookRepo(mockCtrl)
+			if tc.change.Op != model.BookChangeDelete {
+				bookRepo.EXPECT().GetBookById(tc.change.BookID).Return(tc.book, tc.err).Times(1)
+			}
+
+			index := recommend.NewIndex()
+			index.Load(1, []recommend.Document{
+				{ID: 1, Terms: recommend.Terms("Dune", "A desert planet and the spice")},
+				{ID: 3, Terms: recommend.Terms("Dune Messiah", "The desert planet under the emperor")},
+			}, index.Version(1))
+			root := &Service{repo: mockRepo{MockBookRepo: bookRepo}, similar: index, feed: newBookFeed()}
+			events, stop := root.WithTenant(1).WatchBooks()
+			defer stop()
+
+			err := root.ReplicateBookChange(tc.change)
+			// the watchers hear of it from the outbox
+			assert.Len(t, events, 0)
+			if tc.wantError {
+				assert.Error(t, err)
+				assert.True(t, index.Loaded(1))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantBook, len(index.Similar(1, 1, 10)) > 0, "index follows the change")
+		})
+	}
+}
+
+func Test_BookFeedService_BookChangesLost(t *testing.T) {
+	index := recommend.NewIndex()
+	index.Load(1, nil, index.Version(1))
+	s := &Service{similar: index, feed: newBookFeed(), tenantID: 1}
+
+	s.BroadcastBookEvent(bookMessage(1, model.BookCreated, 10, &model.Book{ID: 10}))
+	events, stop := s.WatchBooks()
+	defer stop()
+	lastEventID := s.feed.history[0].event.ID
+
+	s.BookChangesLost()
+	assert.False(t, index.Loaded(1))
+
+	// the watchers are dropped and come back to a reset
+	_, open := <-events
+	assert.False(t, open)
+	missed, _, stopResume, complete := s.ResumeBooks(lastEventID)
+	defer stopResume()
+	assert.False(t, complete)
+	assert.Empty(t, missed)
+}
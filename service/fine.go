@@ -0,0 +1,53 @@
+package service
+
+import (
+	"errors"
+	"ninth-learn/model"
+)
+
+var ErrFineLoanMismatch = errors.New("loan does not belong to this member")
+
+type FineService interface {
+	GetMemberFines(memberID int64) (model.FineSummary, error)
+	PayFine(memberID int64, in model.FineTransaction) (res model.FineTransaction, err error)
+	WaiveFine(memberID int64, in model.FineTransaction) (res model.FineTransaction, err error)
+}
+
+func (s *Service) GetMemberFines(memberID int64) (res model.FineSummary, err error) {
+	if _, err := s.repo.GetMemberById(memberID); err != nil {
+		return res, err
+	}
+	return s.repo.GetFineSummary(memberID)
+}
+
+// PayFine records a payment towards the member's outstanding fines
+func (s *Service) PayFine(memberID int64, in model.FineTransaction) (res model.FineTransaction, err error) {
+	in.Kind = model.FineKindPayment
+	return s.settleFine(memberID, in)
+}
+
+// WaiveFine writes off part of the member's outstanding fines
+func (s *Service) WaiveFine(memberID int64, in model.FineTransaction) (res model.FineTransaction, err error) {
+	in.Kind = model.FineKindWaiver
+	return s.settleFine(memberID, in)
+}
+
+// settleFine records a payment or waiver of at most the outstanding balance
+func (s *Service) settleFine(memberID int64, in model.FineTransaction) (res model.FineTransaction, err error) {
+	if _, err := s.repo.GetMemberById(memberID); err != nil {
+		return res, err
+	}
+
+	if in.LoanID != nil {
+		loan, err := s.repo.GetLoanById(*in.LoanID)
+		if err != nil {
+			return res, err
+		}
+		if loan.MemberID != memberID {
+			return res, ErrFineLoanMismatch
+		}
+	}
+
+	in.MemberID = memberID
+	return s.repo.CreateFineTransaction(in)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FineService_PayFine(t *testing.T) {
+	type testCase struct {
+		name          string
+		wantError     bool
+		input         model.FineTransaction
+		expectedError error
+		onLoanRepo    func(mock *mocks.MockLoanRepo)
+		onFineRepo    func(mock *mocks.MockFineRepo)
+	}
+
+	loanID := int64(7)
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "success",
+		wantError: false,
+		input:     model.FineTransaction{AmountCents: 200, LoanID: &loanID},
+		onLoanRepo: func(mock *mocks.MockLoanRepo) {
+			mock.EXPECT().GetLoanById(loanID).Return(model.Loan{ID: loanID, MemberID: 2}, nil).Times(1)
+		},
+		onFineRepo: func(mock *mocks.MockFineRepo) {
+			mock.EXPECT().CreateFineTransaction(model.FineTransaction{MemberID: 2, LoanID: &loanID, Kind: model.FineKindPayment, AmountCents: 200}).
+				Return(model.FineTransaction{ID: 1}, nil).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "exceeds balance",
+		wantError:     true,
+		input:         model.FineTransaction{AmountCents: 400},
+		expectedError: model.ErrFineExceedsBalance,
+		onFineRepo: func(mock *mocks.MockFineRepo) {
+			mock.EXPECT().CreateFineTransaction(model.FineTransaction{MemberID: 2, Kind: model.FineKindPayment, AmountCents: 400}).
+				Return(model.FineTransaction{}, model.ErrFineExceedsBalance).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "loan of another member",
+		wantError:     true,
+		input:         model.FineTransaction{AmountCents: 100, LoanID: &loanID},
+		expectedError: ErrFineLoanMismatch,
+		onLoanRepo: func(mock *mocks.MockLoanRepo) {
+			mock.EXPECT().GetLoanById(loanID).Return(model.Loan{ID: loanID, MemberID: 3}, nil).Times(1)
+		},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			memberRepo := mocks.NewMockMemberRepo(mockCtrl)
+			memberRepo.EXPECT().GetMemberById(int64(2)).Return(model.Member{ID: 2}, nil).Times(1)
+			loanRepo := mocks.NewMockLoanRepo(mockCtrl)
+			fineRepo := mocks.NewMockFineRepo(mockCtrl)
+
+			if testCase.onLoanRepo != nil {
+				testCase.onLoanRepo(loanRepo)
+			}
+			if testCase.onFineRepo != nil {
+				testCase.onFineRepo(fineRepo)
+			}
+
+			service := Service{
+				repo: mockRepo{MockMemberRepo: memberRepo, MockLoanRepo: loanRepo, MockFineRepo: fineRepo},
+			}
+
+			_, err := service.PayFine(2, testCase.input)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func Test_LoanPolicy_FineFor(t *testing.T) {
+	policy := model.LoanPolicy{FineDailyCents: 25, FineGraceDays: 2, FineCapCents: 1000}
+	due := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// two days of grace, then only full days count
+	assert.Equal(t, int64(0), policy.FineFor(due, due.AddDate(0, 0, 2)))
+	assert.Equal(t, int64(0), policy.FineFor(due, due.AddDate(0, 0, 3).Add(-time.Second)))
+	assert.Equal(t, int64(25), policy.FineFor(due, due.AddDate(0, 0, 3)))
+	assert.Equal(t, int64(1000), policy.FineFor(due, due.AddDate(0, 3, 0)))
+}
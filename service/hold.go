@@ -0,0 +1,65 @@
+package service
+
+import (
+	"ninth-learn/model"
+	"time"
+)
+
+type HoldService interface {
+	PlaceHold(bookID int64, in model.HoldRequest) (res model.Hold, err error)
+	CancelHold(id int64) (res model.Hold, err error)
+	GetHoldById(id int64) (model.Hold, error)
+	GetBookHolds(bookID int64, activeOnly bool) ([]model.Hold, error)
+	GetMemberHolds(memberID int64, activeOnly bool) ([]model.Hold, error)
+	GetHoldPosition(bookID, memberID int64) (int64, error)
+	ExpireHolds() (expired int64, err error)
+}
+
+// PlaceHold queues the member for a book whose copies are all out
+func (s *Service) PlaceHold(bookID int64, in model.HoldRequest) (res model.Hold, err error) {
+	if _, err := s.repo.ExpireHolds(bookID, s.pickupUntil()); err != nil {
+		return res, err
+	}
+	return s.repo.PlaceHold(bookID, in.MemberID)
+}
+
+func (s *Service) CancelHold(id int64) (res model.Hold, err error) {
+	return s.repo.CancelHold(id, s.pickupUntil())
+}
+
+func (s *Service) GetHoldById(id int64) (res model.Hold, err error) {
+	return s.repo.GetHoldById(id)
+}
+
+// GetBookHolds lists the queue of a book in the order it is served
+func (s *Service) GetBookHolds(bookID int64, activeOnly bool) ([]model.Hold, error) {
+	if _, err := s.repo.GetBookById(bookID); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.ExpireHolds(bookID, s.pickupUntil()); err != nil {
+		return nil, err
+	}
+	return s.repo.GetHolds(model.HoldFilter{BookID: bookID, ActiveOnly: activeOnly})
+}
+
+func (s *Service) GetMemberHolds(memberID int64, activeOnly bool) ([]model.Hold, error) {
+	if _, err := s.repo.GetMemberById(memberID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetHolds(model.HoldFilter{MemberID: memberID, ActiveOnly: activeOnly})
+}
+
+func (s *Service) GetHoldPosition(bookID, memberID int64) (int64, error) {
+	return s.repo.GetHoldPosition(bookID, memberID)
+}
+
+// ExpireHolds passes every copy left uncollected past its pickup window to
+// the next member in line
+func (s *Service) ExpireHolds() (expired int64, err error) {
+	return s.repo.ExpireHolds(0, s.pickupUntil())
+}
+
+// pickupUntil is the expiry of a copy set aside for a hold today
+func (s *Service) pickupUntil() time.Time {
+	return time.Now().AddDate(0, 0, s.policy.HoldPickupDays)
+}
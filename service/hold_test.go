@@ -0,0 +1,101 @@
+package service
+
+import (
+	"errors"
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HoldService_PlaceHold(t *testing.T) {
+	type testCase struct {
+		name           string
+		wantError      bool
+		input          model.HoldRequest
+		expectedResult model.Hold
+		expectedError  error
+		onHoldRepo     func(mock *mocks.MockHoldRepo)
+	}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "success",
+		wantError: false,
+		input:     model.HoldRequest{MemberID: 2},
+		onHoldRepo: func(mock *mocks.MockHoldRepo) {
+			gomock.InOrder(
+				mock.EXPECT().ExpireHolds(int64(1), gomock.Any()).
+					DoAndReturn(func(bookID int64, pickupUntil time.Time) (int64, error) {
+						assert.WithinDuration(t, time.Now().AddDate(0, 0, 3), pickupUntil, time.Minute)
+						return 0, nil
+					}),
+				mock.EXPECT().PlaceHold(int64(1), int64(2)).Return(model.Hold{
+					ID:       1,
+					BookID:   1,
+					MemberID: 2,
+					Status:   model.HoldStatusWaiting,
+					Position: 3,
+				}, nil),
+			)
+		},
+		expectedResult: model.Hold{
+			ID:       1,
+			BookID:   1,
+			MemberID: 2,
+			Status:   model.HoldStatusWaiting,
+			Position: 3,
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "copy on the shelf",
+		wantError:     true,
+		input:         model.HoldRequest{MemberID: 2},
+		expectedError: model.ErrCopyAvailableToLoan,
+		onHoldRepo: func(mock *mocks.MockHoldRepo) {
+			mock.EXPECT().ExpireHolds(int64(1), gomock.Any()).Return(int64(1), nil).Times(1)
+			mock.EXPECT().PlaceHold(int64(1), int64(2)).Return(model.Hold{}, model.ErrCopyAvailableToLoan).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "expiry fails",
+		wantError:     true,
+		input:         model.HoldRequest{MemberID: 2},
+		expectedError: errors.New("unexpected error"),
+		onHoldRepo: func(mock *mocks.MockHoldRepo) {
+			mock.EXPECT().ExpireHolds(int64(1), gomock.Any()).Return(int64(0), errors.New("unexpected error")).Times(1)
+		},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			holdRepo := mocks.NewMockHoldRepo(mockCtrl)
+
+			if testCase.onHoldRepo != nil {
+				testCase.onHoldRepo(holdRepo)
+			}
+
+			service := Service{
+				repo:   mockRepo{MockHoldRepo: holdRepo},
+				policy: model.DefaultLoanPolicy(),
+			}
+
+			res, err := service.PlaceHold(1, testCase.input)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, testCase.expectedResult, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"ninth-learn/model"
+	"time"
+)
+
+type LoanService interface {
+	CheckoutBook(bookID int64, in model.CheckoutRequest) (res model.Loan, err error)
+	ReturnLoan(id int64) (res model.Loan, err error)
+	RenewLoan(id int64) (res model.Loan, err error)
+	GetLoanById(id int64) (model.Loan, error)
+	GetMemberLoans(memberID int64, activeOnly bool) ([]model.Loan, error)
+	GetBookLoans(bookID int64, activeOnly bool) ([]model.Loan, error)
+	GetOverdueLoans() ([]model.Loan, error)
+	AccrueFines() (updated int64, err error)
+}
+
+// CheckoutBook lends a copy of the book, due after the policy's loan period.
+// Members owing more than the fine threshold cannot borrow.
+func (s *Service) CheckoutBook(bookID int64, in model.CheckoutRequest) (res model.Loan, err error) {
+	fines, err := s.repo.GetFineSummary(in.MemberID)
+	if err != nil {
+		return res, err
+	}
+	if fines.BalanceCents > s.policy.FineBlockThreshold {
+		return res, model.ErrFinesOutstanding
+	}
+
+	// copies left uncollected go to the next member in the queue first
+	if _, err := s.repo.ExpireHolds(bookID, s.pickupUntil()); err != nil {
+		return res, err
+	}
+
+	dueAt := time.Now().AddDate(0, 0, s.policy.LoanDays)
+	return s.repo.CheckoutCopy(bookID, in.CopyID, in.MemberID, dueAt, s.policy.MaxLoans)
+}
+
+// ReturnLoan closes the loan, the copy goes to the next hold on the book.
+// A late return settles the fine for the days since the last sweep.
+func (s *Service) ReturnLoan(id int64) (res model.Loan, err error) {
+	res, err = s.repo.ReturnLoan(id, s.pickupUntil())
+	if err != nil || res.ReturnedAt == nil || !res.ReturnedAt.After(res.DueAt) {
+		return res, err
+	}
+
+	fine := s.fineAt(res, *res.ReturnedAt)
+	if res.Overdue && fine == res.FineCents {
+		return res, nil
+	}
+
+	// a return is final, the fine of the loan cannot move on meanwhile
+	if _, err := s.repo.UpdateLoanFine(res, fine); err != nil {
+		return res, err
+	}
+	res.Overdue = true
+	res.FineCents = fine
+	return res, nil
+}
+
+// RenewLoan restarts the loan period from today, the fine charged up to now
+// stays with the loan
+func (s *Service) RenewLoan(id int64) (res model.Loan, err error) {
+	loan, err := s.repo.GetLoanById(id)
+	if err != nil {
+		return res, err
+	}
+
+	now := time.Now()
+	dueAt := now.AddDate(0, 0, s.policy.LoanDays)
+	return s.repo.RenewLoan(id, dueAt, s.policy.MaxRenewals, s.fineAt(loan, now))
+}
+
+func (s *Service) GetLoanById(id int64) (res model.Loan, err error) {
+	return s.repo.GetLoanById(id)
+}
+
+func (s *Service) GetMemberLoans(memberID int64, activeOnly bool) ([]model.Loan, error) {
+	if _, err := s.repo.GetMemberById(memberID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetLoans(model.LoanFilter{MemberID: memberID, ActiveOnly: activeOnly})
+}
+
+func (s *Service) GetBookLoans(bookID int64, activeOnly bool) ([]model.Loan, error) {
+	if _, err := s.repo.GetBookById(bookID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetLoans(model.LoanFilter{BookID: bookID, ActiveOnly: activeOnly})
+}
+
+// GetOverdueLoans lists the open loans past their due date
+func (s *Service) GetOverdueLoans() ([]model.Loan, error) {
+	now := time.Now()
+	return s.repo.GetLoans(model.LoanFilter{ActiveOnly: true, DueBefore: &now})
+}
+
+// AccrueFines marks open loans past their due date overdue and brings their
+// fines up to date
+func (s *Service) AccrueFines() (updated int64, err error) {
+	now := time.Now()
+	loans, err := s.repo.GetLoans(model.LoanFilter{ActiveOnly: true, DueBefore: &now})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, loan := range loans {
+		fine := s.fineAt(loan, now)
+		if loan.Overdue && fine == loan.FineCents {
+			continue
+		}
+
+		// the loan may have been renewed or returned since it was read
+		ok, err := s.repo.UpdateLoanFine(loan, fine)
+		if err != nil {
+			return updated, err
+		}
+		if ok {
+			updated++
+		}
+	}
+
+	return updated, nil
+}
+
+// fineAt is the fine of the loan at the given time, the fine carried over
+// from before the last renewal plus the lateness since. Fines never shrink.
+func (s *Service) fineAt(loan model.Loan, at time.Time) int64 {
+	fine := loan.CarriedFineCents + s.policy.FineFor(loan.DueAt, at)
+	if s.policy.FineCapCents > 0 && fine > s.policy.FineCapCents {
+		fine = s.policy.FineCapCents
+	}
+	if fine < loan.FineCents {
+		return loan.FineCents
+	}
+	return fine
+}
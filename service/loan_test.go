@@ -0,0 +1,250 @@
+package service
+
+import (
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LoanService_CheckoutBook(t *testing.T) {
+	type testCase struct {
+		name           string
+		wantError      bool
+		input          model.CheckoutRequest
+		expectedResult model.Loan
+		expectedError  error
+		balance        int64
+		onLoanRepo     func(mock *mocks.MockLoanRepo)
+	}
+
+	policy := model.LoanPolicy{LoanDays: 21, MaxLoans: 3, MaxRenewals: 1, FineBlockThreshold: 500}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "due date from policy",
+		wantError: false,
+		input:     model.CheckoutRequest{MemberID: 2},
+		onLoanRepo: func(mock *mocks.MockLoanRepo) {
+			mock.EXPECT().CheckoutCopy(int64(1), int64(0), int64(2), gomock.Any(), 3).
+				DoAndReturn(func(bookID, copyID, memberID int64, dueAt time.Time, maxLoans int) (model.Loan, error) {
+					assert.WithinDuration(t, time.Now().AddDate(0, 0, 21), dueAt, time.Minute)
+					return model.Loan{ID: 1, BookID: bookID, CopyID: 5, MemberID: memberID}, nil
+				}).Times(1)
+		},
+		expectedResult: model.Loan{ID: 1, BookID: 1, CopyID: 5, MemberID: 2},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "loan limit reached",
+		wantError:     true,
+		input:         model.CheckoutRequest{MemberID: 2, CopyID: 5},
+		expectedError: model.ErrLoanLimitReached,
+		onLoanRepo: func(mock *mocks.MockLoanRepo) {
+			mock.EXPECT().CheckoutCopy(int64(1), int64(5), int64(2), gomock.Any(), 3).Return(model.Loan{}, model.ErrLoanLimitReached).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "copy unavailable",
+		wantError:     true,
+		input:         model.CheckoutRequest{MemberID: 2, CopyID: 5},
+		expectedError: model.ErrCopyUnavailable,
+		onLoanRepo: func(mock *mocks.MockLoanRepo) {
+			mock.EXPECT().CheckoutCopy(int64(1), int64(5), int64(2), gomock.Any(), 3).Return(model.Loan{}, model.ErrCopyUnavailable).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "fines outstanding",
+		wantError:     true,
+		input:         model.CheckoutRequest{MemberID: 2},
+		balance:       501,
+		expectedError: model.ErrFinesOutstanding,
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			loanRepo := mocks.NewMockLoanRepo(mockCtrl)
+			holdRepo := mocks.NewMockHoldRepo(mockCtrl)
+			fineRepo := mocks.NewMockFineRepo(mockCtrl)
+			fineRepo.EXPECT().GetFineSummary(testCase.input.MemberID).Return(model.FineSummary{BalanceCents: testCase.balance}, nil).Times(1)
+
+			if testCase.onLoanRepo != nil {
+				holdRepo.EXPECT().ExpireHolds(int64(1), gomock.Any()).Return(int64(0), nil).Times(1)
+				testCase.onLoanRepo(loanRepo)
+			}
+
+			service := Service{
+				repo:   mockRepo{MockLoanRepo: loanRepo, MockHoldRepo: holdRepo, MockFineRepo: fineRepo},
+				policy: policy,
+			}
+
+			res, err := service.CheckoutBook(1, testCase.input)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, testCase.expectedResult, res)
+			}
+		})
+	}
+}
+
+func Test_LoanService_RenewLoan(t *testing.T) {
+	type testCase struct {
+		name          string
+		wantError     bool
+		expectedError error
+		onLoanRepo    func(mock *mocks.MockLoanRepo)
+	}
+
+	policy := model.LoanPolicy{LoanDays: 14, MaxLoans: 5, MaxRenewals: 2, FineDailyCents: 25, FineGraceDays: 2, FineCapCents: 1000}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "success",
+		wantError: false,
+		onLoanRepo: func(mock *mocks.MockLoanRepo) {
+			mock.EXPECT().GetLoanById(int64(1)).Return(model.Loan{ID: 1, DueAt: time.Now().AddDate(0, 0, 2)}, nil).Times(1)
+			mock.EXPECT().RenewLoan(int64(1), gomock.Any(), 2, int64(0)).
+				DoAndReturn(func(id int64, dueAt time.Time, maxRenewals int, fineCents int64) (model.Loan, error) {
+					assert.WithinDuration(t, time.Now().AddDate(0, 0, 14), dueAt, time.Minute)
+					return model.Loan{ID: id, Renewals: 1}, nil
+				}).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:      "late loan carries its fine up to the renewal",
+		wantError: false,
+		onLoanRepo: func(mock *mocks.MockLoanRepo) {
+			// charged 25 by the last sweep, 75 by now
+			mock.EXPECT().GetLoanById(int64(1)).Return(model.Loan{ID: 1, DueAt: time.Now().AddDate(0, 0, -5), FineCents: 25}, nil).Times(1)
+			mock.EXPECT().RenewLoan(int64(1), gomock.Any(), 2, int64(75)).Return(model.Loan{ID: 1, Renewals: 1}, nil).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "renewal limit reached",
+		wantError:     true,
+		expectedError: model.ErrRenewalLimitReached,
+		onLoanRepo: func(mock *mocks.MockLoanRepo) {
+			mock.EXPECT().GetLoanById(int64(1)).Return(model.Loan{ID: 1, DueAt: time.Now()}, nil).Times(1)
+			mock.EXPECT().RenewLoan(int64(1), gomock.Any(), 2, int64(0)).Return(model.Loan{}, model.ErrRenewalLimitReached).Times(1)
+		},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			loanRepo := mocks.NewMockLoanRepo(mockCtrl)
+
+			if testCase.onLoanRepo != nil {
+				testCase.onLoanRepo(loanRepo)
+			}
+
+			service := Service{
+				repo:   mockRepo{MockLoanRepo: loanRepo},
+				policy: policy,
+			}
+
+			_, err := service.RenewLoan(1)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func Test_LoanService_ReturnLoan(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	loanRepo := mocks.NewMockLoanRepo(mockCtrl)
+	loanRepo.EXPECT().ReturnLoan(int64(1), gomock.Any()).
+		DoAndReturn(func(id int64, pickupUntil time.Time) (model.Loan, error) {
+			assert.WithinDuration(t, time.Now().AddDate(0, 0, 3), pickupUntil, time.Minute)
+			return model.Loan{ID: id}, nil
+		}).Times(1)
+
+	service := Service{
+		repo:   mockRepo{MockLoanRepo: loanRepo},
+		policy: model.DefaultLoanPolicy(),
+	}
+
+	res, err := service.ReturnLoan(1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.ID)
+}
+
+func Test_LoanService_ReturnLoan_Late(t *testing.T) {
+	due := time.Now().AddDate(0, 0, -5)
+	returned := time.Now()
+
+	mockCtrl := gomock.NewController(t)
+	loanRepo := mocks.NewMockLoanRepo(mockCtrl)
+	loan := model.Loan{ID: 1, DueAt: due, ReturnedAt: &returned}
+	loanRepo.EXPECT().ReturnLoan(int64(1), gomock.Any()).Return(loan, nil).Times(1)
+	loanRepo.EXPECT().UpdateLoanFine(loan, int64(75)).Return(true, nil).Times(1)
+
+	service := Service{
+		repo:   mockRepo{MockLoanRepo: loanRepo},
+		policy: model.LoanPolicy{FineDailyCents: 25, FineGraceDays: 2, FineCapCents: 1000},
+	}
+
+	res, err := service.ReturnLoan(1)
+	assert.Nil(t, err)
+	assert.True(t, res.Overdue)
+	assert.Equal(t, int64(75), res.FineCents)
+}
+
+func Test_LoanService_AccrueFines(t *testing.T) {
+	now := time.Now()
+	loans := []model.Loan{
+		// within the grace period, marked overdue without a fine
+		{ID: 1, DueAt: now.AddDate(0, 0, -1)},
+		// already up to date
+		{ID: 2, DueAt: now.AddDate(0, 0, -4), Overdue: true, FineCents: 50},
+		// capped
+		{ID: 3, DueAt: now.AddDate(0, 0, -100), Overdue: true, FineCents: 500},
+		// fined before a renewal, late again since
+		{ID: 4, DueAt: now.AddDate(0, 0, -3), Overdue: true, FineCents: 300, CarriedFineCents: 300},
+		// fined more than the lateness since, never lowered
+		{ID: 5, DueAt: now.AddDate(0, 0, -3), Overdue: true, FineCents: 300},
+		// renewed since it was read, left alone
+		{ID: 6, DueAt: now.AddDate(0, 0, -4)},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	loanRepo := mocks.NewMockLoanRepo(mockCtrl)
+	loanRepo.EXPECT().GetLoans(gomock.Any()).
+		DoAndReturn(func(filter model.LoanFilter) ([]model.Loan, error) {
+			assert.True(t, filter.ActiveOnly)
+			assert.NotNil(t, filter.DueBefore)
+			return loans, nil
+		}).Times(1)
+	loanRepo.EXPECT().UpdateLoanFine(loans[0], int64(0)).Return(true, nil).Times(1)
+	loanRepo.EXPECT().UpdateLoanFine(loans[2], int64(1000)).Return(true, nil).Times(1)
+	loanRepo.EXPECT().UpdateLoanFine(loans[3], int64(325)).Return(true, nil).Times(1)
+	loanRepo.EXPECT().UpdateLoanFine(loans[5], int64(50)).Return(false, nil).Times(1)
+
+	service := Service{
+		repo:   mockRepo{MockLoanRepo: loanRepo},
+		policy: model.LoanPolicy{FineDailyCents: 25, FineGraceDays: 2, FineCapCents: 1000},
+	}
+
+	updated, err := service.AccrueFines()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), updated)
+}
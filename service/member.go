@@ -0,0 +1,58 @@
+package service
+
+import (
+	"errors"
+	"ninth-learn/model"
+)
+
+var (
+	ErrMemberHasLoans = errors.New("member still has books on loan")
+	ErrMemberHasHolds = errors.New("member still has books on hold")
+)
+
+type MemberService interface {
+	GetMembers() ([]model.Member, error)
+	CreateMember(in model.Member) (res model.Member, err error)
+	GetMemberById(id int64) (model.Member, error)
+	UpdateMember(in model.Member) (res model.Member, err error)
+	DeleteMember(id int64) (err error)
+}
+
+func (s Service) GetMembers() ([]model.Member, error) {
+	return s.repo.GetMembers()
+}
+
+func (s *Service) CreateMember(in model.Member) (res model.Member, err error) {
+	in.Normalize()
+	return s.repo.CreateMember(in)
+}
+
+func (s *Service) GetMemberById(id int64) (res model.Member, err error) {
+	return s.repo.GetMemberById(id)
+}
+
+func (s *Service) UpdateMember(in model.Member) (res model.Member, err error) {
+	in.Normalize()
+	return s.repo.UpdateMember(in)
+}
+
+// DeleteMember refuses to drop a member with open loans or holds
+func (s *Service) DeleteMember(id int64) (err error) {
+	count, err := s.repo.CountActiveLoans(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrMemberHasLoans
+	}
+
+	count, err = s.repo.CountActiveHolds(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrMemberHasHolds
+	}
+
+	return s.repo.DeleteMember(id)
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MemberService_CreateMember(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	memberRepo := mocks.NewMockMemberRepo(mockCtrl)
+	memberRepo.EXPECT().CreateMember(model.Member{
+		Name:   "Jane Doe",
+		Email:  "jane@example.com",
+		Status: model.MemberStatusActive,
+	}).Return(model.Member{ID: 1, Name: "Jane Doe", Email: "jane@example.com", Status: model.MemberStatusActive}, nil).Times(1)
+
+	service := Service{
+		repo: mockRepo{MockMemberRepo: memberRepo},
+	}
+
+	res, err := service.CreateMember(model.Member{Name: "Jane Doe", Email: " Jane@Example.com "})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.ID)
+}
+
+func Test_MemberService_DeleteMember(t *testing.T) {
+	type testCase struct {
+		name          string
+		wantError     bool
+		expectedError error
+		onLoanRepo    func(mock *mocks.MockLoanRepo)
+		onHoldRepo    func(mock *mocks.MockHoldRepo)
+		onMemberRepo  func(mock *mocks.MockMemberRepo)
+	}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "success",
+		wantError: false,
+		onLoanRepo: func(mock *mocks.MockLoanRepo) {
+			mock.EXPECT().CountActiveLoans(int64(1)).Return(int64(0), nil).Times(1)
+		},
+		onHoldRepo: func(mock *mocks.MockHoldRepo) {
+			mock.EXPECT().CountActiveHolds(int64(1)).Return(int64(0), nil).Times(1)
+		},
+		onMemberRepo: func(mock *mocks.MockMemberRepo) {
+			mock.EXPECT().DeleteMember(int64(1)).Return(nil).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "books on loan",
+		wantError:     true,
+		expectedError: ErrMemberHasLoans,
+		onLoanRepo: func(mock *mocks.MockLoanRepo) {
+			mock.EXPECT().CountActiveLoans(int64(1)).Return(int64(2), nil).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "books on hold",
+		wantError:     true,
+		expectedError: ErrMemberHasHolds,
+		onLoanRepo: func(mock *mocks.MockLoanRepo) {
+			mock.EXPECT().CountActiveLoans(int64(1)).Return(int64(0), nil).Times(1)
+		},
+		onHoldRepo: func(mock *mocks.MockHoldRepo) {
+			mock.EXPECT().CountActiveHolds(int64(1)).Return(int64(1), nil).Times(1)
+		},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			loanRepo := mocks.NewMockLoanRepo(mockCtrl)
+			holdRepo := mocks.NewMockHoldRepo(mockCtrl)
+			memberRepo := mocks.NewMockMemberRepo(mockCtrl)
+
+			if testCase.onLoanRepo != nil {
+				testCase.onLoanRepo(loanRepo)
+			}
+			if testCase.onHoldRepo != nil {
+				testCase.onHoldRepo(holdRepo)
+			}
+			if testCase.onMemberRepo != nil {
+				testCase.onMemberRepo(memberRepo)
+			}
+
+			service := Service{
+				repo: mockRepo{MockLoanRepo: loanRepo, MockHoldRepo: holdRepo, MockMemberRepo: memberRepo},
+			}
+
+			err := service.DeleteMember(1)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
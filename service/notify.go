@@ -0,0 +1,26 @@
+package service
+
+import "ninth-learn/model"
+
+// ChangeNotifier passes the books written by this service to the change
+// listeners, where the database does not notify them of its writes
+type ChangeNotifier interface {
+	Notify(change model.BookChange)
+}
+
+// WithChangeNotifier has book writes notify the change listeners
+func WithChangeNotifier(notifier ChangeNotifier) Option {
+	return func(s *Service) {
+		s.notifier = notifier
+	}
+}
+
+// notifyBook tells the change listeners about a write to the book, as the
+// books trigger would. rows only touched by writes to their authors,
+// categories or tags are not notified
+func (s *Service) notifyBook(op string, tenantID, id int64) {
+	if s.notifier == nil {
+		return
+	}
+	s.notifier.Notify(model.BookChange{Op: op, BookID: id, TenantID: tenantID})
+}
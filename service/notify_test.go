@@ -0,0 +1,104 @@
+package service
+
+import (
+	"errors"
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeNotifier struct {
+	changes []model.BookChange
+}
+
+func (f *fakeNotifier) Notify(change model.BookChange) {
+	f.changes = append(f.changes, change)
+}
+
+func Test_ChangeNotifier(t *testing.T) {
+	type testCase struct {
+		name        string
+		onRepo      func(*mocks.MockBookRepo, *mocks.MockDuplicateRepo)
+		call        func(ServiceInterface) error
+		wantChanges []model.BookChange
+	}
+
+	testCases := []testCase{
+		{
+			name: "update book",
+			onRepo: func(books *mocks.MockBookRepo, duplicates *mocks.MockDuplicateRepo) {
+				books.EXPECT().UpdateBook(gomock.Any()).Return(model.Book{ID: 10, TenantID: 1}, nil)
+			},
+			call: func(s ServiceInterface) error {
+				_, err := s.UpdateBook(model.Book{ID: 10})
+				return err
+			},
+			wantChanges: []model.BookChange{{Op: model.BookChangeUpdate, BookID: 10, TenantID: 1}},
+		},
+		{
+			name: "failed update",
+			onRepo: func(books *mocks.MockBookRepo, duplicates *mocks.MockDuplicateRepo) {
+				books.EXPECT().UpdateBook(gomock.Any()).Return(model.Book{}, errors.New("record not found"))
+			},
+			call: func(s ServiceInterface) error {
+				_, err := s.UpdateBook(model.Book{ID: 10})
+				assert.Error(t, err)
+				return nil
+			},
+		},
+		{
+			name: "delete book",
+			onRepo: func(books *mocks.MockBookRepo, duplicates *mocks.MockDuplicateRepo) {
+				books.EXPECT().DeleteBook(int64(10), true).Return(nil)
+			},
+			call: func(s ServiceInterface) error {
+				return s.DeleteBook(10, true)
+			},
+			wantChanges: []model.BookChange{{Op: model.BookChangeDelete, BookID: 10, TenantID: 1}},
+		},
+		{
+			name: "cover left behind",
+			onRepo: func(books *mocks.MockBookRepo, duplicates *mocks.MockDuplicateRepo) {
+				books.EXPECT().DeleteBook(int64(10), true).Return(model.ErrCoverLeft)
+			},
+			call: func(s ServiceInterface) error {
+				return s.DeleteBook(10, true)
+			},
+			wantChanges: []model.BookChange{{Op: model.BookChangeDelete, BookID: 10, TenantID: 1}},
+		},
+		{
+			name: "merge deletes the source",
+			onRepo: func(books *mocks.MockBookRepo, duplicates *mocks.MockDuplicateRepo) {
+				duplicates.EXPECT().MergeBooks(int64(11), int64(10)).Return(model.Book{ID: 10, TenantID: 1}, nil)
+			},
+			call: func(s ServiceInterface) error {
+				_, err := s.MergeBooks(11, 10)
+				return err
+			},
+			wantChanges: []model.BookChange{
+				{Op: model.BookChangeDelete, BookID: 11, TenantID: 1},
+				{Op: model.BookChangeUpdate, BookID: 10, TenantID: 1},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			books := mocks.NewMockBookRepo(mockCtrl)
+			duplicates := mocks.NewMockDuplicateRepo(mockCtrl)
+			tc.onRepo(books, duplicates)
+
+			notifier := &fakeNotifier{}
+			repo := mockRepo{MockBookRepo: books, MockDuplicateRepo: duplicates}
+			service := NewService(repo, model.LoanPolicy{}, WithChangeNotifier(notifier)).(*Service)
+			service.sender = nil
+
+			assert.NoError(t, tc.call(service.WithTenant(1)))
+			assert.Equal(t, tc.wantChanges, notifier.changes)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package service
+
+import "ninth-learn/model"
+
+// CachePurger drops the cached responses tagged with any of the surrogate
+// keys, without keeping the writer waiting
+type CachePurger interface {
+	Purge(keys ...string)
+}
+
+type Option func(*Service)
+
+// WithCachePurger has book changes purge the responses showing the books
+func WithCachePurger(purger CachePurger) Option {
+	return func(s *Service) {
+		s.purger = purger
+	}
+}
+
+// purgeBooks drops the cached responses showing the books, and the book
+// lists of the tenant they may appear in
+func (s *Service) purgeBooks(ids ...int64) {
+	if s.purger == nil {
+		return
+	}
+	keys := []string{model.BookListSurrogateKey(s.tenantID)}
+	for _, id := range ids {
+		keys = append(keys, model.BookSurrogateKey(id))
+	}
+	s.purger.Purge(keys...)
+}
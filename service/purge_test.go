@@ -0,0 +1,127 @@
+package service
+
+import (
+	"errors"
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePurger struct {
+	purged [][]string
+}
+
+func (f *fakePurger) Purge(keys ...string) {
+	f.purged = append(f.purged, keys)
+}
+
+func Test_CachePurger(t *testing.T) {
+	type testCase struct {
+		name       string
+		onRepo     func(*mocks.MockBookRepo, *mocks.MockReviewRepo, *mocks.MockRelationRepo)
+		call       func(ServiceInterface) error
+		wantPurged [][]string
+	}
+
+	testCases := []testCase{
+		{
+			name: "update book",
+			onRepo: func(books *mocks.MockBookRepo, reviews *mocks.MockReviewRepo, relations *mocks.MockRelationRepo) {
+				books.EXPECT().UpdateBook(gomock.Any()).Return(model.Book{ID: 10}, nil)
+			},
+			call: func(s ServiceInterface) error {
+				_, err := s.UpdateBook(model.Book{ID: 10})
+				return err
+			},
+			wantPurged: [][]string{{"books-1", "book-10"}},
+		},
+		{
+			name: "failed update",
+			onRepo: func(books *mocks.MockBookRepo, reviews *mocks.MockReviewRepo, relations *mocks.MockRelationRepo) {
+				books.EXPECT().UpdateBook(gomock.Any()).Return(model.Book{}, errors.New("record not found"))
+			},
+			call: func(s ServiceInterface) error {
+				_, err := s.UpdateBook(model.Book{ID: 10})
+				assert.Error(t, err)
+				return nil
+			},
+		},
+		{
+			name: "moderated review changes the rating",
+			onRepo: func(books *mocks.MockBookRepo, reviews *mocks.MockReviewRepo, relations *mocks.MockRelationRepo) {
+				reviews.EXPECT().SetReviewStatus(int64(10), int64(3), model.ReviewStatusRejected).Return(model.Review{}, nil)
+			},
+			call: func(s ServiceInterface) error {
+				_, err := s.ModerateReview(10, 3, model.ReviewStatusRejected)
+				return err
+			},
+			wantPurged: [][]string{{"books-1", "book-10"}},
+		},
+		{
+			name: "relation shows on both books",
+			onRepo: func(books *mocks.MockBookRepo, reviews *mocks.MockReviewRepo, relations *mocks.MockRelationRepo) {
+				relations.EXPECT().CreateBookRelation(gomock.Any()).DoAndReturn(func(in model.BookRelation) (model.BookRelation, error) {
+					in.ID = 1
+					return in, nil
+				})
+			},
+			call: func(s ServiceInterface) error {
+				_, err := s.CreateBookRelation(model.BookRelation{BookID: 10, RelatedID: 11, Type: model.RelationSequelOf})
+				return err
+			},
+			wantPurged: [][]string{{"books-1", "book-10", "book-11"}},
+		},
+		{
+			name: "removed relation leaves both books",
+			onRepo: func(books *mocks.MockBookRepo, reviews *mocks.MockReviewRepo, relations *mocks.MockRelationRepo) {
+				relations.EXPECT().DeleteBookRelation(int64(11), int64(1)).
+					Return(model.BookRelation{ID: 1, BookID: 10, RelatedID: 11, Type: model.RelationSequelOf}, nil)
+			},
+			call: func(s ServiceInterface) error {
+				return s.DeleteBookRelation(11, 1)
+			},
+			wantPurged: [][]string{{"books-1", "book-10", "book-11"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			books := mocks.NewMockBookRepo(mockCtrl)
+			reviews := mocks.NewMockReviewRepo(mockCtrl)
+			relations := mocks.NewMockRelationRepo(mockCtrl)
+			tc.onRepo(books, reviews, relations)
+
+			purger := &fakePurger{}
+			repo := mockRepo{MockBookRepo: books, MockReviewRepo: reviews, MockRelationRepo: relations}
+			service := NewService(repo, model.LoanPolicy{}, WithCachePurger(purger)).(*Service)
+			service.sender = nil
+
+			assert.NoError(t, tc.call(service.WithTenant(1)))
+			assert.Equal(t, tc.wantPurged, purger.purged)
+		})
+	}
+}
+
+func Test_CachePurger_Links(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	authors := mocks.NewMockAuthorRepo(mockCtrl)
+	tags := mocks.NewMockTagRepo(mockCtrl)
+	authors.EXPECT().UpdateAuthor(gomock.Any()).Return(model.Author{ID: 2, Name: "Frank Herbert"}, []int64{10, 11}, nil)
+	tags.EXPECT().DeleteTag(int64(5)).Return([]int64{11}, nil)
+	tags.EXPECT().DeleteTag(int64(6)).Return(nil, errors.New("record not found"))
+
+	purger := &fakePurger{}
+	repo := mockRepo{MockAuthorRepo: authors, MockTagRepo: tags}
+	service := NewService(repo, model.LoanPolicy{}, WithCachePurger(purger)).WithTenant(1)
+
+	// the books show the names of their authors and tags
+	_, err := service.UpdateAuthor(model.Author{ID: 2, Name: "Frank Herbert"})
+	assert.NoError(t, err)
+	assert.NoError(t, service.DeleteTag(5))
+	assert.Error(t, service.DeleteTag(6))
+	assert.Equal(t, [][]string{{"books-1", "book-10", "book-11"}, {"books-1", "book-11"}}, purger.purged)
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"ninth-learn/model"
+)
+
+type ReadingListService interface {
+	GetMyLists(owner model.Principal) ([]model.ReadingList, error)
+	GetMyList(owner model.Principal, id int64) (model.ReadingList, error)
+	CreateList(owner model.Principal, in model.ReadingList) (res model.ReadingList, err error)
+	UpdateList(owner model.Principal, in model.ReadingList) (res model.ReadingList, err error)
+	ShareList(owner model.Principal, id int64) (res model.ReadingList, err error)
+	DeleteList(owner model.Principal, id int64) (err error)
+	AddListBook(owner model.Principal, in model.ReadingListEntry) (res model.ReadingListEntry, err error)
+	MoveListBook(owner model.Principal, listID, bookID int64, position int) (res model.ReadingListEntry, err error)
+	RemoveListBook(owner model.Principal, listID, bookID int64) (err error)
+	GetSharedList(token string) (model.ReadingList, error)
+}
+
+// GetMyLists returns the caller's lists, creating the built-in ones on
+// first use
+func (s *Service) GetMyLists(owner model.Principal) ([]model.ReadingList, error) {
+	lists := make([]model.ReadingList, 0, len(model.DefaultLists))
+	for _, list := range model.DefaultLists {
+		token, err := newShareToken()
+		if err != nil {
+			return nil, err
+		}
+		list.Owner = owner.Subject
+		list.ShareToken = token
+		lists = append(lists, list)
+	}
+
+	if err := s.repo.CreateDefaultReadingLists(lists); err != nil {
+		return nil, err
+	}
+	return s.repo.GetReadingLists(owner.Subject)
+}
+
+func (s *Service) GetMyList(owner model.Principal, id int64) (model.ReadingList, error) {
+	return s.repo.GetReadingListById(owner.Subject, id)
+}
+
+// CreateList adds a custom shelf, private unless asked otherwise
+func (s *Service) CreateList(owner model.Principal, in model.ReadingList) (res model.ReadingList, err error) {
+	token, err := newShareToken()
+	if err != nil {
+		return res, err
+	}
+
+	in.ID = 0
+	in.Owner = owner.Subject
+	in.Kind = model.ListKindCustom
+	in.ShareToken = token
+	if in.Visibility == "" {
+		in.Visibility = model.ListVisibilityPrivate
+	}
+	return s.repo.CreateReadingList(in)
+}
+
+// UpdateList renames a list or changes its visibility, the share link is
+// kept
+func (s *Service) UpdateList(owner model.Principal, in model.ReadingList) (res model.ReadingList, err error) {
+	list, err := s.repo.GetReadingListById(owner.Subject, in.ID)
+	if err != nil {
+		return res, err
+	}
+
+	list.Name = in.Name
+	if in.Visibility != "" {
+		list.Visibility = in.Visibility
+	}
+	return s.repo.UpdateReadingList(list)
+}
+
+// ShareList issues a new share token, links handed out before stop working
+func (s *Service) ShareList(owner model.Principal, id int64) (res model.ReadingList, err error) {
+	list, err := s.repo.GetReadingListById(owner.Subject, id)
+	if err != nil {
+		return res, err
+	}
+
+	list.ShareToken, err = newShareToken()
+	if err != nil {
+		return res, err
+	}
+	return s.repo.UpdateReadingList(list)
+}
+
+func (s *Service) DeleteList(owner model.Principal, id int64) (err error) {
+	return s.repo.DeleteReadingList(owner.Subject, id)
+}
+
+func (s *Service) AddListBook(owner model.Principal, in model.ReadingListEntry) (res model.ReadingListEntry, err error) {
+	in.ID = 0
+	return s.repo.AddListEntry(owner.Subject, in)
+}
+
+func (s *Service) MoveListBook(owner model.Principal, listID, bookID int64, position int) (res model.ReadingListEntry, err error) {
+	return s.repo.MoveListEntry(owner.Subject, listID, bookID, position)
+}
+
+func (s *Service) RemoveListBook(owner model.Principal, listID, bookID int64) (err error) {
+	return s.repo.RemoveListEntry(owner.Subject, listID, bookID)
+}
+
+// GetSharedList opens a public list through its share link
+func (s *Service) GetSharedList(token string) (model.ReadingList, error) {
+	return s.repo.GetPublicReadingList(token)
+}
+
+// newShareToken returns an unguessable token for share links
+func newShareToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
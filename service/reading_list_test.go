@@ -0,0 +1,73 @@
+package service
+
+import (
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReadingListService_GetMyLists(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	listRepo := mocks.NewMockReadingListRepo(mockCtrl)
+	listRepo.EXPECT().CreateDefaultReadingLists(gomock.Any()).
+		DoAndReturn(func(lists []model.ReadingList) error {
+			assert.Len(t, lists, 2)
+			assert.Equal(t, model.ListKindWantToRead, lists[0].Kind)
+			assert.Equal(t, model.ListKindCurrentlyReading, lists[1].Kind)
+			for _, list := range lists {
+				assert.Equal(t, "reader-1", list.Owner)
+				assert.Equal(t, model.ListVisibilityPrivate, list.Visibility)
+				assert.Len(t, list.ShareToken, 32)
+			}
+			assert.NotEqual(t, lists[0].ShareToken, lists[1].ShareToken)
+			return nil
+		}).Times(1)
+	listRepo.EXPECT().GetReadingLists("reader-1").Return([]model.ReadingList{{ID: 1}, {ID: 2}}, nil).Times(1)
+
+	service := Service{repo: mockRepo{MockReadingListRepo: listRepo}}
+
+	res, err := service.GetMyLists(model.Principal{Subject: "reader-1"})
+	assert.Nil(t, err)
+	assert.Len(t, res, 2)
+}
+
+func Test_ReadingListService_CreateList(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	listRepo := mocks.NewMockReadingListRepo(mockCtrl)
+	listRepo.EXPECT().CreateReadingList(gomock.Any()).
+		DoAndReturn(func(in model.ReadingList) (model.ReadingList, error) {
+			assert.Equal(t, int64(0), in.ID)
+			assert.Equal(t, "reader-1", in.Owner)
+			assert.Equal(t, model.ListKindCustom, in.Kind)
+			assert.Equal(t, model.ListVisibilityPrivate, in.Visibility)
+			assert.NotEmpty(t, in.ShareToken)
+			in.ID = 3
+			return in, nil
+		}).Times(1)
+
+	service := Service{repo: mockRepo{MockReadingListRepo: listRepo}}
+
+	// kind and owner cannot be chosen by the caller
+	res, err := service.CreateList(model.Principal{Subject: "reader-1"}, model.ReadingList{ID: 9, Name: "Holiday", Kind: model.ListKindWantToRead, Owner: "reader-2"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), res.ID)
+}
+
+func Test_ReadingListService_ShareList(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	listRepo := mocks.NewMockReadingListRepo(mockCtrl)
+	listRepo.EXPECT().GetReadingListById("reader-1", int64(3)).Return(model.ReadingList{ID: 3, Owner: "reader-1", ShareToken: "old"}, nil).Times(1)
+	listRepo.EXPECT().UpdateReadingList(gomock.Any()).
+		DoAndReturn(func(in model.ReadingList) (model.ReadingList, error) {
+			assert.NotEqual(t, "old", in.ShareToken)
+			return in, nil
+		}).Times(1)
+
+	service := Service{repo: mockRepo{MockReadingListRepo: listRepo}}
+
+	_, err := service.ShareList(model.Principal{Subject: "reader-1"}, 3)
+	assert.Nil(t, err)
+}
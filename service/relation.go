@@ -0,0 +1,120 @@
+package service
+
+import (
+	"ninth-learn/model"
+)
+
+type RelationService interface {
+	GetBookRelations(bookID int64) ([]model.RelatedWork, error)
+	CreateBookRelation(in model.BookRelation) (res model.RelatedWork, err error)
+	DeleteBookRelation(bookID, id int64) (err error)
+	GetRelatedWorksOfBooks(bookIDs []int64) (map[int64][]model.RelatedWork, error)
+	GetBookDetail(id int64, acceptLanguage string) (res model.Book, err error)
+}
+
+// GetBookRelations lists the works related to the book, relations to the
+// book are named from its side, such as has_sequel
+func (s *Service) GetBookRelations(bookID int64) ([]model.RelatedWork, error) {
+	if _, err := s.repo.GetBookById(bookID); err != nil {
+		return nil, err
+	}
+
+	relations, err := s.repo.GetBookRelations(bookID)
+	if err != nil {
+		return nil, err
+	}
+	return relatedWorks(bookID, relations), nil
+}
+
+// CreateBookRelation refuses relations that would close a loop, a book can
+// not end up the sequel of its own sequel
+func (s *Service) CreateBookRelation(in model.BookRelation) (res model.RelatedWork, err error) {
+	if in.BookID == in.RelatedID {
+		return res, model.ErrRelationCycle
+	}
+
+	in.ID = 0
+	relation, err := s.repo.CreateBookRelation(in)
+	if err != nil {
+		return res, err
+	}
+	s.purgeBooks(in.BookID, in.RelatedID)
+	return relatedWorks(in.BookID, []model.BookRelation{relation})[0], nil
+}
+
+// GetRelatedWorksOfBooks is GetBookRelations for many books at once, keyed
+// by book
+func (s *Service) GetRelatedWorksOfBooks(bookIDs []int64) (map[int64][]model.RelatedWork, error) {
+	relations, err := s.repo.GetRelationsOfBooks(bookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[int64]bool, len(bookIDs))
+	for _, id := range bookIDs {
+		wanted[id] = true
+	}
+	works := make(map[int64][]model.RelatedWork, len(bookIDs))
+	for _, r := range relations {
+		for _, id := range []int64{r.BookID, r.RelatedID} {
+			if wanted[id] {
+				works[id] = append(works[id], relatedWork(id, r))
+			}
+		}
+	}
+	return works, nil
+}
+
+func (s *Service) DeleteBookRelation(bookID, id int64) (err error) {
+	relation, err := s.repo.DeleteBookRelation(bookID, id)
+	if err != nil {
+		return err
+	}
+	s.purgeBooks(relation.BookID, relation.RelatedID)
+	return nil
+}
+
+// GetBookDetail is the book as GET /books/:id shows it, translated for the
+// reader, with its place in its series and its related works
+func (s *Service) GetBookDetail(id int64, acceptLanguage string) (res model.Book, err error) {
+	res, err = s.GetLocalizedBook(id, acceptLanguage)
+	if err != nil {
+		return res, err
+	}
+
+	res.Series, err = s.repo.GetBookSeries(res.ID)
+	if err != nil {
+		return res, err
+	}
+
+	relations, err := s.repo.GetBookRelations(res.ID)
+	if err != nil {
+		return res, err
+	}
+	res.RelatedWorks = relatedWorks(res.ID, relations)
+	return res, nil
+}
+
+// relatedWorks names each relation from the book's side and links the book
+// on the other end
+func relatedWorks(bookID int64, relations []model.BookRelation) []model.RelatedWork {
+	works := make([]model.RelatedWork, 0, len(relations))
+	for _, r := range relations {
+		works = append(works, relatedWork(bookID, r))
+	}
+	return works
+}
+
+func relatedWork(bookID int64, r model.BookRelation) model.RelatedWork {
+	work := model.RelatedWork{RelationID: r.ID, Type: r.Type, Book: model.NewBookLink(r.RelatedID, "")}
+	other := r.Related
+	if r.BookID != bookID {
+		work.Type = model.InverseRelation(r.Type)
+		work.Book = model.NewBookLink(r.BookID, "")
+		other = r.Book
+	}
+	if other != nil {
+		work.Book.Title = other.Title
+	}
+	return work
+}
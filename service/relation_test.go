@@ -0,0 +1,111 @@
+package service
+
+import (
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RelationService_CreateBookRelation(t *testing.T) {
+	type testCase struct {
+		name           string
+		wantError      bool
+		input          model.BookRelation
+		expectedError  error
+		onRelationRepo func(mock *mocks.MockRelationRepo)
+	}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "success",
+		wantError: false,
+		input:     model.BookRelation{BookID: 2, RelatedID: 1, Type: model.RelationSequelOf},
+		onRelationRepo: func(mock *mocks.MockRelationRepo) {
+			mock.EXPECT().CreateBookRelation(model.BookRelation{BookID: 2, RelatedID: 1, Type: model.RelationSequelOf}).
+				Return(model.BookRelation{ID: 7, BookID: 2, RelatedID: 1, Type: model.RelationSequelOf, Related: &model.Book{ID: 1, Title: "Dune"}}, nil).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "related to itself",
+		wantError:     true,
+		input:         model.BookRelation{BookID: 1, RelatedID: 1, Type: model.RelationSequelOf},
+		expectedError: model.ErrRelationCycle,
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "sequel of its own sequel",
+		wantError:     true,
+		input:         model.BookRelation{BookID: 1, RelatedID: 3, Type: model.RelationSequelOf},
+		expectedError: model.ErrRelationCycle,
+		onRelationRepo: func(mock *mocks.MockRelationRepo) {
+			// 3 is a sequel of 2, which is a sequel of 1
+			mock.EXPECT().CreateBookRelation(model.BookRelation{BookID: 1, RelatedID: 3, Type: model.RelationSequelOf}).
+				Return(model.BookRelation{}, model.ErrRelationCycle).Times(1)
+		},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			relationRepo := mocks.NewMockRelationRepo(mockCtrl)
+
+			if testCase.onRelationRepo != nil {
+				testCase.onRelationRepo(relationRepo)
+			}
+
+			service := Service{repo: mockRepo{MockRelationRepo: relationRepo}}
+
+			res, err := service.CreateBookRelation(testCase.input)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, model.RelatedWork{
+					RelationID: 7,
+					Type:       model.RelationSequelOf,
+					Book:       &model.BookLink{ID: 1, Title: "Dune", Href: "/books/1"},
+				}, res)
+			}
+		})
+	}
+}
+
+func Test_RelationService_GetBookDetail(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	bookRepo := mocks.NewMockBookRepo(mockCtrl)
+	bookRepo.EXPECT().GetBookById(int64(2)).Return(model.Book{ID: 2, Title: "Dune Messiah", Language: "en"}, nil).Times(1)
+
+	series := []model.BookSeries{{
+		SeriesID: 1,
+		Name:     "Dune Chronicles",
+		Position: 2,
+		Total:    3,
+		Previous: model.NewBookLink(1, "Dune"),
+		Next:     model.NewBookLink(3, "Children of Dune"),
+	}}
+	seriesRepo := mocks.NewMockSeriesRepo(mockCtrl)
+	seriesRepo.EXPECT().GetBookSeries(int64(2)).Return(series, nil).Times(1)
+
+	relationRepo := mocks.NewMockRelationRepo(mockCtrl)
+	relationRepo.EXPECT().GetBookRelations(int64(2)).Return([]model.BookRelation{
+		{ID: 1, BookID: 2, RelatedID: 1, Type: model.RelationSequelOf, Book: &model.Book{ID: 2, Title: "Dune Messiah"}, Related: &model.Book{ID: 1, Title: "Dune"}},
+		{ID: 2, BookID: 3, RelatedID: 2, Type: model.RelationSequelOf, Book: &model.Book{ID: 3, Title: "Children of Dune"}, Related: &model.Book{ID: 2, Title: "Dune Messiah"}},
+	}, nil).Times(1)
+
+	service := Service{repo: mockRepo{MockBookRepo: bookRepo, MockSeriesRepo: seriesRepo, MockRelationRepo: relationRepo}}
+
+	res, err := service.GetBookDetail(2, "")
+	assert.Nil(t, err)
+	assert.Equal(t, series, res.Series)
+	assert.Equal(t, []model.RelatedWork{
+		{RelationID: 1, Type: model.RelationSequelOf, Book: &model.BookLink{ID: 1, Title: "Dune", Href: "/books/1"}},
+		{RelationID: 2, Type: "has_sequel", Book: &model.BookLink{ID: 3, Title: "Children of Dune", Href: "/books/3"}},
+	}, res.RelatedWorks)
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"errors"
+	"ninth-learn/model"
+)
+
+var ErrNotReviewAuthor = errors.New("only the author of a review may change it")
+
+type ReviewService interface {
+	GetBookReviews(bookID int64, status string) ([]model.Review, error)
+	GetReviewById(bookID, id int64) (model.Review, error)
+	CreateReview(reviewer model.Principal, in model.Review) (res model.Review, err error)
+	UpdateReview(reviewer model.Principal, in model.Review) (res model.Review, err error)
+	DeleteReview(reviewer model.Principal, bookID, id int64) (err error)
+	ModerateReview(bookID, id int64, status string) (res model.Review, err error)
+}
+
+// GetBookReviews lists the reviews of a book, newest first, an empty
+// status lists every review
+func (s *Service) GetBookReviews(bookID int64, status string) ([]model.Review, error) {
+	if _, err := s.repo.GetBookById(bookID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetReviews(model.ReviewFilter{BookID: bookID, Status: status})
+}
+
+func (s *Service) GetReviewById(bookID, id int64) (model.Review, error) {
+	return s.repo.GetReviewById(bookID, id)
+}
+
+// CreateReview records the caller's review of a book, a second review of
+// the same book by the same caller is a duplicate
+func (s *Service) CreateReview(reviewer model.Principal, in model.Review) (res model.Review, err error) {
+	in.ID = 0
+	in.Reviewer = reviewer.Subject
+	in.Status = model.ReviewStatusApproved
+	res, err = s.repo.CreateReview(in)
+	if err != nil {
+		return res, err
+	}
+	s.purgeBooks(in.BookID)
+	return res, nil
+}
+
+// UpdateReview lets the author change the rating and text of their review,
+// the moderation status is kept
+func (s *Service) UpdateReview(reviewer model.Principal, in model.Review) (res model.Review, err error) {
+	review, err := s.repo.GetReviewById(in.BookID, in.ID)
+	if err != nil {
+		return res, err
+	}
+	if review.Reviewer != reviewer.Subject {
+		return res, ErrNotReviewAuthor
+	}
+
+	res, err = s.repo.UpdateReview(in)
+	if err != nil {
+		return res, err
+	}
+	s.purgeBooks(in.BookID)
+	return res, nil
+}
+
+// DeleteReview removes a review, by its author or an admin
+func (s *Service) DeleteReview(reviewer model.Principal, bookID, id int64) (err error) {
+	review, err := s.repo.GetReviewById(bookID, id)
+	if err != nil {
+		return err
+	}
+	if review.Reviewer != reviewer.Subject && !reviewer.IsAdmin() {
+		return ErrNotReviewAuthor
+	}
+
+	if err := s.repo.DeleteReview(bookID, id); err != nil {
+		return err
+	}
+	s.purgeBooks(bookID)
+	return nil
+}
+
+// ModerateReview approves or rejects a review, rejected reviews no longer
+// count towards the rating of the book
+func (s *Service) ModerateReview(bookID, id int64, status string) (res model.Review, err error) {
+	res, err = s.repo.SetReviewStatus(bookID, id, status)
+	if err != nil {
+		return res, err
+	}
+	s.purgeBooks(bookID)
+	return res, nil
+}
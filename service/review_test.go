@@ -0,0 +1,156 @@
+package service
+
+import (
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReviewService_CreateReview(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	reviewRepo := mocks.NewMockReviewRepo(mockCtrl)
+	reviewRepo.EXPECT().CreateReview(model.Review{BookID: 1, Reviewer: "reader-1", Rating: 4, Status: model.ReviewStatusApproved}).
+		Return(model.Review{ID: 1, BookID: 1, Reviewer: "reader-1", Rating: 4, Status: model.ReviewStatusApproved}, nil).Times(1)
+
+	service := Service{repo: mockRepo{MockReviewRepo: reviewRepo}}
+
+	// the reviewer and status come from the caller, not the request
+	res, err := service.CreateReview(model.Principal{Subject: "reader-1"}, model.Review{BookID: 1, Reviewer: "someone-else", Rating: 4, Status: model.ReviewStatusRejected})
+	assert.Nil(t, err)
+	assert.Equal(t, "reader-1", res.Reviewer)
+}
+
+func Test_ReviewService_UpdateReview(t *testing.T) {
+	type testCase struct {
+		name          string
+		wantError     bool
+		reviewer      model.Principal
+		expectedError error
+		onReviewRepo  func(mock *mocks.MockReviewRepo)
+	}
+
+	in := model.Review{ID: 2, BookID: 1, Rating: 5, Text: "Better the second time"}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "author",
+		wantError: false,
+		reviewer:  model.Principal{Subject: "reader-1"},
+		onReviewRepo: func(mock *mocks.MockReviewRepo) {
+			mock.EXPECT().GetReviewById(int64(1), int64(2)).Return(model.Review{ID: 2, BookID: 1, Reviewer: "reader-1", Rating: 3}, nil).Times(1)
+			mock.EXPECT().UpdateReview(in).Return(in, nil).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "someone else",
+		wantError:     true,
+		reviewer:      model.Principal{Subject: "reader-2"},
+		expectedError: ErrNotReviewAuthor,
+		onReviewRepo: func(mock *mocks.MockReviewRepo) {
+			mock.EXPECT().GetReviewById(int64(1), int64(2)).Return(model.Review{ID: 2, BookID: 1, Reviewer: "reader-1", Rating: 3}, nil).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "admins do not edit reviews",
+		wantError:     true,
+		reviewer:      model.Principal{Subject: "librarian", Role: model.RoleAdmin},
+		expectedError: ErrNotReviewAuthor,
+		onReviewRepo: func(mock *mocks.MockReviewRepo) {
+			mock.EXPECT().GetReviewById(int64(1), int64(2)).Return(model.Review{ID: 2, BookID: 1, Reviewer: "reader-1", Rating: 3}, nil).Times(1)
+		},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			reviewRepo := mocks.NewMockReviewRepo(mockCtrl)
+
+			if testCase.onReviewRepo != nil {
+				testCase.onReviewRepo(reviewRepo)
+			}
+
+			service := Service{repo: mockRepo{MockReviewRepo: reviewRepo}}
+
+			_, err := service.UpdateReview(testCase.reviewer, in)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func Test_ReviewService_DeleteReview(t *testing.T) {
+	type testCase struct {
+		name          string
+		wantError     bool
+		reviewer      model.Principal
+		expectedError error
+		onReviewRepo  func(mock *mocks.MockReviewRepo)
+	}
+
+	review := model.Review{ID: 2, BookID: 1, Reviewer: "reader-1", Rating: 3}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "author",
+		wantError: false,
+		reviewer:  model.Principal{Subject: "reader-1"},
+		onReviewRepo: func(mock *mocks.MockReviewRepo) {
+			mock.EXPECT().GetReviewById(int64(1), int64(2)).Return(review, nil).Times(1)
+			mock.EXPECT().DeleteReview(int64(1), int64(2)).Return(nil).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:      "admin",
+		wantError: false,
+		reviewer:  model.Principal{Subject: "librarian", Role: model.RoleAdmin},
+		onReviewRepo: func(mock *mocks.MockReviewRepo) {
+			mock.EXPECT().GetReviewById(int64(1), int64(2)).Return(review, nil).Times(1)
+			mock.EXPECT().DeleteReview(int64(1), int64(2)).Return(nil).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "someone else",
+		wantError:     true,
+		reviewer:      model.Principal{Subject: "reader-2"},
+		expectedError: ErrNotReviewAuthor,
+		onReviewRepo: func(mock *mocks.MockReviewRepo) {
+			mock.EXPECT().GetReviewById(int64(1), int64(2)).Return(review, nil).Times(1)
+		},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			reviewRepo := mocks.NewMockReviewRepo(mockCtrl)
+
+			if testCase.onReviewRepo != nil {
+				testCase.onReviewRepo(reviewRepo)
+			}
+
+			service := Service{repo: mockRepo{MockReviewRepo: reviewRepo}}
+
+			err := service.DeleteReview(testCase.reviewer, 1, 2)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"ninth-learn/model"
+)
+
+type SeriesService interface {
+	GetSeries() ([]model.Series, error)
+	GetSeriesById(id int64) (model.Series, error)
+	CreateSeries(in model.Series) (res model.Series, err error)
+	UpdateSeries(in model.Series) (res model.Series, err error)
+	DeleteSeries(id int64) (err error)
+	AddSeriesBook(in model.SeriesEntry) (res model.SeriesEntry, err error)
+	MoveSeriesBook(seriesID, bookID int64, position int) (res model.SeriesEntry, err error)
+	RemoveSeriesBook(seriesID, bookID int64) (err error)
+	GetSeriesOfBooks(bookIDs []int64) (map[int64][]model.BookSeries, error)
+}
+
+func (s *Service) GetSeries() ([]model.Series, error) {
+	return s.repo.GetSeries()
+}
+
+func (s *Service) GetSeriesById(id int64) (model.Series, error) {
+	return s.repo.GetSeriesById(id)
+}
+
+func (s *Service) CreateSeries(in model.Series) (res model.Series, err error) {
+	in.ID = 0
+	return s.repo.CreateSeries(in)
+}
+
+func (s *Service) UpdateSeries(in model.Series) (res model.Series, err error) {
+	return s.repo.UpdateSeries(in)
+}
+
+// DeleteSeries deletes the series, its books stay in the catalogue
+func (s *Service) DeleteSeries(id int64) (err error) {
+	return s.repo.DeleteSeries(id)
+}
+
+// AddSeriesBook puts a book in the series, at the end unless a position is
+// given
+func (s *Service) AddSeriesBook(in model.SeriesEntry) (res model.SeriesEntry, err error) {
+	in.ID = 0
+	return s.repo.AddSeriesEntry(in)
+}
+
+func (s *Service) MoveSeriesBook(seriesID, bookID int64, position int) (res model.SeriesEntry, err error) {
+	return s.repo.MoveSeriesEntry(seriesID, bookID, position)
+}
+
+func (s *Service) RemoveSeriesBook(seriesID, bookID int64) (err error) {
+	return s.repo.RemoveSeriesEntry(seriesID, bookID)
+}
+
+// GetSeriesOfBooks tells where each of the books stands in its series, keyed
+// by book
+func (s *Service) GetSeriesOfBooks(bookIDs []int64) (map[int64][]model.BookSeries, error) {
+	return s.repo.GetSeriesOfBooks(bookIDs)
+}
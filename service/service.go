@@ -1,15 +1,60 @@
 package service
 
-import "ninth-learn/repository"
+import (
+	"ninth-learn/model"
+	"ninth-learn/recommend"
+	"ninth-learn/repository"
+	"ninth-learn/webhook"
+)
 
 type Service struct {
-	repo repository.RepoInterface
+	repo     repository.RepoInterface
+	policy   model.LoanPolicy
+	similar  *recommend.Index
+	feed     *bookFeed
+	sender   WebhookSender
+	purger   CachePurger
+	notifier ChangeNotifier
+	tenantID int64
 }
 
 type ServiceInterface interface {
 	BookService
+	TenantService
+	AuthorService
+	CategoryService
+	TagService
+	CoverService
+	CopyService
+	MemberService
+	LoanService
+	HoldService
+	FineService
+	ReviewService
+	ReadingListService
+	DuplicateService
+	SimilarService
+	TranslationService
+	SeriesService
+	RelationService
+	BookFeedService
+	WebhookService
+
+	// WithTenant returns a service that only sees the given tenant's data
+	WithTenant(tenantID int64) ServiceInterface
+}
+
+func NewService(repo repository.RepoInterface, policy model.LoanPolicy, opts ...Option) ServiceInterface {
+	s := &Service{repo: repo, policy: policy, similar: recommend.NewIndex(), feed: newBookFeed(), sender: webhook.NewSender(webhookTimeout)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-func NewService(repo repository.RepoInterface) ServiceInterface {
-	return &Service{repo: repo}
+func (s *Service) WithTenant(tenantID int64) ServiceInterface {
+	scoped := *s
+	scoped.repo = s.repo.WithTenant(tenantID)
+	scoped.tenantID = tenantID
+	return &scoped
 }
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"ninth-learn/repository"
+	"ninth-learn/repository/mocks"
+)
+
+// mockRepo stitches the per-entity mocks into a repository.RepoInterface,
+// tests only set the mocks they expect calls on
+type mockRepo struct {
+	*mocks.MockBookRepo
+	*mocks.MockTenantRepo
+	*mocks.MockAuthorRepo
+	*mocks.MockCategoryRepo
+	*mocks.MockTagRepo
+	*mocks.MockCoverRepo
+	*mocks.MockCopyRepo
+	*mocks.MockMemberRepo
+	*mocks.MockLoanRepo
+	*mocks.MockHoldRepo
+	*mocks.MockFineRepo
+	*mocks.MockReviewRepo
+	*mocks.MockReadingListRepo
+	*mocks.MockDuplicateRepo
+	*mocks.MockTranslationRepo
+	*mocks.MockSeriesRepo
+	*mocks.MockRelationRepo
+	*mocks.MockWebhookRepo
+	*mocks.MockOutboxRepo
+}
+
+func (m mockRepo) WithTenant(tenantID int64) repository.RepoInterface {
+	return m
+}
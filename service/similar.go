@@ -0,0 +1,89 @@
+package service
+
+import (
+	"ninth-learn/model"
+	"ninth-learn/recommend"
+)
+
+// similarLoadAttempts bounds the reads of a tenant's books while writes keep
+// overtaking them, the books are then ranked on the next call
+const similarLoadAttempts = 3
+
+type SimilarService interface {
+	GetSimilarBooks(id int64, limit int) ([]model.SimilarBook, error)
+}
+
+// GetSimilarBooks ranks the books of the catalogue by how close their title
+// and description are to the given book's, and by the authors, tags and
+// categories they share. the tenant's index is built on first use.
+func (s *Service) GetSimilarBooks(id int64, limit int) ([]model.SimilarBook, error) {
+	book, err := s.repo.GetBookById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// a load overtaken by a write is read again, the corpus it read lacks
+	// the write
+	for attempt := 0; attempt < similarLoadAttempts && !s.similar.Loaded(s.tenantID); attempt++ {
+		version := s.similar.Version(s.tenantID)
+		books, err := s.repo.GetBooks(model.BookFilter{})
+		if err != nil {
+			return nil, err
+		}
+
+		docs := make([]recommend.Document, 0, len(books))
+		for _, b := range books {
+			docs = append(docs, bookDocument(b))
+		}
+		s.similar.Load(s.tenantID, docs, version)
+	}
+
+	results := s.similar.Similar(s.tenantID, book.ID, limit)
+	if len(results) == 0 {
+		return []model.SimilarBook{}, nil
+	}
+
+	ids := make([]int64, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+	books, err := s.repo.GetBooksByIds(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]model.Book, len(books))
+	for _, b := range books {
+		byID[b.ID] = b
+	}
+	similar := make([]model.SimilarBook, 0, len(results))
+	for _, r := range results {
+		if b, ok := byID[r.ID]; ok {
+			similar = append(similar, model.SimilarBook{Book: b, Similarity: r.Score})
+		}
+	}
+	return similar, nil
+}
+
+// indexBook brings the similarity index up to date with a created or
+// updated book
+func (s *Service) indexBook(book model.Book) {
+	s.similar.Upsert(s.tenantID, bookDocument(book))
+}
+
+func bookDocument(b model.Book) recommend.Document {
+	doc := recommend.Document{
+		ID:    b.ID,
+		Terms: recommend.Terms(b.Title, b.Subtitle, b.Description),
+	}
+	for _, a := range b.Authors {
+		doc.Authors = append(doc.Authors, a.AuthorID)
+	}
+	for _, t := range b.Tags {
+		doc.Tags = append(doc.Tags, t.ID)
+	}
+	for _, c := range b.Categories {
+		doc.Categories = append(doc.Categories, c.ID)
+	}
+	return doc
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"ninth-learn/model"
+	"ninth-learn/recommend"
+	"ninth-learn/repository/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SimilarService_GetSimilarBooks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	bookRepo := mocks.NewMockBookRepo(mockCtrl)
+
+	books := []model.Book{
+		{ID: 1, Title: "Dune", Description: "A desert planet and the spice", Tags: []model.Tag{{ID: 1}}},
+		{ID: 2, Title: "Dune Messiah", Description: "The emperor of the desert planet", Tags: []model.Tag{{ID: 1}}},
+		{ID: 3, Title: "Emma", Description: "Matchmaking in a country village"},
+	}
+	bookRepo.EXPECT().GetBookById(int64(1)).Return(books[0], nil).Times(2)
+	// the index is loaded once per tenant
+	bookRepo.EXPECT().GetBooks(model.BookFilter{}).Return(books, nil).Times(1)
+	bookRepo.EXPECT().GetBooksByIds([]int64{2}).Return([]model.Book{books[1]}, nil).Times(2)
+
+	service := Service{repo: mockRepo{MockBookRepo: bookRepo}, similar: recommend.NewIndex(), tenantID: 1}
+
+	for i := 0; i < 2; i++ {
+		res, err := service.GetSimilarBooks(1, 10)
+		assert.Nil(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, int64(2), res[0].ID)
+		assert.Greater(t, res[0].Similarity, 0.0)
+	}
+}
+
+func Test_SimilarService_GetSimilarBooks_Overtaken(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	bookRepo := mocks.NewMockBookRepo(mockCtrl)
+
+	dune := model.Book{ID: 1, Title: "Dune", Description: "A desert planet and the spice"}
+	messiah := model.Book{ID: 2, Title: "Dune Messiah", Description: "The emperor of the desert planet"}
+	index := recommend.NewIndex()
+	bookRepo.EXPECT().GetBookById(int64(1)).Return(dune, nil).Times(1)
+	// the book is written while the first read is under way, the corpus
+	// without it is dropped and read again
+	gomock.InOrder(
+		bookRepo.EXPECT().GetBooks(model.BookFilter{}).DoAndReturn(func(model.BookFilter) ([]model.Book, error) {
+			index.Upsert(1, bookDocument(messiah))
+			return []model.Book{dune}, nil
+		}),
+		bookRepo.EXPECT().GetBooks(model.BookFilter{}).Return([]model.Book{dune, messiah}, nil),
+	)
+	bookRepo.EXPECT().GetBooksByIds([]int64{2}).Return([]model.Book{messiah}, nil).Times(1)
+
+	service := Service{repo: mockRepo{MockBookRepo: bookRepo}, similar: index, tenantID: 1}
+
+	res, err := service.GetSimilarBooks(1, 10)
+	assert.Nil(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, int64(2), res[0].ID)
+}
+
+func Test_SimilarService_UpdateBook(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	bookRepo := mocks.NewMockBookRepo(mockCtrl)
+
+	updated := model.Book{ID: 3, Title: "Children of Dune", Description: "The desert planet after the emperor"}
+	bookRepo.EXPECT().UpdateBook(gomock.Any()).Return(updated, nil).Times(1)
+
+	index := recommend.NewIndex()
+	index.Load(1, []recommend.Document{
+		{ID: 1, Terms: recommend.Terms("Dune", "A desert planet and the spice")},
+		{ID: 3, Terms: recommend.Terms("Emma", "Matchmaking in a country village")},
+	}, index.Version(1))
+	assert.Empty(t, index.Similar(1, 1, 10))
+
+	service := Service{repo: mockRepo{MockBookRepo: bookRepo}, similar: index, tenantID: 1}
+
+	_, err := service.UpdateBook(updated)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), index.Similar(1, 1, 10)[0].ID)
+}
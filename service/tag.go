@@ -0,0 +1,37 @@
+package service
+
+import "ninth-learn/model"
+
+type TagService interface {
+	GetTags() ([]model.TagUsage, error)
+	CreateTag(in model.Tag) (res model.Tag, err error)
+	UpdateTag(in model.Tag) (res model.Tag, err error)
+	DeleteTag(id int64) (err error)
+}
+
+func (s Service) GetTags() ([]model.TagUsage, error) {
+	return s.repo.GetTags()
+}
+
+func (s *Service) CreateTag(in model.Tag) (res model.Tag, err error) {
+	return s.repo.CreateTag(in)
+}
+
+// UpdateTag purges the books showing the tag
+func (s *Service) UpdateTag(in model.Tag) (res model.Tag, err error) {
+	res, bookIDs, err := s.repo.UpdateTag(in)
+	if err != nil {
+		return res, err
+	}
+	s.bookChanged(bookIDs...)
+	return res, nil
+}
+
+func (s *Service) DeleteTag(id int64) (err error) {
+	bookIDs, err := s.repo.DeleteTag(id)
+	if err != nil {
+		return err
+	}
+	s.bookChanged(bookIDs...)
+	return nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"errors"
+	"ninth-learn/model"
+)
+
+var ErrTenantSuspended = errors.New("tenant is suspended")
+
+type TenantService interface {
+	GetTenants() ([]model.Tenant, error)
+	CreateTenant(in model.Tenant) (res model.Tenant, err error)
+	ResolveTenant(slug string) (res model.Tenant, err error)
+	SuspendTenant(id int64) (res model.Tenant, err error)
+	ActivateTenant(id int64) (res model.Tenant, err error)
+}
+
+func (s Service) GetTenants() ([]model.Tenant, error) {
+	return s.repo.GetTenants()
+}
+
+func (s *Service) CreateTenant(in model.Tenant) (res model.Tenant, err error) {
+	return s.repo.CreateTenant(in)
+}
+
+// ResolveTenant looks up a tenant by slug and refuses suspended ones
+func (s *Service) ResolveTenant(slug string) (res model.Tenant, err error) {
+	res, err = s.repo.GetTenantBySlug(slug)
+	if err != nil {
+		return res, err
+	}
+
+	if !res.IsActive() {
+		return res, ErrTenantSuspended
+	}
+
+	return res, nil
+}
+
+func (s *Service) SuspendTenant(id int64) (res model.Tenant, err error) {
+	return s.repo.UpdateTenantStatus(id, model.TenantStatusSuspended)
+}
+
+func (s *Service) ActivateTenant(id int64) (res model.Tenant, err error) {
+	return s.repo.UpdateTenantStatus(id, model.TenantStatusActive)
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"errors"
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TenantService_ResolveTenant(t *testing.T) {
+	type testCase struct {
+		name           string
+		slug           string
+		wantError      bool
+		expectedResult model.Tenant
+		expectedError  error
+		onTenantRepo   func(mock *mocks.MockTenantRepo)
+	}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "active tenant",
+		slug:      "city-library",
+		wantError: false,
+		onTenantRepo: func(mock *mocks.MockTenantRepo) {
+			mock.EXPECT().GetTenantBySlug("city-library").Return(model.Tenant{
+				ID:     1,
+				Slug:   "city-library",
+				Name:   "City Library",
+				Status: model.TenantStatusActive,
+			}, nil).Times(1)
+		},
+		expectedResult: model.Tenant{
+			ID:     1,
+			Slug:   "city-library",
+			Name:   "City Library",
+			Status: model.TenantStatusActive,
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "suspended tenant",
+		slug:          "old-library",
+		wantError:     true,
+		expectedError: ErrTenantSuspended,
+		onTenantRepo: func(mock *mocks.MockTenantRepo) {
+			mock.EXPECT().GetTenantBySlug("old-library").Return(model.Tenant{
+				ID:     2,
+				Slug:   "old-library",
+				Name:   "Old Library",
+				Status: model.TenantStatusSuspended,
+			}, nil).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "record not found",
+		slug:          "nowhere",
+		wantError:     true,
+		expectedError: errors.New("record not found"),
+		onTenantRepo: func(mock *mocks.MockTenantRepo) {
+			mock.EXPECT().GetTenantBySlug("nowhere").Return(model.Tenant{}, errors.New("record not found")).Times(1)
+		},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			tenantRepo := mocks.NewMockTenantRepo(mockCtrl)
+
+			if testCase.onTenantRepo != nil {
+				testCase.onTenantRepo(tenantRepo)
+			}
+
+			service := Service{
+				repo: mockRepo{MockTenantRepo: tenantRepo},
+			}
+
+			res, err := service.ResolveTenant(testCase.slug)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, testCase.expectedResult, res)
+			}
+		})
+	}
+}
+
+func Test_TenantService_SuspendTenant(t *testing.T) {
+	type testCase struct {
+		name           string
+		id             int64
+		wantError      bool
+		expectedResult model.Tenant
+		expectedError  error
+		onTenantRepo   func(mock *mocks.MockTenantRepo)
+	}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "success",
+		id:        1,
+		wantError: false,
+		onTenantRepo: func(mock *mocks.MockTenantRepo) {
+			mock.EXPECT().UpdateTenantStatus(int64(1), model.TenantStatusSuspended).Return(model.Tenant{
+				ID:     1,
+				Slug:   "city-library",
+				Name:   "City Library",
+				Status: model.TenantStatusSuspended,
+			}, nil).Times(1)
+		},
+		expectedResult: model.Tenant{
+			ID:     1,
+			Slug:   "city-library",
+			Name:   "City Library",
+			Status: model.TenantStatusSuspended,
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "record not found",
+		id:            2,
+		wantError:     true,
+		expectedError: errors.New("record not found"),
+		onTenantRepo: func(mock *mocks.MockTenantRepo) {
+			mock.EXPECT().UpdateTenantStatus(int64(2), model.TenantStatusSuspended).Return(model.Tenant{}, errors.New("record not found")).Times(1)
+		},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			tenantRepo := mocks.NewMockTenantRepo(mockCtrl)
+
+			if testCase.onTenantRepo != nil {
+				testCase.onTenantRepo(tenantRepo)
+			}
+
+			service := Service{
+				repo: mockRepo{MockTenantRepo: tenantRepo},
+			}
+
+			res, err := service.SuspendTenant(testCase.id)
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, testCase.expectedResult, res)
+			}
+		})
+	}
+}
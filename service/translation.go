@@ -0,0 +1,94 @@
+package service
+
+import (
+	"ninth-learn/model"
+
+	"golang.org/x/text/language"
+)
+
+type TranslationService interface {
+	GetBookTranslations(bookID int64) ([]model.BookTranslation, error)
+	PutBookTranslation(bookID int64, tag string, in model.BookTranslation) (res model.BookTranslation, err error)
+	DeleteBookTranslation(bookID int64, tag string) (err error)
+	GetLocalizedBook(id int64, acceptLanguage string) (res model.Book, err error)
+}
+
+func (s *Service) GetBookTranslations(bookID int64) ([]model.BookTranslation, error) {
+	if _, err := s.repo.GetBookById(bookID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetBookTranslations(bookID)
+}
+
+// PutBookTranslation adds the book's translation in the language, or
+// replaces the one it already has
+func (s *Service) PutBookTranslation(bookID int64, tag string, in model.BookTranslation) (res model.BookTranslation, err error) {
+	in.Language, err = model.CanonicalLanguageTag(tag)
+	if err != nil {
+		return res, err
+	}
+	in.ID = 0
+	in.BookID = bookID
+
+	res, err = s.repo.SaveBookTranslation(in)
+	if err != nil {
+		return res, err
+	}
+	s.purgeBooks(bookID)
+	return res, nil
+}
+
+func (s *Service) DeleteBookTranslation(bookID int64, tag string) (err error) {
+	lang, err := model.CanonicalLanguageTag(tag)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteBookTranslation(bookID, lang); err != nil {
+		return err
+	}
+	s.purgeBooks(bookID)
+	return nil
+}
+
+// GetLocalizedBook returns the book with the title and description of the
+// translation that best matches the Accept-Language header. the book's own
+// text is kept when no translation matches.
+func (s *Service) GetLocalizedBook(id int64, acceptLanguage string) (res model.Book, err error) {
+	res, err = s.repo.GetBookById(id)
+	if err != nil {
+		return res, err
+	}
+	res.DisplayLanguage = res.Language
+
+	preferred, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(preferred) == 0 {
+		return res, nil
+	}
+
+	translations, err := s.repo.GetBookTranslations(res.ID)
+	if err != nil || len(translations) == 0 {
+		return res, err
+	}
+
+	// the book's own language comes first, so it is the fallback
+	original := language.Und
+	if tag, err := language.Parse(res.Language); err == nil {
+		original = tag
+	}
+	supported := []language.Tag{original}
+	for _, t := range translations {
+		supported = append(supported, language.Make(t.Language))
+	}
+
+	_, index, confidence := language.NewMatcher(supported).Match(preferred...)
+	if confidence == language.No || index == 0 {
+		return res, nil
+	}
+
+	t := translations[index-1]
+	res.Title = t.Title
+	res.Subtitle = t.Subtitle
+	res.Description = t.Description
+	res.DisplayLanguage = t.Language
+	return res, nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TranslationService_GetLocalizedBook(t *testing.T) {
+	type testCase struct {
+		name             string
+		acceptLanguage   string
+		expectedTitle    string
+		expectedLanguage string
+	}
+
+	book := model.Book{ID: 1, Title: "The Great Gatsby", Language: "en"}
+	translations := []model.BookTranslation{
+		{BookID: 1, Language: "de", Title: "Der große Gatsby"},
+		{BookID: 1, Language: "pt-BR", Title: "O Grande Gatsby"},
+	}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:             "exact match",
+		acceptLanguage:   "de-DE,de;q=0.9,en;q=0.5",
+		expectedTitle:    "Der große Gatsby",
+		expectedLanguage: "de",
+	})
+
+	testTable = append(testTable, testCase{
+		name:             "regional variant",
+		acceptLanguage:   "pt",
+		expectedTitle:    "O Grande Gatsby",
+		expectedLanguage: "pt-BR",
+	})
+
+	testTable = append(testTable, testCase{
+		name:             "original preferred",
+		acceptLanguage:   "en-GB,de;q=0.8",
+		expectedTitle:    "The Great Gatsby",
+		expectedLanguage: "en",
+	})
+
+	testTable = append(testTable, testCase{
+		name:             "falls back to the original",
+		acceptLanguage:   "ja",
+		expectedTitle:    "The Great Gatsby",
+		expectedLanguage: "en",
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			bookRepo := mocks.NewMockBookRepo(mockCtrl)
+			bookRepo.EXPECT().GetBookById(int64(1)).Return(book, nil).Times(1)
+			translationRepo := mocks.NewMockTranslationRepo(mockCtrl)
+			translationRepo.EXPECT().GetBookTranslations(int64(1)).Return(translations, nil).Times(1)
+
+			service := Service{repo: mockRepo{MockBookRepo: bookRepo, MockTranslationRepo: translationRepo}}
+
+			res, err := service.GetLocalizedBook(1, testCase.acceptLanguage)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedTitle, res.Title)
+			assert.Equal(t, testCase.expectedLanguage, res.DisplayLanguage)
+		})
+	}
+}
+
+func Test_TranslationService_GetLocalizedBook_NoPreference(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	bookRepo := mocks.NewMockBookRepo(mockCtrl)
+	bookRepo.EXPECT().GetBookById(int64(1)).Return(model.Book{ID: 1, Title: "The Great Gatsby", Language: "en"}, nil).Times(1)
+
+	service := Service{repo: mockRepo{MockBookRepo: bookRepo}}
+
+	res, err := service.GetLocalizedBook(1, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "The Great Gatsby", res.Title)
+	assert.Equal(t, "en", res.DisplayLanguage)
+}
+
+func Test_TranslationService_PutBookTranslation(t *testing.T) {
+	type testCase struct {
+		name          string
+		wantError     bool
+		tag           string
+		expectedError error
+		onRepo        func(mock *mocks.MockTranslationRepo)
+	}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:      "canonical tag",
+		wantError: false,
+		tag:       "PT_br",
+		onRepo: func(mock *mocks.MockTranslationRepo) {
+			mock.EXPECT().SaveBookTranslation(model.BookTranslation{BookID: 1, Language: "pt-BR", Title: "O Grande Gatsby"}).
+				Return(model.BookTranslation{ID: 1, BookID: 1, Language: "pt-BR"}, nil).Times(1)
+		},
+	})
+
+	testTable = append(testTable, testCase{
+		name:          "invalid tag",
+		wantError:     true,
+		tag:           "not a language",
+		expectedError: model.ErrInvalidLanguageTag,
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			translationRepo := mocks.NewMockTranslationRepo(mockCtrl)
+
+			if testCase.onRepo != nil {
+				testCase.onRepo(translationRepo)
+			}
+
+			service := Service{repo: mockRepo{MockTranslationRepo: translationRepo}}
+
+			_, err := service.PutBookTranslation(1, testCase.tag, model.BookTranslation{Title: "O Grande Gatsby"})
+
+			if testCase.wantError {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"ninth-learn/model"
+	"ninth-learn/outbox"
+	"ninth-learn/webhook"
+	"strconv"
+	"time"
+)
+
+const (
+	// webhookTimeout bounds a single attempt
+	webhookTimeout = 10 * time.Second
+	// webhookBatch is how many deliveries one run claims at a time
+	webhookBatch = 20
+	// webhookBackoff is the wait before the first retry, it doubles after
+	// every failed attempt
+	webhookBackoff = 30 * time.Second
+)
+
+// webhookLease covers sending a whole batch, the deliveries of an instance
+// that dies on the way are retried once it runs out
+const webhookLease = webhookBatch*webhookTimeout + time.Minute
+
+type WebhookSender interface {
+	Send(ctx context.Context, r webhook.Request) (status int, err error)
+}
+
+type WebhookService interface {
+	GetWebhooks() ([]model.Webhook, error)
+	GetWebhookById(id int64) (model.Webhook, error)
+	CreateWebhook(in model.Webhook) (res model.Webhook, err error)
+	UpdateWebhook(in model.Webhook) (res model.Webhook, err error)
+	DeleteWebhook(id int64) (err error)
+	GetWebhookDeliveries(webhookID int64) ([]model.WebhookDelivery, error)
+	RedeliverWebhook(webhookID, deliveryID int64) (res model.WebhookDelivery, err error)
+	// DeliverWebhooks sends the deliveries that are due, of every tenant
+	DeliverWebhooks() (delivered int64, err error)
+	// QueueWebhooks is handed the book events by the outbox relay
+	QueueWebhooks(ctx context.Context, m outbox.Message) error
+}
+
+// GetWebhooks never shows the secrets, they are only returned on create
+func (s *Service) GetWebhooks() ([]model.Webhook, error) {
+	webhooks, err := s.repo.GetWebhooks()
+	if err != nil {
+		return nil, err
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return webhooks, nil
+}
+
+func (s *Service) GetWebhookById(id int64) (model.Webhook, error) {
+	res, err := s.repo.GetWebhookById(id)
+	res.Secret = ""
+	return res, err
+}
+
+// CreateWebhook generates a secret when none is given, the response is the
+// only place it is shown
+func (s *Service) CreateWebhook(in model.Webhook) (res model.Webhook, err error) {
+	if in.Secret == "" {
+		in.Secret, err = newShareToken()
+		if err != nil {
+			return res, err
+		}
+	}
+
+	return s.repo.CreateWebhook(in)
+}
+
+// UpdateWebhook keeps the secret when none is given
+func (s *Service) UpdateWebhook(in model.Webhook) (res model.Webhook, err error) {
+	if in.Secret == "" {
+		current, err := s.repo.GetWebhookById(in.ID)
+		if err != nil {
+			return res, err
+		}
+		in.Secret = current.Secret
+	}
+
+	res, err = s.repo.UpdateWebhook(in)
+	res.Secret = ""
+	return res, err
+}
+
+func (s *Service) DeleteWebhook(id int64) (err error) {
+	return s.repo.DeleteWebhook(id)
+}
+
+func (s *Service) GetWebhookDeliveries(webhookID int64) ([]model.WebhookDelivery, error) {
+	return s.repo.GetWebhookDeliveries(webhookID)
+}
+
+// RedeliverWebhook sends the payload of a delivery again right away, as a
+// delivery of its own that is retried like any other
+func (s *Service) RedeliverWebhook(webhookID, deliveryID int64) (res model.WebhookDelivery, err error) {
+	previous, err := s.repo.GetWebhookDeliveryById(webhookID, deliveryID)
+	if err != nil {
+		return res, err
+	}
+
+	now := time.Now()
+	created, err := s.repo.CreateWebhookDeliveries([]model.WebhookDelivery{{
+		WebhookID:     previous.WebhookID,
+		EventID:       previous.EventID,
+		Event:         previous.Event,
+		Payload:       previous.Payload,
+		Status:        model.DeliveryStatusPending,
+		NextAttemptAt: now.Add(webhookLease),
+	}})
+	if err != nil {
+		return res, err
+	}
+
+	res = created[0]
+	res.Webhook = previous.Webhook
+	s.attemptDelivery(&res, now)
+	if err := s.repo.SaveWebhookDelivery(res); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+func (s *Service) DeliverWebhooks() (delivered int64, err error) {
+	for {
+		now := time.Now()
+		deliveries, err := s.repo.ClaimWebhookDeliveries(now, webhookBatch, webhookLease)
+		if err != nil {
+			return delivered, err
+		}
+
+		for i := range deliveries {
+			s.attemptDelivery(&deliveries[i], now)
+			if err := s.repo.SaveWebhookDelivery(deliveries[i]); err != nil {
+				return delivered, err
+			}
+			if deliveries[i].Status == model.DeliveryStatusSucceeded {
+				delivered++
+			}
+		}
+
+		if len(deliveries) < webhookBatch {
+			return delivered, nil
+		}
+	}
+}
+
+// attemptDelivery sends the delivery once and records the outcome on it, a
+// failed attempt is retried with exponential backoff until it runs out of
+// attempts
+func (s *Service) attemptDelivery(d *model.WebhookDelivery, now time.Time) {
+	d.Attempts++
+
+	if d.Webhook == nil || !d.Webhook.Active {
+		d.Status = model.DeliveryStatusFailed
+		d.LastStatusCode = 0
+		d.LastError = "webhook is inactive"
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+	status, err := s.sender.Send(ctx, webhook.Request{
+		URL:        d.Webhook.URL,
+		Secret:     d.Webhook.Secret,
+		Event:      d.Event,
+		DeliveryID: strconv.FormatInt(d.ID, 10),
+		Body:       []byte(d.Payload),
+	})
+	d.LastStatusCode = status
+
+	if err == nil {
+		delivered := time.Now()
+		d.Status = model.DeliveryStatusSucceeded
+		d.LastError = ""
+		d.DeliveredAt = &delivered
+		return
+	}
+
+	d.LastError = err.Error()
+	if d.Attempts >= model.WebhookMaxAttempts {
+		d.Status = model.DeliveryStatusFailed
+		return
+	}
+	d.Status = model.DeliveryStatusPending
+	d.NextAttemptAt = now.Add(webhookBackoff << (d.Attempts - 1))
+}
+
+// QueueWebhooks queues a book event the outbox relay publishes for the
+// webhooks of its tenant subscribed to it. the delivery carries the id of
+// the outbox event, so receivers drop the repeats of a relay that retries
+func (s *Service) QueueWebhooks(ctx context.Context, m outbox.Message) error {
+	if s.sender == nil {
+		return nil
+	}
+	e, ok, err := bookEventOf(m)
+	if !ok {
+		return err
+	}
+
+	repo := s.repo.WithTenant(m.TenantID)
+	webhooks, err := repo.GetActiveWebhooks(e.Type)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	eventID := strconv.FormatInt(m.ID, 10)
+	payload, err := json.Marshal(model.WebhookPayload{
+		ID:         eventID,
+		Type:       e.Type,
+		BookID:     e.BookID,
+		Book:       e.Book,
+		OccurredAt: e.OccurredAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]model.WebhookDelivery, 0, len(webhooks))
+	for _, w := range webhooks {
+		deliveries = append(deliveries, model.WebhookDelivery{
+			WebhookID:     w.ID,
+			EventID:       eventID,
+			Event:         e.Type,
+			Payload:       string(payload),
+			Status:        model.DeliveryStatusPending,
+			NextAttemptAt: e.OccurredAt,
+		})
+	}
+	_, err = repo.CreateWebhookDeliveries(deliveries)
+	return err
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"ninth-learn/model"
+	"ninth-learn/repository/mocks"
+	"ninth-learn/webhook"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSender answers every delivery with the same status
+type fakeSender struct {
+	status int
+	err    error
+	sent   []webhook.Request
+}
+
+func (f *fakeSender) Send(ctx context.Context, r webhook.Request) (int, error) {
+	f.sent = append(f.sent, r)
+	return f.status, f.err
+}
+
+func Test_WebhookService_CreateWebhook(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	webhookRepo := mocks.NewMockWebhookRepo(mockCtrl)
+	webhookRepo.EXPECT().CreateWebhook(gomock.Any()).DoAndReturn(func(in model.Webhook) (model.Webhook, error) {
+		in.ID = 1
+		return in, nil
+	}).Times(2)
+
+	service := Service{repo: mockRepo{MockWebhookRepo: webhookRepo}}
+
+	res, err := service.CreateWebhook(model.Webhook{URL: "https://example.com/hooks", Events: []string{model.BookCreated}})
+	assert.Nil(t, err)
+	assert.Len(t, res.Secret, 32)
+
+	res, err = service.CreateWebhook(model.Webhook{URL: "https://example.com/hooks", Events: []string{model.BookCreated}, Secret: "chosen-by-the-receiver"})
+	assert.Nil(t, err)
+	assert.Equal(t, "chosen-by-the-receiver", res.Secret)
+}
+
+func Test_WebhookService_UpdateWebhook(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	webhookRepo := mocks.NewMockWebhookRepo(mockCtrl)
+	webhookRepo.EXPECT().GetWebhookById(int64(1)).Return(model.Webhook{ID: 1, Secret: "kept-from-before-1234"}, nil).Times(1)
+	webhookRepo.EXPECT().UpdateWebhook(model.Webhook{ID: 1, URL: "https://example.com/v2", Events: []string{model.BookDeleted}, Secret: "kept-from-before-1234"}).
+		Return(model.Webhook{ID: 1, URL: "https://example.com/v2", Events: []string{model.BookDeleted}, Secret: "kept-from-before-1234"}, nil).Times(1)
+
+	service := Service{repo: mockRepo{MockWebhookRepo: webhookRepo}}
+
+	res, err := service.UpdateWebhook(model.Webhook{ID: 1, URL: "https://example.com/v2", Events: []string{model.BookDeleted}})
+	assert.Nil(t, err)
+	assert.Empty(t, res.Secret)
+}
+
+func Test_WebhookService_DeliverWebhooks(t *testing.T) {
+	hook := &model.Webhook{ID: 1, URL: "https://example.com/hooks", Secret: "secret", Active: true}
+
+	type testCase struct {
+		name          string
+		delivery      model.WebhookDelivery
+		sender        *fakeSender
+		wantStatus    string
+		wantAttempts  int
+		wantBackoff   time.Duration
+		wantDelivered int64
+	}
+
+	var testTable []testCase
+
+	testTable = append(testTable, testCase{
+		name:          "success",
+		delivery:      model.WebhookDelivery{ID: 9, Event: model.BookCreated, Payload: `{}`, Webhook: hook},
+		sender:        &fakeSender{status: 204},
+		wantStatus:    model.DeliveryStatusSucceeded,
+		wantAttempts:  1,
+		wantDelivered: 1,
+	})
+
+	testTable = append(testTable, testCase{
+		name:         "first failure",
+		delivery:     model.WebhookDelivery{ID: 9, Event: model.BookCreated, Payload: `{}`, Webhook: hook},
+		sender:       &fakeSender{status: 500, err: errors.New("receiver answered 500")},
+		wantStatus:   model.DeliveryStatusPending,
+		wantAttempts: 1,
+		wantBackoff:  30 * time.Second,
+	})
+
+	testTable = append(testTable, testCase{
+		name:         "third failure",
+		delivery:     model.WebhookDelivery{ID: 9, Event: model.BookCreated, Payload: `{}`, Attempts: 2, Webhook: hook},
+		sender:       &fakeSender{status: 500, err: errors.New("receiver answered 500")},
+		wantStatus:   model.DeliveryStatusPending,
+		wantAttempts: 3,
+		wantBackoff:  2 * time.Minute,
+	})
+
+	testTable = append(testTable, testCase{
+		name:         "out of attempts",
+		delivery:     model.WebhookDelivery{ID: 9, Event: model.BookCreated, Payload: `{}`, Attempts: model.WebhookMaxAttempts - 1, Webhook: hook},
+		sender:       &fakeSender{err: errors.New("connection refused")},
+		wantStatus:   model.DeliveryStatusFailed,
+		wantAttempts: model.WebhookMaxAttempts,
+	})
+
+	testTable = append(testTable, testCase{
+		name:         "inactive webhook",
+		delivery:     model.WebhookDelivery{ID: 9, Event: model.BookCreated, Payload: `{}`, Webhook: &model.Webhook{ID: 1}},
+		sender:       &fakeSender{},
+		wantStatus:   model.DeliveryStatusFailed,
+		wantAttempts: 1,
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			webhookRepo := mocks.NewMockWebhookRepo(mockCtrl)
+
+			var saved model.WebhookDelivery
+			var claimedAt time.Time
+			webhookRepo.EXPECT().ClaimWebhookDeliveries(gomock.Any(), webhookBatch, webhookLease).
+				DoAndReturn(func(now time.Time, limit int, lease time.Duration) ([]model.WebhookDelivery, error) {
+					claimedAt = now
+					return []model.WebhookDelivery{testCase.delivery}, nil
+				}).Times(1)
+			webhookRepo.EXPECT().SaveWebhookDelivery(gomock.Any()).DoAndReturn(func(in model.WebhookDelivery) error {
+				saved = in
+				return nil
+			}).Times(1)
+
+			service := Service{repo: mockRepo{MockWebhookRepo: webhookRepo}, sender: testCase.sender}
+
+			delivered, err := service.DeliverWebhooks()
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.wantDelivered, delivered)
+			assert.Equal(t, testCase.wantStatus, saved.Status)
+			assert.Equal(t, testCase.wantAttempts, saved.Attempts)
+			if testCase.wantBackoff > 0 {
+				assert.Equal(t, claimedAt.Add(testCase.wantBackoff), saved.NextAttemptAt)
+			}
+			if testCase.wantStatus == model.DeliveryStatusSucceeded {
+				assert.NotNil(t, saved.DeliveredAt)
+				assert.Equal(t, "9", testCase.sender.sent[0].DeliveryID)
+				assert.Equal(t, "secret", testCase.sender.sent[0].Secret)
+			}
+		})
+	}
+}
+
+func Test_WebhookService_RedeliverWebhook(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	webhookRepo := mocks.NewMockWebhookRepo(mockCtrl)
+	hook := &model.Webhook{ID: 1, URL: "https://example.com/hooks", Secret: "secret", Active: true}
+
+	webhookRepo.EXPECT().GetWebhookDeliveryById(int64(1), int64(9)).Return(model.WebhookDelivery{
+		ID: 9, WebhookID: 1, EventID: "abc", Event: model.BookDeleted, Payload: `{"id":"abc"}`,
+		Status: model.DeliveryStatusFailed, Attempts: model.WebhookMaxAttempts, Webhook: hook,
+	}, nil).Times(1)
+	webhookRepo.EXPECT().CreateWebhookDeliveries(gomock.Any()).DoAndReturn(func(in []model.WebhookDelivery) ([]model.WebhookDelivery, error) {
+		assert.Len(t, in, 1)
+		assert.Equal(t, "abc", in[0].EventID)
+		assert.Equal(t, 0, in[0].Attempts)
+		in[0].ID = 10
+		return in, nil
+	}).Times(1)
+	webhookRepo.EXPECT().SaveWebhookDelivery(gomock.Any()).Return(nil).Times(1)
+
+	sender := &fakeSender{status: 200}
+	service := Service{repo: mockRepo{MockWebhookRepo: webhookRepo}, sender: sender}
+
+	res, err := service.RedeliverWebhook(1, 9)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), res.ID)
+	assert.Equal(t, model.DeliveryStatusSucceeded, res.Status)
+	assert.Equal(t, []byte(`{"id":"abc"}`), sender.sent[0].Body)
+	assert.Equal(t, "10", sender.sent[0].DeliveryID)
+}
+
+func Test_WebhookService_QueueWebhooks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	webhookRepo := mocks.NewMockWebhookRepo(mockCtrl)
+
+	webhookRepo.EXPECT().GetActiveWebhooks(model.BookUpdated).Return([]model.Webhook{{ID: 1}, {ID: 2}}, nil).Times(1)
+	webhookRepo.EXPECT().CreateWebhookDeliveries(gomock.Any()).DoAndReturn(func(in []model.WebhookDelivery) ([]model.WebhookDelivery, error) {
+		assert.Len(t, in, 2)
+		assert.Equal(t, int64(1), in[0].WebhookID)
+		assert.Equal(t, int64(2), in[1].WebhookID)
+		// repeats of the relay carry the same id
+		assert.Equal(t, "7", in[0].EventID)
+		assert.Equal(t, in[0].EventID, in[1].EventID)
+		assert.Equal(t, model.DeliveryStatusPending, in[0].Status)
+
+		payload := model.WebhookPayload{}
+		assert.NoError(t, json.Unmarshal([]byte(in[0].Payload), &payload))
+		assert.Equal(t, in[0].EventID, payload.ID)
+		assert.Equal(t, model.BookUpdated, payload.Type)
+		assert.Equal(t, int64(5), payload.BookID)
+		assert.Equal(t, "Dune", payload.Book.Title)
+		return in, nil
+	}).Times(1)
+
+	service := Service{repo: mockRepo{MockWebhookRepo: webhookRepo}, sender: &fakeSender{}}
+	m := bookMessage(1, model.BookUpdated, 5, &model.Book{ID: 5, Title: "Dune"})
+	m.ID = 7
+	assert.NoError(t, service.QueueWebhooks(context.Background(), m))
+
+	// a failure is the relay's to retry
+	webhookRepo.EXPECT().GetActiveWebhooks(model.BookUpdated).Return(nil, errors.New("connection refused")).Times(1)
+	assert.Error(t, service.QueueWebhooks(context.Background(), m))
+
+	// without a sender webhooks are off, the repository is left alone
+	service.sender = nil
+	assert.NoError(t, service.QueueWebhooks(context.Background(), m))
+}
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// Blob is a stored object with its content type
+type Blob struct {
+	Data        []byte
+	ContentType string
+}
+
+// BlobStore keeps binary objects under slash separated keys
+type BlobStore interface {
+	Put(key string, blob Blob) error
+	Get(key string) (Blob, error)
+	Delete(key string) error
+}
+
+// validKey rejects keys that could escape the store root
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
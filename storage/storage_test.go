@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testBlobStore runs the behaviour every BlobStore must share
+func testBlobStore(t *testing.T, store BlobStore) {
+	_, err := store.Get("covers/1/1/original")
+	assert.Equal(t, ErrNotFound, err)
+
+	err = store.Put("covers/1/1/original", Blob{Data: []byte("\x89PNG\r\n\x1a\ndata"), ContentType: "image/png"})
+	assert.Nil(t, err)
+
+	blob, err := store.Get("covers/1/1/original")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("\x89PNG\r\n\x1a\ndata"), blob.Data)
+	assert.Equal(t, "image/png", blob.ContentType)
+
+	assert.Nil(t, store.Delete("covers/1/1/original"))
+	assert.Nil(t, store.Delete("covers/1/1/original"))
+
+	_, err = store.Get("covers/1/1/original")
+	assert.Equal(t, ErrNotFound, err)
+
+	assert.Equal(t, ErrInvalidKey, store.Put("../escape", Blob{}))
+}
+
+func Test_LocalStore(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	assert.Nil(t, err)
+
+	testBlobStore(t, store)
+}
+
+// fakeS3 is a minimal in-memory stand-in for an S3 bucket
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]Blob
+	auth    []string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = Blob{Data: data, ContentType: r.Header.Get("Content-Type")}
+	case http.MethodGet:
+		blob, ok := f.objects[r.URL.Path]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", blob.ContentType)
+		w.Write(blob.Data)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func Test_S3Store(t *testing.T) {
+	fake := &fakeS3{objects: map[string]Blob{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store := NewS3Store(S3Config{
+		Endpoint:  server.URL,
+		Region:    "eu-west-1",
+		Bucket:    "covers",
+		AccessKey: "AKIDEXAMPLE",
+		SecretKey: "secret",
+	})
+
+	testBlobStore(t, store)
+
+	assert.NotEmpty(t, fake.auth)
+	for _, auth := range fake.auth {
+		assert.True(t, strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), auth)
+		assert.Contains(t, auth, "/eu-west-1/s3/aws4_request")
+		assert.Contains(t, auth, "x-amz-content-sha256;x-amz-date")
+	}
+}
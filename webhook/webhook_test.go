@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Verify(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	body := []byte(`{"type":"book.created"}`)
+	header := Sign("secret", at, body)
+
+	assert.Equal(t, "t=1700000000,v1=", header[:16])
+	assert.NoError(t, Verify("secret", header, body, time.Minute, at.Add(30*time.Second)))
+
+	assert.ErrorIs(t, Verify("other", header, body, time.Minute, at), ErrBadSignature)
+	assert.ErrorIs(t, Verify("secret", header, []byte(`{}`), time.Minute, at), ErrBadSignature)
+	assert.ErrorIs(t, Verify("secret", "v1=abc", body, time.Minute, at), ErrBadSignature)
+	assert.ErrorIs(t, Verify("secret", header, body, time.Minute, at.Add(2*time.Minute)), ErrExpired)
+}
+
+func Test_Sender_Send(t *testing.T) {
+	var got *http.Request
+	var gotBody []byte
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	s := NewSender(time.Second)
+	req := Request{URL: server.URL, Secret: "secret", Event: "book.deleted", DeliveryID: "7", Body: []byte(`{"book_id":1}`)}
+
+	code, err := s.Send(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, code)
+	assert.Equal(t, "book.deleted", got.Header.Get(EventHeader))
+	assert.Equal(t, "7", got.Header.Get(DeliveryHeader))
+	assert.NoError(t, Verify("secret", got.Header.Get(SignatureHeader), gotBody, time.Minute, time.Now()))
+
+	status = http.StatusGone
+	code, err = s.Send(context.Background(), req)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusGone, code)
+
+	server.Close()
+	code, err = s.Send(context.Background(), req)
+	assert.Error(t, err)
+	assert.Equal(t, 0, code)
+}